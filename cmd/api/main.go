@@ -1,6 +1,7 @@
 package main
 
 import (
+	"fmt"
 	"log"
 	"os"
 	"os/signal"
@@ -13,9 +14,13 @@ import (
 	"github.com/hscHeric/go-potential-api/internal/handler"
 	"github.com/hscHeric/go-potential-api/internal/repository"
 	"github.com/hscHeric/go-potential-api/internal/router"
+	"github.com/hscHeric/go-potential-api/internal/scheduler"
 	"github.com/hscHeric/go-potential-api/internal/service"
+	"github.com/hscHeric/go-potential-api/pkg/cancellation"
+	"github.com/hscHeric/go-potential-api/pkg/cep"
 	"github.com/hscHeric/go-potential-api/pkg/email"
 	"github.com/hscHeric/go-potential-api/pkg/jwt"
+	"github.com/hscHeric/go-potential-api/pkg/timezone"
 
 	_ "github.com/hscHeric/go-potential-api/docs" // Import do Swagger
 )
@@ -71,6 +76,20 @@ func main() {
 	timeSlotRepo := repository.NewTimeSlotRepository(db)
 	classRepo := repository.NewClassRepository(db)
 	classStudentRepo := repository.NewClassStudentRepository(db)
+	teacherProfileRepo := repository.NewTeacherProfileRepository(db)
+	studentLevelRepo := repository.NewStudentLevelRepository(db)
+	bookingRepo := repository.NewBookingRepository(db)
+	classSeriesRepo := repository.NewClassSeriesRepository(db)
+	waitlistRepo := repository.NewWaitlistRepository(db)
+	rescheduleRepo := repository.NewClassRescheduleRepository(db)
+	blackoutRepo := repository.NewBlackoutRepository(db)
+	holidayRepo := repository.NewHolidayRepository(db)
+	calendarFeedRepo := repository.NewCalendarFeedRepository(db)
+	branchRepo := repository.NewBranchRepository(db)
+	roomRepo := repository.NewRoomRepository(db)
+	fileRepo := repository.NewFileRepository(db)
+	classReminderRepo := repository.NewClassReminderRepository(db)
+	creditRepo := repository.NewCreditRepository(db)
 
 	// Inicializar services
 	jwtService := jwt.NewService(cfg.JWT.Secret, cfg.JWT.ExpirationHours)
@@ -83,43 +102,220 @@ func main() {
 		cfg.Tokens.FrontendURL,
 	)
 
+	cepProvider, err := newCEPProvider(cfg)
+	if err != nil {
+		log.Fatalf("Failed to initialize CEP provider: %v", err)
+	}
+	addressService := service.NewAddressService(cepProvider)
+
 	authService := service.NewAuthService(
 		authRepo,
 		userRepo,
 		activationTokenRepo,
 		passwordResetRepo,
+		addressService,
 		jwtService,
 		emailService,
 		cfg.GetActivationTokenExpiration(),
 		cfg.GetPasswordResetTokenExpiration(),
 	)
 
-	userService := service.NewUserService(authRepo, userRepo)
+	holidayPolicy := service.HolidayPolicy(cfg.GetHolidayPolicy())
+	if !holidayPolicy.IsValid() {
+		log.Fatalf("Invalid holiday policy: %s", holidayPolicy)
+	}
+	holidayChecker := service.NewHolidayChecker(holidayRepo, holidayPolicy)
+
+	schoolLocation, err := timezone.Load(cfg.GetSchoolTimezone())
+	if err != nil {
+		log.Fatalf("Invalid school time zone %q: %v", cfg.GetSchoolTimezone(), err)
+	}
+	timezoneService := service.NewTimezoneService(userRepo, schoolLocation)
+
+	creditService := service.NewCreditService(
+		creditRepo,
+		classStudentRepo,
+		userRepo,
+		authRepo,
+		emailService,
+		timezoneService,
+		cfg.GetCreditLowBalance(),
+		cfg.GetCreditMakeupValidity(),
+	)
+
+	userService := service.NewUserService(authRepo, userRepo, studentLevelRepo, addressService, creditService)
+
+	timeSlotService := service.NewTimeSlotService(timeSlotRepo, classRepo, blackoutRepo, authRepo, holidayChecker, timezoneService)
+	blackoutService := service.NewBlackoutService(blackoutRepo, classRepo, authRepo)
 
-	timeSlotService := service.NewTimeSlotService(timeSlotRepo, authRepo)
+	teacherService := service.NewTeacherService(teacherProfileRepo, authRepo)
+	studentLevelService := service.NewStudentLevelService(studentLevelRepo, authRepo)
+
+	waitlistService := service.NewWaitlistService(
+		waitlistRepo,
+		classRepo,
+		classStudentRepo,
+		timeSlotRepo,
+		roomRepo,
+		userRepo,
+		authRepo,
+		creditService,
+		emailService,
+		cfg.GetWaitlistOfferExpiration(),
+		timezoneService,
+	)
+
+	cancellationPolicy := cancellation.Policy{
+		MinNotice:         cfg.GetCancellationMinNotice(),
+		StudentsMayCancel: cfg.Cancellation.StudentsMayCancel,
+		TeachersMayCancel: cfg.Cancellation.TeachersMayCancel,
+		LatePenalty:       cancellation.Penalty(cfg.GetCancellationLatePenalty()),
+	}
+	if err := cancellationPolicy.Validate(); err != nil {
+		log.Fatalf("Invalid cancellation policy: %v", err)
+	}
 
 	classService := service.NewClassService(
 		classRepo,
 		classStudentRepo,
 		timeSlotRepo,
+		bookingRepo,
+		waitlistRepo,
+		roomRepo,
+		userRepo,
+		authRepo,
+		waitlistService,
+		creditService,
+		emailService,
+		cancellationPolicy,
+		holidayChecker,
+		timezoneService,
+	)
+
+	classSeriesService := service.NewClassSeriesService(classSeriesRepo, classRepo, classStudentRepo, classService, holidayChecker, timezoneService)
+
+	rescheduleService := service.NewClassRescheduleService(
+		rescheduleRepo,
+		classRepo,
+		classStudentRepo,
+		roomRepo,
+		userRepo,
+		authRepo,
+		emailService,
+		holidayChecker,
+		timezoneService,
+	)
+
+	substitutionService := service.NewClassSubstitutionService(
+		classRepo,
+		classStudentRepo,
+		teacherProfileRepo,
+		userRepo,
+		authRepo,
+		emailService,
+		timezoneService,
+	)
+
+	roomService := service.NewRoomService(branchRepo, roomRepo, classRepo, classStudentRepo, authRepo, timezoneService)
+
+	holidayService := service.NewHolidayService(holidayRepo, classRepo, classService, rescheduleService)
+	calendarService := service.NewCalendarService(classRepo, blackoutRepo, authRepo, timeSlotService, holidayChecker)
+	calendarFeedService := service.NewCalendarFeedService(calendarFeedRepo, classRepo, authRepo, timezoneService, cfg.GetPublicURL())
+
+	lifecycleService := service.NewClassLifecycleService(
+		classRepo,
 		userRepo,
 		authRepo,
+		creditService,
 		emailService,
+		timezoneService,
+		cfg.GetAttendanceReminder(),
+		cfg.GetClassClose(),
 	)
 
+	reminderService := service.NewClassReminderService(
+		classReminderRepo,
+		classRepo,
+		fileRepo,
+		userRepo,
+		authRepo,
+		emailService,
+		timezoneService,
+		cfg.GetClassReminderOffsets(),
+	)
+
+	purgeService := service.NewPurgeService(userRepo, classRepo, timeSlotRepo, cfg.GetSoftDeleteRetention())
+
+	// Jobs em segundo plano
+	jobs := scheduler.New()
+	jobs.Add(scheduler.Job{
+		Name:     "purge-soft-deleted",
+		Interval: cfg.GetPurgeInterval(),
+		Run: func() error {
+			_, err := purgeService.PurgeDeleted()
+			return err
+		},
+	})
+	jobs.Add(scheduler.Job{
+		Name:     "expire-waitlist-offers",
+		Interval: cfg.GetWaitlistCheckInterval(),
+		Run:      waitlistService.ExpireOffers,
+	})
+	jobs.Add(scheduler.Job{
+		Name:     "class-lifecycle",
+		Interval: cfg.GetClassLifecycleInterval(),
+		Run: func() error {
+			_, err := lifecycleService.Run()
+			return err
+		},
+	})
+	jobs.Add(scheduler.Job{
+		Name:     "class-reminders",
+		Interval: cfg.GetClassReminderInterval(),
+		Run: func() error {
+			_, err := reminderService.SendDue()
+			return err
+		},
+	})
+	jobs.Start()
+
 	// Inicializar handlers
 	authHandler := handler.NewAuthHandler(authService)
 	userHandler := handler.NewUserHandler(userService)
-	timeSlotHandler := handler.NewTimeSlotHandler(timeSlotService)
-	classHandler := handler.NewClassHandler(classService)
+	timeSlotHandler := handler.NewTimeSlotHandler(timeSlotService, timezoneService)
+	classHandler := handler.NewClassHandler(classService, classSeriesService, timezoneService)
+	teacherHandler := handler.NewTeacherHandler(teacherService)
+	studentHandler := handler.NewStudentHandler(studentLevelService)
+	addressHandler := handler.NewAddressHandler(addressService)
+	waitlistHandler := handler.NewWaitlistHandler(waitlistService)
+	rescheduleHandler := handler.NewRescheduleHandler(rescheduleService, timezoneService)
+	blackoutHandler := handler.NewBlackoutHandler(blackoutService)
+	holidayHandler := handler.NewHolidayHandler(holidayService)
+	calendarFeedHandler := handler.NewCalendarFeedHandler(calendarFeedService)
+	calendarHandler := handler.NewCalendarHandler(calendarService, timezoneService)
+	substitutionHandler := handler.NewSubstitutionHandler(substitutionService, timezoneService)
+	roomHandler := handler.NewRoomHandler(roomService, timezoneService)
+	creditHandler := handler.NewCreditHandler(creditService)
 
 	// Configurar router
 	routerCfg := router.RouterConfig{
-		AuthHandler:     authHandler,
-		UserHandler:     userHandler,
-		TimeSlotHandler: timeSlotHandler,
-		ClassHandler:    classHandler,
-		JWTService:      jwtService,
+		AuthHandler:         authHandler,
+		UserHandler:         userHandler,
+		TimeSlotHandler:     timeSlotHandler,
+		ClassHandler:        classHandler,
+		TeacherHandler:      teacherHandler,
+		StudentHandler:      studentHandler,
+		AddressHandler:      addressHandler,
+		WaitlistHandler:     waitlistHandler,
+		RescheduleHandler:   rescheduleHandler,
+		BlackoutHandler:     blackoutHandler,
+		HolidayHandler:      holidayHandler,
+		CalendarFeedHandler: calendarFeedHandler,
+		CalendarHandler:     calendarHandler,
+		SubstitutionHandler: substitutionHandler,
+		RoomHandler:         roomHandler,
+		CreditHandler:       creditHandler,
+		JWTService:          jwtService,
 	}
 
 	r := router.SetupRouter(routerCfg)
@@ -147,5 +343,19 @@ func main() {
 	<-quit
 
 	log.Println("Shutting down server...")
+	jobs.Stop()
 	log.Println("Server stopped")
 }
+
+// newCEPProvider escolhe a implementação de consulta de CEP configurada
+func newCEPProvider(cfg *config.Config) (cep.Provider, error) {
+	switch cfg.CEP.Provider {
+	case "offline":
+		return cep.NewOfflineProviderFromFile(cfg.CEP.DatasetPath)
+	case "", "viacep":
+		viaCEP := cep.NewViaCEPProvider(cfg.CEP.ViaCEPURL, cfg.GetCEPTimeout())
+		return cep.NewCachedProvider(viaCEP, cfg.GetCEPCacheTTL(), cfg.GetCEPCacheMaxSize()), nil
+	default:
+		return nil, fmt.Errorf("unknown CEP provider: %s", cfg.CEP.Provider)
+	}
+}
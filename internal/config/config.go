@@ -3,23 +3,31 @@ package config
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/spf13/viper"
 )
 
 type Config struct {
-	Server   ServerConfig
-	Database DatabaseConfig
-	JWT      JWTConfig
-	Email    EmailConfig
-	S3       S3Config
-	Tokens   TokensConfig
+	Server       ServerConfig
+	Database     DatabaseConfig
+	JWT          JWTConfig
+	Email        EmailConfig
+	S3           S3Config
+	Tokens       TokensConfig
+	CEP          CEPConfig
+	Jobs         JobsConfig
+	Credits      CreditsConfig
+	Cancellation CancellationConfig
+	Scheduling   SchedulingConfig
 }
 
 type ServerConfig struct {
-	Port   string
-	AppEnv string
+	Port      string
+	AppEnv    string
+	PublicURL string
 }
 
 type DatabaseConfig struct {
@@ -59,6 +67,44 @@ type TokensConfig struct {
 	FrontendURL                  string
 }
 
+type CEPConfig struct {
+	Provider       string // "viacep" ou "offline"
+	DatasetPath    string
+	ViaCEPURL      string
+	CacheTTLHours  int
+	CacheMaxSize   int
+	TimeoutSeconds int
+}
+
+type JobsConfig struct {
+	PurgeIntervalHours        int
+	SoftDeleteRetentionDays   int
+	WaitlistOfferHours        int
+	WaitlistCheckMinutes      int
+	ClassLifecycleMinutes     int
+	AttendanceReminderHours   int
+	ClassCloseHours           int
+	ClassReminderMinutes      string // Antecedências separadas por vírgula, ex.: "1440,60"
+	ClassReminderCheckMinutes int
+}
+
+type CreditsConfig struct {
+	LowBalance int // Saldo a partir do qual o aluno é alertado
+	MakeupDays int // Validade dos créditos de reposição
+}
+
+type CancellationConfig struct {
+	MinNoticeHours    int
+	StudentsMayCancel bool
+	TeachersMayCancel bool
+	LatePenalty       string // "used" ou "no_show"
+}
+
+type SchedulingConfig struct {
+	HolidayPolicy  string // "block" ou "warn"
+	SchoolTimezone string // Nome IANA, ex.: "America/Sao_Paulo"
+}
+
 // LoadConfig carrega as configurações usando Viper
 func LoadConfig(path string) (*Config, error) {
 	viper.SetConfigName(".env")
@@ -75,8 +121,9 @@ func LoadConfig(path string) (*Config, error) {
 
 	config := &Config{
 		Server: ServerConfig{
-			Port:   viper.GetString("PORT"),
-			AppEnv: viper.GetString("APP_ENV"),
+			Port:      viper.GetString("PORT"),
+			AppEnv:    viper.GetString("APP_ENV"),
+			PublicURL: viper.GetString("PUBLIC_URL"),
 		},
 		Database: DatabaseConfig{
 			Host:     viper.GetString("DB_HOST"),
@@ -110,6 +157,39 @@ func LoadConfig(path string) (*Config, error) {
 			PasswordResetExpirationHours: viper.GetInt("PASSWORD_RESET_TOKEN_EXPIRATION_HOURS"),
 			FrontendURL:                  viper.GetString("FRONTEND_URL"),
 		},
+		CEP: CEPConfig{
+			Provider:       viper.GetString("CEP_PROVIDER"),
+			DatasetPath:    viper.GetString("CEP_DATASET_PATH"),
+			ViaCEPURL:      viper.GetString("CEP_VIACEP_URL"),
+			CacheTTLHours:  viper.GetInt("CEP_CACHE_TTL_HOURS"),
+			CacheMaxSize:   viper.GetInt("CEP_CACHE_MAX_SIZE"),
+			TimeoutSeconds: viper.GetInt("CEP_TIMEOUT_SECONDS"),
+		},
+		Jobs: JobsConfig{
+			PurgeIntervalHours:        viper.GetInt("PURGE_INTERVAL_HOURS"),
+			SoftDeleteRetentionDays:   viper.GetInt("SOFT_DELETE_RETENTION_DAYS"),
+			WaitlistOfferHours:        viper.GetInt("WAITLIST_OFFER_HOURS"),
+			WaitlistCheckMinutes:      viper.GetInt("WAITLIST_CHECK_MINUTES"),
+			ClassLifecycleMinutes:     viper.GetInt("CLASS_LIFECYCLE_MINUTES"),
+			AttendanceReminderHours:   viper.GetInt("ATTENDANCE_REMINDER_HOURS"),
+			ClassCloseHours:           viper.GetInt("CLASS_CLOSE_HOURS"),
+			ClassReminderMinutes:      viper.GetString("CLASS_REMINDER_MINUTES"),
+			ClassReminderCheckMinutes: viper.GetInt("CLASS_REMINDER_CHECK_MINUTES"),
+		},
+		Credits: CreditsConfig{
+			LowBalance: viper.GetInt("CREDIT_LOW_BALANCE"),
+			MakeupDays: viper.GetInt("CREDIT_MAKEUP_DAYS"),
+		},
+		Cancellation: CancellationConfig{
+			MinNoticeHours:    viper.GetInt("CANCELLATION_MIN_NOTICE_HOURS"),
+			StudentsMayCancel: viper.GetBool("CANCELLATION_STUDENTS_MAY_CANCEL"),
+			TeachersMayCancel: viper.GetBool("CANCELLATION_TEACHERS_MAY_CANCEL"),
+			LatePenalty:       viper.GetString("CANCELLATION_LATE_PENALTY"),
+		},
+		Scheduling: SchedulingConfig{
+			HolidayPolicy:  viper.GetString("HOLIDAY_POLICY"),
+			SchoolTimezone: viper.GetString("SCHOOL_TIMEZONE"),
+		},
 	}
 
 	return config, nil
@@ -128,6 +208,14 @@ func (c *Config) GetDatabaseURL() string {
 	)
 }
 
+// GetPublicURL retorna a URL pública da API usada em links enviados aos usuários
+func (c *Config) GetPublicURL() string {
+	if c.Server.PublicURL == "" {
+		return "http://localhost:" + c.Server.Port
+	}
+	return c.Server.PublicURL
+}
+
 // GetJWTExpiration retorna a duração de expiração do JWT
 func (c *Config) GetJWTExpiration() time.Duration {
 	return time.Duration(c.JWT.ExpirationHours) * time.Hour
@@ -142,3 +230,157 @@ func (c *Config) GetActivationTokenExpiration() time.Duration {
 func (c *Config) GetPasswordResetTokenExpiration() time.Duration {
 	return time.Duration(c.Tokens.PasswordResetExpirationHours) * time.Hour
 }
+
+// GetCEPCacheTTL retorna por quanto tempo as consultas de CEP ficam em cache
+func (c *Config) GetCEPCacheTTL() time.Duration {
+	if c.CEP.CacheTTLHours <= 0 {
+		return 24 * time.Hour
+	}
+	return time.Duration(c.CEP.CacheTTLHours) * time.Hour
+}
+
+// GetCEPCacheMaxSize retorna quantos CEPs o cache guarda no máximo
+func (c *Config) GetCEPCacheMaxSize() int {
+	if c.CEP.CacheMaxSize <= 0 {
+		return 10000
+	}
+	return c.CEP.CacheMaxSize
+}
+
+// GetCEPTimeout retorna o timeout das consultas de CEP externas
+func (c *Config) GetCEPTimeout() time.Duration {
+	if c.CEP.TimeoutSeconds <= 0 {
+		return 5 * time.Second
+	}
+	return time.Duration(c.CEP.TimeoutSeconds) * time.Second
+}
+
+// GetPurgeInterval retorna o intervalo entre execuções do job de purga
+func (c *Config) GetPurgeInterval() time.Duration {
+	if c.Jobs.PurgeIntervalHours <= 0 {
+		return 24 * time.Hour
+	}
+	return time.Duration(c.Jobs.PurgeIntervalHours) * time.Hour
+}
+
+// GetSoftDeleteRetention retorna por quanto tempo registros excluídos ficam disponíveis para restauração
+func (c *Config) GetSoftDeleteRetention() time.Duration {
+	if c.Jobs.SoftDeleteRetentionDays <= 0 {
+		return 30 * 24 * time.Hour
+	}
+	return time.Duration(c.Jobs.SoftDeleteRetentionDays) * 24 * time.Hour
+}
+
+// GetWaitlistOfferExpiration retorna o prazo para o aluno confirmar uma vaga da lista de espera
+func (c *Config) GetWaitlistOfferExpiration() time.Duration {
+	if c.Jobs.WaitlistOfferHours <= 0 {
+		return 12 * time.Hour
+	}
+	return time.Duration(c.Jobs.WaitlistOfferHours) * time.Hour
+}
+
+// GetWaitlistCheckInterval retorna o intervalo de verificação das ofertas vencidas
+func (c *Config) GetWaitlistCheckInterval() time.Duration {
+	if c.Jobs.WaitlistCheckMinutes <= 0 {
+		return 5 * time.Minute
+	}
+	return time.Duration(c.Jobs.WaitlistCheckMinutes) * time.Minute
+}
+
+// GetClassLifecycleInterval retorna o intervalo do job que encerra as aulas terminadas
+func (c *Config) GetClassLifecycleInterval() time.Duration {
+	if c.Jobs.ClassLifecycleMinutes <= 0 {
+		return 15 * time.Minute
+	}
+	return time.Duration(c.Jobs.ClassLifecycleMinutes) * time.Minute
+}
+
+// GetAttendanceReminder retorna quanto tempo após o fim da aula o professor é lembrado da chamada pendente
+func (c *Config) GetAttendanceReminder() time.Duration {
+	if c.Jobs.AttendanceReminderHours <= 0 {
+		return 2 * time.Hour
+	}
+	return time.Duration(c.Jobs.AttendanceReminderHours) * time.Hour
+}
+
+// GetClassClose retorna quanto tempo após o fim a aula é encerrada mesmo sem a chamada completa
+func (c *Config) GetClassClose() time.Duration {
+	if c.Jobs.ClassCloseHours <= 0 {
+		return 24 * time.Hour
+	}
+	return time.Duration(c.Jobs.ClassCloseHours) * time.Hour
+}
+
+// GetClassReminderOffsets retorna com quanta antecedência os lembretes de aula são enviados.
+// Valores inválidos são ignorados; sem nenhum válido usa 24 horas e 1 hora.
+func (c *Config) GetClassReminderOffsets() []time.Duration {
+	var offsets []time.Duration
+	for _, value := range strings.Split(c.Jobs.ClassReminderMinutes, ",") {
+		minutes, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil || minutes <= 0 {
+			continue
+		}
+		offsets = append(offsets, time.Duration(minutes)*time.Minute)
+	}
+
+	if len(offsets) == 0 {
+		return []time.Duration{24 * time.Hour, time.Hour}
+	}
+	return offsets
+}
+
+// GetClassReminderInterval retorna o intervalo de verificação dos lembretes de aula
+func (c *Config) GetClassReminderInterval() time.Duration {
+	if c.Jobs.ClassReminderCheckMinutes <= 0 {
+		return 5 * time.Minute
+	}
+	return time.Duration(c.Jobs.ClassReminderCheckMinutes) * time.Minute
+}
+
+// GetCreditLowBalance retorna o saldo de créditos que dispara o alerta de saldo baixo
+func (c *Config) GetCreditLowBalance() int {
+	if c.Credits.LowBalance <= 0 {
+		return 1
+	}
+	return c.Credits.LowBalance
+}
+
+// GetCreditMakeupValidity retorna a validade dos créditos de reposição
+func (c *Config) GetCreditMakeupValidity() time.Duration {
+	if c.Credits.MakeupDays <= 0 {
+		return 30 * 24 * time.Hour
+	}
+	return time.Duration(c.Credits.MakeupDays) * 24 * time.Hour
+}
+
+// GetCancellationMinNotice retorna a antecedência mínima para cancelar sem penalidade
+func (c *Config) GetCancellationMinNotice() time.Duration {
+	if c.Cancellation.MinNoticeHours <= 0 {
+		return 24 * time.Hour
+	}
+	return time.Duration(c.Cancellation.MinNoticeHours) * time.Hour
+}
+
+// GetCancellationLatePenalty retorna como o cancelamento tardio do aluno é contabilizado
+func (c *Config) GetCancellationLatePenalty() string {
+	if c.Cancellation.LatePenalty == "" {
+		return "used"
+	}
+	return c.Cancellation.LatePenalty
+}
+
+// GetHolidayPolicy retorna se feriados bloqueiam ("block") ou apenas avisam ("warn") no agendamento
+func (c *Config) GetHolidayPolicy() string {
+	if c.Scheduling.HolidayPolicy == "" {
+		return "block"
+	}
+	return c.Scheduling.HolidayPolicy
+}
+
+// GetSchoolTimezone retorna o fuso IANA em que a escola cadastra aulas e horários
+func (c *Config) GetSchoolTimezone() string {
+	if c.Scheduling.SchoolTimezone == "" {
+		return "America/Sao_Paulo"
+	}
+	return c.Scheduling.SchoolTimezone
+}
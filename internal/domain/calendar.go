@@ -21,15 +21,49 @@ const (
 
 // TimeSlot representa um horário disponível recorrente do professor
 type TimeSlot struct {
-	ID          uuid.UUID `db:"id" json:"id"`
-	TeacherID   uuid.UUID `db:"teacher_id" json:"teacher_id"`
-	DayOfWeek   DayOfWeek `db:"day_of_week" json:"day_of_week"`
-	StartTime   string    `db:"start_time" json:"start_time"` // Format: "14:00:00"
-	EndTime     string    `db:"end_time" json:"end_time"`     // Format: "15:00:00"
-	MaxStudents int       `db:"max_students" json:"max_students"`
-	IsAvailable bool      `db:"is_available" json:"is_available"`
-	CreatedAt   time.Time `db:"created_at" json:"created_at"`
-	UpdatedAt   time.Time `db:"updated_at" json:"updated_at"`
+	ID          uuid.UUID  `db:"id" json:"id"`
+	TeacherID   uuid.UUID  `db:"teacher_id" json:"teacher_id"`
+	DayOfWeek   DayOfWeek  `db:"day_of_week" json:"day_of_week"`
+	StartTime   string     `db:"start_time" json:"start_time"` // Format: "14:00:00"
+	EndTime     string     `db:"end_time" json:"end_time"`     // Format: "15:00:00"
+	MaxStudents int        `db:"max_students" json:"max_students"`
+	IsAvailable bool       `db:"is_available" json:"is_available"`
+	ValidFrom   time.Time  `db:"valid_from" json:"valid_from"`             // Primeira data em que o horário vale
+	ValidUntil  *time.Time `db:"valid_until" json:"valid_until,omitempty"` // Última data; nil = sem término
+	CreatedAt   time.Time  `db:"created_at" json:"created_at"`
+	UpdatedAt   time.Time  `db:"updated_at" json:"updated_at"`
+	DeletedAt   *time.Time `db:"deleted_at" json:"deleted_at,omitempty"`
+}
+
+// Covers verifica se esta versão do horário vale na data (apenas ano, mês e dia)
+func (s TimeSlot) Covers(date time.Time) bool {
+	day := date.Format("2006-01-02")
+	if day < s.ValidFrom.Format("2006-01-02") {
+		return false
+	}
+	return s.ValidUntil == nil || day <= s.ValidUntil.Format("2006-01-02")
+}
+
+// TimeSlotChange é um horário da grade semanal que ganhou uma nova versão
+type TimeSlotChange struct {
+	Before        TimeSlot `json:"before"`
+	After         TimeSlot `json:"after"`
+	FutureClasses int      `json:"future_classes"` // Aulas agendadas que continuam na versão anterior
+}
+
+// RemovedTimeSlot é um horário retirado da grade semanal
+type RemovedTimeSlot struct {
+	TimeSlot
+	FutureClasses int `json:"future_classes"` // Aulas agendadas que continuam marcadas no horário removido
+}
+
+// TimeSlotDiff resume a substituição da grade semanal de um professor
+type TimeSlotDiff struct {
+	Added     []TimeSlot        `json:"added"`
+	Changed   []TimeSlotChange  `json:"changed"`
+	Removed   []RemovedTimeSlot `json:"removed"`
+	Unchanged int               `json:"unchanged"`
+	Applied   bool              `json:"applied"` // false quando é apenas uma simulação (dry run)
 }
 
 // ClassStatus representa o status de uma aula
@@ -42,32 +76,315 @@ const (
 	ClassStatusNoShow    ClassStatus = "no_show"
 )
 
+// classStatusTransitions lista para quais status cada status pode mudar.
+// Aulas canceladas são finais; completed e no_show podem ser corrigidos após a chamada.
+var classStatusTransitions = map[ClassStatus][]ClassStatus{
+	ClassStatusScheduled: {ClassStatusCompleted, ClassStatusNoShow, ClassStatusCancelled},
+	ClassStatusCompleted: {ClassStatusNoShow},
+	ClassStatusNoShow:    {ClassStatusCompleted},
+}
+
+// IsValid verifica se o status é conhecido
+func (s ClassStatus) IsValid() bool {
+	switch s {
+	case ClassStatusScheduled, ClassStatusCompleted, ClassStatusCancelled, ClassStatusNoShow:
+		return true
+	}
+	return false
+}
+
+// CanTransitionTo verifica se a aula pode passar do status atual para next
+func (s ClassStatus) CanTransitionTo(next ClassStatus) bool {
+	for _, allowed := range classStatusTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// ClassAttendanceSummary resume a chamada de uma aula já terminada que ainda está agendada
+type ClassAttendanceSummary struct {
+	ClassID              uuid.UUID  `db:"class_id" json:"class_id"`
+	TeacherID            uuid.UUID  `db:"teacher_id" json:"teacher_id"`
+	EndsAt               time.Time  `db:"ends_at" json:"ends_at"`
+	Attended             int        `db:"attended" json:"attended"` // Alunos com presença
+	Pending              int        `db:"pending" json:"pending"`   // Alunos sem chamada registrada
+	AttendanceRemindedAt *time.Time `db:"attendance_reminded_at" json:"attendance_reminded_at,omitempty"`
+}
+
+// ClassReminderTarget é um destinatário (professor ou aluno) de um lembrete de aula pendente
+type ClassReminderTarget struct {
+	ClassID uuid.UUID `db:"class_id" json:"class_id"`
+	AuthID  uuid.UUID `db:"auth_id" json:"auth_id"`
+}
+
 // Class representa uma aula agendada
 type Class struct {
-	ID            uuid.UUID   `db:"id" json:"id"`
-	TeacherID     uuid.UUID   `db:"teacher_id" json:"teacher_id"`
-	TimeSlotID    *uuid.UUID  `db:"time_slot_id" json:"time_slot_id,omitempty"`
-	ScheduledDate time.Time   `db:"scheduled_date" json:"scheduled_date"`
-	StartTime     string      `db:"start_time" json:"start_time"` // Format: "14:00:00"
-	EndTime       string      `db:"end_time" json:"end_time"`     // Format: "15:00:00"
-	Status        ClassStatus `db:"status" json:"status"`
-	Title         *string     `db:"title" json:"title,omitempty"`
-	Description   *string     `db:"description" json:"description,omitempty"`
-	ClassLink     *string     `db:"class_link" json:"class_link,omitempty"`
-	MaterialID    *uuid.UUID  `db:"material_id" json:"material_id,omitempty"`
-	CreatedBy     uuid.UUID   `db:"created_by" json:"created_by"`
-	CreatedAt     time.Time   `db:"created_at" json:"created_at"`
-	UpdatedAt     time.Time   `db:"updated_at" json:"updated_at"`
+	ID            uuid.UUID      `db:"id" json:"id"`
+	TeacherID     uuid.UUID      `db:"teacher_id" json:"teacher_id"`
+	TimeSlotID    *uuid.UUID     `db:"time_slot_id" json:"time_slot_id,omitempty"`
+	SeriesID      *uuid.UUID     `db:"series_id" json:"series_id,omitempty"`
+	ScheduledDate time.Time      `db:"scheduled_date" json:"scheduled_date"`
+	StartTime     string         `db:"start_time" json:"start_time"` // Format: "14:00:00"
+	EndTime       string         `db:"end_time" json:"end_time"`     // Format: "15:00:00"
+	StartsAt      time.Time      `db:"starts_at" json:"starts_at"`   // Instante de início, exibido no fuso de quem consulta
+	EndsAt        time.Time      `db:"ends_at" json:"ends_at"`
+	Timezone      string         `db:"-" json:"timezone,omitempty"` // Fuso usado em starts_at/ends_at na resposta
+	Status        ClassStatus    `db:"status" json:"status"`
+	Title         *string        `db:"title" json:"title,omitempty"`
+	Description   *string        `db:"description" json:"description,omitempty"`
+	ClassLink     *string        `db:"class_link" json:"class_link,omitempty"`
+	RoomID        *uuid.UUID     `db:"room_id" json:"room_id,omitempty"` // Sala da aula presencial (NULL=online)
+	MaterialID    *uuid.UUID     `db:"material_id" json:"material_id,omitempty"`
+	Language      *string        `db:"language" json:"language,omitempty"`
+	Level         *LanguageLevel `db:"level" json:"level,omitempty"`
+	CreatedBy     uuid.UUID      `db:"created_by" json:"created_by"`
+	CreatedAt     time.Time      `db:"created_at" json:"created_at"`
+	UpdatedAt     time.Time      `db:"updated_at" json:"updated_at"`
+	DeletedAt     *time.Time     `db:"deleted_at" json:"deleted_at,omitempty"`
+	Warnings      []string       `db:"-" json:"warnings,omitempty"` // Avisos do agendamento (ex.: feriado)
+
+	// Substituição: TeacherID é quem dá a aula e OriginalTeacherID o titular
+	OriginalTeacherID  *uuid.UUID `db:"original_teacher_id" json:"original_teacher_id,omitempty"`
+	SubstitutionReason *string    `db:"substitution_reason" json:"substitution_reason,omitempty"`
+	SubstitutedAt      *time.Time `db:"substituted_at" json:"substituted_at,omitempty"`
+}
+
+// Localize expressa starts_at/ends_at no fuso de quem consulta a aula
+func (c *Class) Localize(loc *time.Location) {
+	c.StartsAt = c.StartsAt.In(loc)
+	c.EndsAt = c.EndsAt.In(loc)
+	c.Timezone = loc.String()
+}
+
+// SubstituteCandidate é um professor livre para substituir o titular de uma aula
+type SubstituteCandidate struct {
+	TeacherID uuid.UUID       `json:"teacher_id"`
+	FullName  string          `json:"full_name"`
+	Languages []string        `json:"languages"`
+	Levels    []LanguageLevel `json:"levels"`
+}
+
+// StudentConflict lista as aulas do aluno que colidem com o horário pedido
+type StudentConflict struct {
+	StudentID uuid.UUID `json:"student_id"`
+	Classes   []Class   `json:"classes"`
+}
+
+// ClassSeries representa um padrão semanal que gera aulas individuais
+type ClassSeries struct {
+	ID           uuid.UUID      `db:"id" json:"id"`
+	TeacherID    uuid.UUID      `db:"teacher_id" json:"teacher_id"`
+	DaysOfWeek   []DayOfWeek    `db:"days_of_week" json:"days_of_week"`
+	StartDate    time.Time      `db:"start_date" json:"start_date"`
+	UntilDate    *time.Time     `db:"until_date" json:"until_date,omitempty"`
+	Occurrences  *int           `db:"occurrences" json:"occurrences,omitempty"`
+	StartTime    string         `db:"start_time" json:"start_time"`
+	EndTime      string         `db:"end_time" json:"end_time"`
+	Title        *string        `db:"title" json:"title,omitempty"`
+	Description  *string        `db:"description" json:"description,omitempty"`
+	ClassLink    *string        `db:"class_link" json:"class_link,omitempty"`
+	Language     *string        `db:"language" json:"language,omitempty"`
+	Level        *LanguageLevel `db:"level" json:"level,omitempty"`
+	ExcludeDates []string       `db:"exclude_dates" json:"exclude_dates"` // Format: "2006-01-02"
+	CreatedBy    uuid.UUID      `db:"created_by" json:"created_by"`
+	CreatedAt    time.Time      `db:"created_at" json:"created_at"`
+	UpdatedAt    time.Time      `db:"updated_at" json:"updated_at"`
+}
+
+// ClassSeriesWithClasses combina a série com as aulas agendadas que ela gerou
+type ClassSeriesWithClasses struct {
+	ClassSeries
+	Classes []Class `json:"classes"`
+}
+
+// SkippedOccurrence é uma data da série que não gerou aula
+type SkippedOccurrence struct {
+	Date   string `json:"date"`
+	Reason string `json:"reason"`
+}
+
+// ClassSeriesReport é o resultado da geração de uma série
+type ClassSeriesReport struct {
+	Series   ClassSeries         `json:"series"`
+	Created  []Class             `json:"created"`
+	Skipped  []SkippedOccurrence `json:"skipped"`
+	Warnings []SkippedOccurrence `json:"warnings,omitempty"` // Aulas criadas em datas com aviso (ex.: feriado)
+}
+
+// SeriesScope define quais ocorrências de uma série uma alteração atinge
+type SeriesScope string
+
+const (
+	SeriesScopeThis      SeriesScope = "this"      // Apenas a aula informada
+	SeriesScopeFollowing SeriesScope = "following" // A aula informada e as seguintes
+	SeriesScopeAll       SeriesScope = "all"       // Todas as aulas agendadas da série
+)
+
+// IsValid verifica se o escopo é conhecido
+func (s SeriesScope) IsValid() bool {
+	switch s {
+	case SeriesScopeThis, SeriesScopeFollowing, SeriesScopeAll:
+		return true
+	}
+	return false
+}
+
+// ClassFilter representa filtros opcionais na listagem de aulas
+type ClassFilter struct {
+	Language string
+	Level    LanguageLevel
+}
+
+// ClassOccupancy combina a aula com o número de alunos matriculados
+type ClassOccupancy struct {
+	Class
+	StudentCount int `db:"student_count" json:"student_count"`
+}
+
+// ClassListFilter filtra a agenda geral de aulas consultada pelos admins.
+// Campos vazios ou nulos não filtram.
+type ClassListFilter struct {
+	TeacherID *uuid.UUID
+	StudentID *uuid.UUID
+	Status    ClassStatus
+	StartDate *time.Time
+	EndDate   *time.Time
+	Language  string
+	Page      int // A partir de 1
+	PageSize  int
+}
+
+// ClassPage é uma página da agenda geral de aulas
+type ClassPage struct {
+	Classes  []Class `json:"classes"`
+	Page     int     `json:"page"`
+	PageSize int     `json:"page_size"`
+	Total    int     `json:"total"` // Aulas que atendem aos filtros, em todas as páginas
+}
+
+// ScheduleEntry é uma aula da grade geral com a lotação e o nome do professor
+type ScheduleEntry struct {
+	ClassOccupancy
+	TeacherName string `db:"teacher_name" json:"-"`
+}
+
+// ScheduleGrid é a grade de aulas por professor e dia usada pela secretaria
+type ScheduleGrid struct {
+	From     string            `json:"from"` // Format: "2006-01-02"
+	To       string            `json:"to"`   // Inclusive
+	Timezone string            `json:"timezone"`
+	Days     []string          `json:"days"`
+	Teachers []ScheduleGridRow `json:"teachers"`
+}
+
+// ScheduleGridRow é a linha de um professor na grade, com uma célula por dia
+type ScheduleGridRow struct {
+	TeacherID   uuid.UUID         `json:"teacher_id"`
+	TeacherName string            `json:"teacher_name"`
+	Days        []ScheduleGridDay `json:"days"`
+}
+
+// ScheduleGridDay são as aulas do professor em um dia da grade
+type ScheduleGridDay struct {
+	Date    string           `json:"date"`
+	Classes []ClassOccupancy `json:"classes"`
+}
+
+// Localize expressa starts_at/ends_at das aulas da grade no fuso de quem consulta
+func (g *ScheduleGrid) Localize(loc *time.Location) {
+	g.Timezone = loc.String()
+	for i := range g.Teachers {
+		for j := range g.Teachers[i].Days {
+			for k := range g.Teachers[i].Days[j].Classes {
+				g.Teachers[i].Days[j].Classes[k].Localize(loc)
+			}
+		}
+	}
+}
+
+// Opening representa uma ocorrência datada de um TimeSlot que ainda aceita alunos
+type Opening struct {
+	TimeSlotID        uuid.UUID  `json:"time_slot_id"`
+	TeacherID         uuid.UUID  `json:"teacher_id"`
+	Date              string     `json:"date"`       // Format: "2006-01-02"
+	StartTime         string     `json:"start_time"` // Format: "14:00:00"
+	EndTime           string     `json:"end_time"`   // Format: "15:00:00"
+	StartsAt          time.Time  `json:"starts_at"`  // Instante de início, exibido no fuso de quem consulta
+	EndsAt            time.Time  `json:"ends_at"`
+	Timezone          string     `json:"timezone,omitempty"`
+	MaxStudents       int        `json:"max_students"`
+	BookedStudents    int        `json:"booked_students"`
+	RemainingCapacity int        `json:"remaining_capacity"`
+	ClassID           *uuid.UUID `json:"class_id,omitempty"` // Turma já criada na ocorrência
+	Holiday           *string    `json:"holiday,omitempty"`  // Nome do feriado, quando a política apenas avisa
+}
+
+// Localize expressa starts_at/ends_at no fuso de quem consulta a agenda
+func (o *Opening) Localize(loc *time.Location) {
+	o.StartsAt = o.StartsAt.In(loc)
+	o.EndsAt = o.EndsAt.In(loc)
+	o.Timezone = loc.String()
+}
+
+// RescheduleStatus representa o status de uma proposta de remarcação
+type RescheduleStatus string
+
+const (
+	RescheduleStatusPending   RescheduleStatus = "pending"   // Aguardando confirmação dos alunos
+	RescheduleStatusApplied   RescheduleStatus = "applied"   // Aula remarcada
+	RescheduleStatusRejected  RescheduleStatus = "rejected"  // Algum aluno recusou
+	RescheduleStatusCancelled RescheduleStatus = "cancelled" // Substituída por outra proposta ou inviável
+)
+
+// ClassReschedule representa uma proposta de nova data/horário para a aula.
+// As propostas aplicadas formam o histórico de horários anteriores da aula.
+type ClassReschedule struct {
+	ID                uuid.UUID            `db:"id" json:"id"`
+	ClassID           uuid.UUID            `db:"class_id" json:"class_id"`
+	ProposedBy        uuid.UUID            `db:"proposed_by" json:"proposed_by"`
+	PreviousDate      time.Time            `db:"previous_date" json:"previous_date"`
+	PreviousStartTime string               `db:"previous_start_time" json:"previous_start_time"`
+	PreviousEndTime   string               `db:"previous_end_time" json:"previous_end_time"`
+	NewDate           time.Time            `db:"new_date" json:"new_date"`
+	NewStartTime      string               `db:"new_start_time" json:"new_start_time"`
+	NewEndTime        string               `db:"new_end_time" json:"new_end_time"`
+	Reason            *string              `db:"reason" json:"reason,omitempty"`
+	Status            RescheduleStatus     `db:"status" json:"status"`
+	CreatedAt         time.Time            `db:"created_at" json:"created_at"`
+	ResolvedAt        *time.Time           `db:"resolved_at" json:"resolved_at,omitempty"`
+	Responses         []RescheduleResponse `db:"-" json:"responses,omitempty"`
 }
 
+// RescheduleResponse é a resposta de um aluno a uma proposta de remarcação
+type RescheduleResponse struct {
+	RescheduleID uuid.UUID `db:"reschedule_id" json:"reschedule_id"`
+	StudentID    uuid.UUID `db:"student_id" json:"student_id"`
+	Accepted     bool      `db:"accepted" json:"accepted"`
+	RespondedAt  time.Time `db:"responded_at" json:"responded_at"`
+}
+
+// CancellationOutcome registra a decisão da política para o cancelamento do aluno
+type CancellationOutcome string
+
+const (
+	CancellationOnTime   CancellationOutcome = "on_time"   // Dentro do prazo, sem penalidade
+	CancellationLateUsed CancellationOutcome = "late_used" // Fora do prazo, conta como aula usada
+	CancellationNoShow   CancellationOutcome = "no_show"   // Fora do prazo, registrado como falta
+)
+
 // ClassStudent representa a relação entre aula e aluno
 type ClassStudent struct {
-	ID        uuid.UUID `db:"id" json:"id"`
-	ClassID   uuid.UUID `db:"class_id" json:"class_id"`
-	StudentID uuid.UUID `db:"student_id" json:"student_id"`
-	AddedBy   uuid.UUID `db:"added_by" json:"added_by"`
-	Attended  *bool     `db:"attended" json:"attended,omitempty"`
-	CreatedAt time.Time `db:"created_at" json:"created_at"`
+	ID                  uuid.UUID            `db:"id" json:"id"`
+	ClassID             uuid.UUID            `db:"class_id" json:"class_id"`
+	StudentID           uuid.UUID            `db:"student_id" json:"student_id"`
+	AddedBy             uuid.UUID            `db:"added_by" json:"added_by"`
+	Attended            *bool                `db:"attended" json:"attended,omitempty"`
+	CreatedAt           time.Time            `db:"created_at" json:"created_at"`
+	CancelledAt         *time.Time           `db:"cancelled_at" json:"cancelled_at,omitempty"`
+	CancelledBy         *uuid.UUID           `db:"cancelled_by" json:"cancelled_by,omitempty"`
+	CancellationOutcome *CancellationOutcome `db:"cancellation_outcome" json:"cancellation_outcome,omitempty"`
 }
 
 // ClassWithDetails combina Class com detalhes
@@ -77,3 +394,67 @@ type ClassWithDetails struct {
 	Students []User `json:"students,omitempty"`
 	Material *File  `json:"material,omitempty"`
 }
+
+// CalendarFeed é a assinatura .ics de um usuário. O token fica só na URL, que
+// é exibida apenas ao dono.
+type CalendarFeed struct {
+	ID             uuid.UUID  `db:"id" json:"id"`
+	AuthID         uuid.UUID  `db:"auth_id" json:"auth_id"`
+	Token          string     `db:"token" json:"-"`
+	LastAccessedAt *time.Time `db:"last_accessed_at" json:"last_accessed_at,omitempty"`
+	CreatedAt      time.Time  `db:"created_at" json:"created_at"`
+	URL            string     `db:"-" json:"url"`
+}
+
+// CalendarView representa o período exibido pela agenda
+type CalendarView string
+
+const (
+	CalendarViewWeek  CalendarView = "week"  // Segunda a domingo da semana da data
+	CalendarViewMonth CalendarView = "month" // Mês da data
+)
+
+// IsValid verifica se a visão é conhecida
+func (v CalendarView) IsValid() bool {
+	return v == CalendarViewWeek || v == CalendarViewMonth
+}
+
+// CalendarEventType representa a origem de um evento da agenda
+type CalendarEventType string
+
+const (
+	CalendarEventClass    CalendarEventType = "class"    // Aula marcada
+	CalendarEventOpening  CalendarEventType = "opening"  // Ocorrência livre de um horário do professor
+	CalendarEventBlackout CalendarEventType = "blackout" // Folga ou ausência do professor
+	CalendarEventHoliday  CalendarEventType = "holiday"  // Feriado
+)
+
+// CalendarEvent é um item normalizado da agenda. Eventos de dia inteiro (folgas e
+// feriados) usam apenas as datas; os demais também têm starts_at/ends_at.
+type CalendarEvent struct {
+	Type              CalendarEventType `json:"type"`
+	ColorKey          string            `json:"color_key"` // Ex.: "class_scheduled", "opening", "holiday"
+	Title             string            `json:"title"`
+	AllDay            bool              `json:"all_day"`
+	StartDate         string            `json:"start_date"` // Format: "2006-01-02"
+	EndDate           string            `json:"end_date"`   // Inclusive
+	StartsAt          *time.Time        `json:"starts_at,omitempty"`
+	EndsAt            *time.Time        `json:"ends_at,omitempty"`
+	TeacherID         *uuid.UUID        `json:"teacher_id,omitempty"`
+	ClassID           *uuid.UUID        `json:"class_id,omitempty"`
+	TimeSlotID        *uuid.UUID        `json:"time_slot_id,omitempty"`
+	BlackoutID        *uuid.UUID        `json:"blackout_id,omitempty"`
+	HolidayID         *uuid.UUID        `json:"holiday_id,omitempty"`
+	Status            *ClassStatus      `json:"status,omitempty"`             // Apenas aulas
+	RemainingCapacity *int              `json:"remaining_capacity,omitempty"` // Apenas ocorrências livres
+}
+
+// Calendar é a agenda de um período pronta para as visões de semana e mês
+type Calendar struct {
+	View      CalendarView    `json:"view"`
+	From      string          `json:"from"` // Format: "2006-01-02"
+	To        string          `json:"to"`   // Inclusive
+	Timezone  string          `json:"timezone"`
+	TeacherID *uuid.UUID      `json:"teacher_id,omitempty"` // Professor cuja agenda é exibida
+	Events    []CalendarEvent `json:"events"`
+}
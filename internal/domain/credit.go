@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CreditPackageKind representa a origem de um lote de créditos
+type CreditPackageKind string
+
+const (
+	CreditPackageKindPackage CreditPackageKind = "package" // Pacote de aulas comprado
+	CreditPackageKindMakeup  CreditPackageKind = "makeup"  // Reposição por aula cancelada pelo professor
+)
+
+// CreditEntryType representa o tipo de lançamento no extrato de créditos
+type CreditEntryType string
+
+const (
+	CreditEntryPurchase CreditEntryType = "purchase" // Créditos do pacote comprado
+	CreditEntryMakeup   CreditEntryType = "makeup"   // Crédito de reposição concedido
+	CreditEntryDebit    CreditEntryType = "debit"    // Aula reservada ou realizada
+	CreditEntryRefund   CreditEntryType = "refund"   // Estorno de uma aula debitada
+)
+
+// CreditPackage representa um lote de créditos de aula do aluno
+type CreditPackage struct {
+	ID        uuid.UUID         `db:"id" json:"id"`
+	StudentID uuid.UUID         `db:"student_id" json:"student_id"`
+	Kind      CreditPackageKind `db:"kind" json:"kind"`
+	Name      string            `db:"name" json:"name"`
+	Credits   int               `db:"credits" json:"credits"`
+	Remaining int               `db:"remaining" json:"remaining"` // Soma dos lançamentos do lote
+	ExpiresAt *time.Time        `db:"expires_at" json:"expires_at,omitempty"`
+	ClassID   *uuid.UUID        `db:"class_id" json:"class_id,omitempty"` // Aula que originou a reposição
+	CreatedBy *uuid.UUID        `db:"created_by" json:"created_by,omitempty"`
+	CreatedAt time.Time         `db:"created_at" json:"created_at"`
+	UpdatedAt time.Time         `db:"updated_at" json:"updated_at"`
+}
+
+// IsExpired informa se os créditos do lote já venceram
+func (p *CreditPackage) IsExpired(now time.Time) bool {
+	return p.ExpiresAt != nil && !p.ExpiresAt.After(now)
+}
+
+// CreditEntry representa um lançamento no extrato de créditos do aluno
+type CreditEntry struct {
+	ID        uuid.UUID       `db:"id" json:"id"`
+	PackageID uuid.UUID       `db:"package_id" json:"package_id"`
+	StudentID uuid.UUID       `db:"student_id" json:"student_id"`
+	ClassID   *uuid.UUID      `db:"class_id" json:"class_id,omitempty"`
+	EntryType CreditEntryType `db:"entry_type" json:"entry_type"`
+	Amount    int             `db:"amount" json:"amount"`
+	CreatedAt time.Time       `db:"created_at" json:"created_at"`
+}
+
+// CreditBalance representa o saldo de créditos do aluno
+type CreditBalance struct {
+	StudentID  uuid.UUID       `json:"student_id"`
+	Available  int             `json:"available"`   // Créditos de lotes válidos
+	LowBalance bool            `json:"low_balance"` // Saldo no limite de alerta ou abaixo
+	Packages   []CreditPackage `json:"packages"`
+}
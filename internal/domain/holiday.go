@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// HolidayScope representa a abrangência do feriado
+type HolidayScope string
+
+const (
+	HolidayScopeNational  HolidayScope = "national"
+	HolidayScopeState     HolidayScope = "state"
+	HolidayScopeMunicipal HolidayScope = "municipal"
+	HolidayScopeSchool    HolidayScope = "school" // Recesso definido pela escola
+)
+
+// Holiday representa um dia em que a escola não funciona
+type Holiday struct {
+	ID        uuid.UUID    `db:"id" json:"id"`
+	Date      time.Time    `db:"date" json:"date"`
+	Name      string       `db:"name" json:"name"`
+	Scope     HolidayScope `db:"scope" json:"scope"`
+	CreatedBy *uuid.UUID   `db:"created_by" json:"created_by,omitempty"`
+	CreatedAt time.Time    `db:"created_at" json:"created_at"`
+	UpdatedAt time.Time    `db:"updated_at" json:"updated_at"`
+}
+
+// HolidayReport combina o feriado com as aulas agendadas na data
+type HolidayReport struct {
+	Holiday         Holiday `json:"holiday"`
+	AffectedClasses []Class `json:"affected_classes"`
+}
+
+// HolidayClassAction é a ação em lote aplicada às aulas que caem em um feriado
+type HolidayClassAction string
+
+const (
+	HolidayClassActionCancel     HolidayClassAction = "cancel"
+	HolidayClassActionReschedule HolidayClassAction = "reschedule"
+)
+
+// HolidayClassResult é o resultado da ação em lote para uma aula
+type HolidayClassResult struct {
+	ClassID uuid.UUID `json:"class_id"`
+	Status  string    `json:"status"` // cancelled, rescheduled, proposed ou failed
+	Error   string    `json:"error,omitempty"`
+}
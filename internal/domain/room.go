@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Branch representa uma unidade física da escola
+type Branch struct {
+	ID        uuid.UUID `db:"id" json:"id"`
+	Name      string    `db:"name" json:"name"`
+	Address   *string   `db:"address" json:"address,omitempty"`
+	IsActive  bool      `db:"is_active" json:"is_active"`
+	CreatedAt time.Time `db:"created_at" json:"created_at"`
+	UpdatedAt time.Time `db:"updated_at" json:"updated_at"`
+}
+
+// Room representa uma sala de aula de uma unidade
+type Room struct {
+	ID        uuid.UUID `db:"id" json:"id"`
+	BranchID  uuid.UUID `db:"branch_id" json:"branch_id"`
+	Name      string    `db:"name" json:"name"`
+	Capacity  int       `db:"capacity" json:"capacity"`
+	Equipment []string  `db:"equipment" json:"equipment"` // Ex: "projector", "whiteboard"
+	IsActive  bool      `db:"is_active" json:"is_active"`
+	CreatedAt time.Time `db:"created_at" json:"created_at"`
+	UpdatedAt time.Time `db:"updated_at" json:"updated_at"`
+}
+
+// RoomFilter representa os requisitos de sala usados na sugestão de salas livres
+type RoomFilter struct {
+	BranchID    *uuid.UUID
+	MinCapacity int
+	Equipment   []string // A sala precisa ter todos os equipamentos
+}
+
+// RoomOccupancy representa as aulas que ocupam uma sala no período consultado
+type RoomOccupancy struct {
+	Room          Room    `json:"room"`
+	Classes       []Class `json:"classes"`
+	BookedMinutes int     `json:"booked_minutes"`
+}
@@ -0,0 +1,19 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// StudentLevel representa uma avaliação de nível CEFR do aluno em um idioma
+type StudentLevel struct {
+	ID         uuid.UUID     `db:"id" json:"id"`
+	StudentID  uuid.UUID     `db:"student_id" json:"student_id"`
+	Language   string        `db:"language" json:"language"`
+	Level      LanguageLevel `db:"level" json:"level"`
+	AssessedAt time.Time     `db:"assessed_at" json:"assessed_at"`
+	AssessedBy *uuid.UUID    `db:"assessed_by" json:"assessed_by,omitempty"`
+	Notes      *string       `db:"notes" json:"notes,omitempty"`
+	CreatedAt  time.Time     `db:"created_at" json:"created_at"`
+}
@@ -0,0 +1,79 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// LanguageLevel representa um nível do Quadro Europeu Comum de Referência (CEFR)
+type LanguageLevel string
+
+const (
+	LevelA1 LanguageLevel = "A1"
+	LevelA2 LanguageLevel = "A2"
+	LevelB1 LanguageLevel = "B1"
+	LevelB2 LanguageLevel = "B2"
+	LevelC1 LanguageLevel = "C1"
+	LevelC2 LanguageLevel = "C2"
+)
+
+// IsValid verifica se o nível é um dos níveis CEFR conhecidos
+func (l LanguageLevel) IsValid() bool {
+	switch l {
+	case LevelA1, LevelA2, LevelB1, LevelB2, LevelC1, LevelC2:
+		return true
+	}
+	return false
+}
+
+// TeacherProfile representa o perfil profissional do professor
+type TeacherProfile struct {
+	TeacherID     uuid.UUID       `db:"teacher_id" json:"teacher_id"`
+	Languages     []string        `db:"languages" json:"languages"`
+	Levels        []LanguageLevel `db:"levels" json:"levels"`
+	Bio           *string         `db:"bio" json:"bio,omitempty"`
+	Specialties   []string        `db:"specialties" json:"specialties"`
+	VideoIntroURL *string         `db:"video_intro_url" json:"video_intro_url,omitempty"`
+	CreatedAt     time.Time       `db:"created_at" json:"created_at"`
+	UpdatedAt     time.Time       `db:"updated_at" json:"updated_at"`
+}
+
+// TeacherDirectoryFilter representa os filtros do diretório de professores
+type TeacherDirectoryFilter struct {
+	Language string
+	Level    LanguageLevel
+}
+
+// TeacherDirectoryEntry representa um professor listado no diretório
+type TeacherDirectoryEntry struct {
+	TeacherID         uuid.UUID      `json:"teacher_id"`
+	FullName          string         `json:"full_name"`
+	Profile           TeacherProfile `json:"profile"`
+	AvailableSlotsURL string         `json:"available_slots_url"`
+}
+
+// TeacherBlackout representa um período (férias, folga) em que o professor não dá aulas
+type TeacherBlackout struct {
+	ID        uuid.UUID `db:"id" json:"id"`
+	TeacherID uuid.UUID `db:"teacher_id" json:"teacher_id"`
+	StartDate time.Time `db:"start_date" json:"start_date"`
+	EndDate   time.Time `db:"end_date" json:"end_date"`
+	Reason    *string   `db:"reason" json:"reason,omitempty"`
+	CreatedBy uuid.UUID `db:"created_by" json:"created_by"`
+	CreatedAt time.Time `db:"created_at" json:"created_at"`
+	UpdatedAt time.Time `db:"updated_at" json:"updated_at"`
+}
+
+// Covers verifica se a data cai dentro do período (inclusive)
+func (b TeacherBlackout) Covers(date time.Time) bool {
+	day := date.Format("2006-01-02")
+	return day >= b.StartDate.Format("2006-01-02") && day <= b.EndDate.Format("2006-01-02")
+}
+
+// BlackoutReport combina o período com as aulas agendadas que caem nele e
+// precisam ser remarcadas, passadas a outro professor ou canceladas
+type BlackoutReport struct {
+	Blackout        TeacherBlackout `json:"blackout"`
+	AffectedClasses []Class         `json:"affected_classes"`
+}
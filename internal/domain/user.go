@@ -48,24 +48,27 @@ type Address struct {
 	Country    string `json:"country" binding:"required"`
 }
 
-// Contact representa os contatos (armazenado como JSONB)
+// Contact representa os contatos (armazenado como JSONB, telefones em E.164)
 type Contact struct {
-	Phone       string `json:"phone"`
-	MobilePhone string `json:"mobile_phone" binding:"required"`
-	WhatsApp    string `json:"whatsapp"`
+	Phone       string `json:"phone" binding:"omitempty,phone"`
+	MobilePhone string `json:"mobile_phone" binding:"required,phone"`
+	WhatsApp    string `json:"whatsapp" binding:"omitempty,phone"`
 }
 
 // User representa as informações pessoais do usuário
 type User struct {
-	ID        uuid.UUID `db:"id" json:"id"`
-	AuthID    uuid.UUID `db:"auth_id" json:"auth_id"`
-	FullName  string    `db:"full_name" json:"full_name" binding:"required,min=3"`
-	CPF       string    `db:"cpf" json:"cpf" binding:"required,len=11"`
-	BirthDate time.Time `db:"birth_date" json:"birth_date" binding:"required"`
-	Address   Address   `db:"address" json:"address" binding:"required"`
-	Contact   Contact   `db:"contact" json:"contact" binding:"required"`
-	CreatedAt time.Time `db:"created_at" json:"created_at"`
-	UpdatedAt time.Time `db:"updated_at" json:"updated_at"`
+	ID             uuid.UUID  `db:"id" json:"id"`
+	AuthID         uuid.UUID  `db:"auth_id" json:"auth_id"`
+	FullName       string     `db:"full_name" json:"full_name" binding:"required,min=3"`
+	CPF            string     `db:"cpf" json:"cpf" binding:"required,cpf"`
+	BirthDate      time.Time  `db:"birth_date" json:"birth_date" binding:"required"`
+	Address        Address    `db:"address" json:"address" binding:"required"`
+	Contact        Contact    `db:"contact" json:"contact" binding:"required"`
+	Timezone       string     `db:"timezone" json:"timezone"`               // Nome IANA, ex.: "America/Sao_Paulo"
+	ClassReminders bool       `db:"class_reminders" json:"class_reminders"` // Recebe lembretes por e-mail antes das aulas
+	CreatedAt      time.Time  `db:"created_at" json:"created_at"`
+	UpdatedAt      time.Time  `db:"updated_at" json:"updated_at"`
+	DeletedAt      *time.Time `db:"deleted_at" json:"deleted_at,omitempty"`
 }
 
 // ActivationToken representa o token de ativação de conta
@@ -91,5 +94,7 @@ type PasswordResetToken struct {
 // UserWithAuth combina Auth e User para respostas completas
 type UserWithAuth struct {
 	Auth
-	User *User `json:"user,omitempty"`
+	User    *User          `json:"user,omitempty"`
+	Levels  []StudentLevel `json:"levels,omitempty"`  // Níveis CEFR atuais (apenas alunos)
+	Credits *CreditBalance `json:"credits,omitempty"` // Saldo de créditos de aula (apenas alunos)
 }
@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WaitlistStatus representa a situação do aluno na lista de espera
+type WaitlistStatus string
+
+const (
+	WaitlistStatusWaiting   WaitlistStatus = "waiting"   // Aguardando vaga
+	WaitlistStatusOffered   WaitlistStatus = "offered"   // Vaga oferecida, aguardando confirmação
+	WaitlistStatusConfirmed WaitlistStatus = "confirmed" // Vaga confirmada (aluno matriculado)
+	WaitlistStatusExpired   WaitlistStatus = "expired"   // Oferta não confirmada no prazo
+	WaitlistStatusLeft      WaitlistStatus = "left"      // Aluno saiu da fila
+)
+
+// WaitlistEntry representa um aluno na lista de espera de uma aula
+type WaitlistEntry struct {
+	ID             uuid.UUID      `db:"id" json:"id"`
+	ClassID        uuid.UUID      `db:"class_id" json:"class_id"`
+	StudentID      uuid.UUID      `db:"student_id" json:"student_id"`
+	Position       int            `db:"position" json:"position"`
+	Status         WaitlistStatus `db:"status" json:"status"`
+	OfferToken     *string        `db:"offer_token" json:"-"`
+	OfferedAt      *time.Time     `db:"offered_at" json:"offered_at,omitempty"`
+	OfferExpiresAt *time.Time     `db:"offer_expires_at" json:"offer_expires_at,omitempty"`
+	CreatedAt      time.Time      `db:"created_at" json:"created_at"`
+	UpdatedAt      time.Time      `db:"updated_at" json:"updated_at"`
+}
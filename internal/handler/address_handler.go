@@ -0,0 +1,55 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hscHeric/go-potential-api/internal/service"
+	"github.com/hscHeric/go-potential-api/pkg/cep"
+)
+
+type AddressHandler struct {
+	addressService service.AddressService
+}
+
+func NewAddressHandler(addressService service.AddressService) *AddressHandler {
+	return &AddressHandler{
+		addressService: addressService,
+	}
+}
+
+// LookupCEP godoc
+// @Summary Consultar CEP
+// @Description Retorna o endereço correspondente ao CEP para preenchimento automático
+// @Tags endereços
+// @Produce json
+// @Param cep path string true "CEP (com ou sem hífen)"
+// @Success 200 {object} cep.Address
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /addresses/cep/{cep} [get]
+func (h *AddressHandler) LookupCEP(c *gin.Context) {
+	address, err := h.addressService.LookupCEP(c.Param("cep"))
+	if err != nil {
+		if errors.Is(err, cep.ErrInvalidCEP) {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error: "CEP inválido",
+			})
+			return
+		}
+		if errors.Is(err, cep.ErrNotFound) {
+			c.JSON(http.StatusNotFound, ErrorResponse{
+				Error: "CEP não encontrado",
+			})
+			return
+		}
+
+		c.JSON(http.StatusBadGateway, ErrorResponse{
+			Error: "Falha ao consultar o CEP",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, address)
+}
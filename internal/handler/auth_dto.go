@@ -16,11 +16,12 @@ type CreateInvitationRequest struct {
 type CompleteRegistrationRequest struct {
 	Token     string         `json:"token" binding:"required"`
 	FullName  string         `json:"full_name" binding:"required,min=3"`
-	CPF       string         `json:"cpf" binding:"required,len=11"`
+	CPF       string         `json:"cpf" binding:"required,cpf"`
 	BirthDate time.Time      `json:"birth_date" binding:"required"`
 	Address   domain.Address `json:"address" binding:"required"`
 	Contact   domain.Contact `json:"contact" binding:"required"`
 	Password  string         `json:"password" binding:"required,min=8"`
+	Timezone  *string        `json:"timezone"` // Nome IANA; padrão America/Sao_Paulo
 }
 
 // LoginRequest representa o payload de login
@@ -8,6 +8,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/hscHeric/go-potential-api/internal/repository"
 	"github.com/hscHeric/go-potential-api/internal/service"
+	"github.com/hscHeric/go-potential-api/pkg/timezone"
 	"github.com/hscHeric/go-potential-api/pkg/validator"
 )
 
@@ -132,6 +133,14 @@ func (h *AuthHandler) CompleteRegistration(c *gin.Context) {
 	var req CompleteRegistrationRequest
 
 	if err := c.ShouldBindJSON(&req); err != nil {
+		// Erros de validação (CPF, telefone, etc.) retornam os detalhes por campo
+		if details := validator.FormatValidationErrors(err); len(details) > 0 {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error:   "Falha na validação",
+				Details: details,
+			})
+			return
+		}
 		c.JSON(http.StatusBadRequest, ErrorResponse{
 			Error: "Corpo da requisição inválido",
 		})
@@ -154,6 +163,9 @@ func (h *AuthHandler) CompleteRegistration(c *gin.Context) {
 		Contact:   req.Contact,
 		Password:  req.Password,
 	}
+	if req.Timezone != nil {
+		userData.Timezone = *req.Timezone
+	}
 
 	if err := h.authService.CompleteRegistration(req.Token, userData); err != nil {
 		if errors.Is(err, service.ErrTokenExpired) {
@@ -174,12 +186,25 @@ func (h *AuthHandler) CompleteRegistration(c *gin.Context) {
 			})
 			return
 		}
+		if errors.Is(err, service.ErrInvalidCPF) {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error: "CPF inválido",
+			})
+			return
+		}
 		if errors.Is(err, repository.ErrCPFAlreadyExists) {
 			c.JSON(http.StatusConflict, ErrorResponse{
 				Error: "Este CPF já está cadastrado",
 			})
 			return
 		}
+		if errors.Is(err, service.ErrInvalidAddress) || errors.Is(err, service.ErrInvalidContact) ||
+			errors.Is(err, timezone.ErrInvalidZone) {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error: err.Error(),
+			})
+			return
+		}
 
 		c.JSON(http.StatusInternalServerError, ErrorResponse{
 			Error: "Falha ao completar registro",
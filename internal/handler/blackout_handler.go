@@ -0,0 +1,258 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/hscHeric/go-potential-api/internal/domain"
+	"github.com/hscHeric/go-potential-api/internal/middleware"
+	"github.com/hscHeric/go-potential-api/internal/repository"
+	"github.com/hscHeric/go-potential-api/internal/service"
+)
+
+type BlackoutHandler struct {
+	blackoutService service.BlackoutService
+}
+
+func NewBlackoutHandler(blackoutService service.BlackoutService) *BlackoutHandler {
+	return &BlackoutHandler{
+		blackoutService: blackoutService,
+	}
+}
+
+// CreateMyBlackout godoc
+// @Summary Create blackout period
+// @Description Teacher registers a vacation or time off period. The response lists scheduled classes that fall inside it.
+// @Tags teachers
+// @Accept json
+// @Produce json
+// @Param request body service.CreateBlackoutInput true "Blackout period"
+// @Success 201 {object} domain.BlackoutReport
+// @Failure 400 {object} ErrorResponse
+// @Router /api/v1/teachers/me/blackouts [post]
+// @Security BearerAuth
+func (h *BlackoutHandler) CreateMyBlackout(c *gin.Context) {
+	authID, err := middleware.GetAuthID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error: "Unauthorized",
+		})
+		return
+	}
+
+	h.createBlackout(c, authID, authID)
+}
+
+// CreateTeacherBlackout godoc
+// @Summary Create blackout period for a teacher
+// @Description Admin registers a vacation or time off period for a teacher. The response lists scheduled classes that fall inside it.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path string true "Teacher ID"
+// @Param request body service.CreateBlackoutInput true "Blackout period"
+// @Success 201 {object} domain.BlackoutReport
+// @Failure 400 {object} ErrorResponse
+// @Router /api/v1/admin/teachers/{id}/blackouts [post]
+// @Security BearerAuth
+func (h *BlackoutHandler) CreateTeacherBlackout(c *gin.Context) {
+	authID, err := middleware.GetAuthID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error: "Unauthorized",
+		})
+		return
+	}
+
+	teacherID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "Invalid teacher ID",
+		})
+		return
+	}
+
+	h.createBlackout(c, teacherID, authID)
+}
+
+func (h *BlackoutHandler) createBlackout(c *gin.Context, teacherID, createdBy uuid.UUID) {
+	var req service.CreateBlackoutInput
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "Invalid request body",
+		})
+		return
+	}
+
+	report, err := h.blackoutService.CreateBlackout(teacherID, createdBy, &req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, report)
+}
+
+// GetMyBlackout godoc
+// @Summary Get blackout period
+// @Description Get one of the teacher's blackout periods with the scheduled classes that still fall inside it
+// @Tags teachers
+// @Produce json
+// @Param blackout_id path string true "Blackout ID"
+// @Success 200 {object} domain.BlackoutReport
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v1/teachers/me/blackouts/{blackout_id} [get]
+// @Security BearerAuth
+func (h *BlackoutHandler) GetMyBlackout(c *gin.Context) {
+	authID, err := middleware.GetAuthID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error: "Unauthorized",
+		})
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("blackout_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "Invalid blackout ID",
+		})
+		return
+	}
+
+	report, err := h.blackoutService.GetBlackout(authID, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			c.JSON(http.StatusNotFound, ErrorResponse{
+				Error: "Blackout not found",
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error: "Failed to get blackout",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// DeleteMyBlackout godoc
+// @Summary Delete blackout period
+// @Description Remove one of the teacher's blackout periods
+// @Tags teachers
+// @Produce json
+// @Param blackout_id path string true "Blackout ID"
+// @Success 200 {object} MessageResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v1/teachers/me/blackouts/{blackout_id} [delete]
+// @Security BearerAuth
+func (h *BlackoutHandler) DeleteMyBlackout(c *gin.Context) {
+	authID, err := middleware.GetAuthID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error: "Unauthorized",
+		})
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("blackout_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "Invalid blackout ID",
+		})
+		return
+	}
+
+	if err := h.blackoutService.DeleteBlackout(authID, id); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			c.JSON(http.StatusNotFound, ErrorResponse{
+				Error: "Blackout not found",
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error: "Failed to delete blackout",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, MessageResponse{
+		Message: "Blackout deleted successfully",
+	})
+}
+
+// GetTeacherBlackouts godoc
+// @Summary Get teacher blackout periods
+// @Description Get the teacher's blackout periods overlapping the range (default: today through one year ahead)
+// @Tags teachers
+// @Produce json
+// @Param id path string true "Teacher ID"
+// @Param from query string false "Start date (YYYY-MM-DD)"
+// @Param to query string false "End date (YYYY-MM-DD)"
+// @Success 200 {array} domain.TeacherBlackout
+// @Failure 400 {object} ErrorResponse
+// @Router /api/v1/teachers/{id}/blackouts [get]
+// @Security BearerAuth
+func (h *BlackoutHandler) GetTeacherBlackouts(c *gin.Context) {
+	teacherID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "Invalid teacher ID",
+		})
+		return
+	}
+
+	// Datas sem horário, como as colunas DATE (default: hoje)
+	now := time.Now()
+	from := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	if fromStr := c.Query("from"); fromStr != "" {
+		from, err = time.Parse("2006-01-02", fromStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error: "Invalid from date (use YYYY-MM-DD)",
+			})
+			return
+		}
+	}
+
+	to := from.AddDate(1, 0, 0)
+	if toStr := c.Query("to"); toStr != "" {
+		to, err = time.Parse("2006-01-02", toStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error: "Invalid to date (use YYYY-MM-DD)",
+			})
+			return
+		}
+	}
+
+	blackouts, err := h.blackoutService.GetTeacherBlackouts(teacherID, from, to)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidDateRange) {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error: err.Error(),
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error: "Failed to get blackouts",
+		})
+		return
+	}
+
+	if blackouts == nil {
+		blackouts = []domain.TeacherBlackout{}
+	}
+
+	c.JSON(http.StatusOK, blackouts)
+}
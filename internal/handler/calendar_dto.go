@@ -1,6 +1,9 @@
 package handler
 
-import "github.com/google/uuid"
+import (
+	"github.com/google/uuid"
+	"github.com/hscHeric/go-potential-api/internal/domain"
+)
 
 // ToggleAvailabilityRequest para ativar/desativar time slot
 type ToggleAvailabilityRequest struct {
@@ -9,7 +12,19 @@ type ToggleAvailabilityRequest struct {
 
 // AddStudentRequest para adicionar aluno a uma aula
 type AddStudentRequest struct {
-	StudentID uuid.UUID `json:"student_id" binding:"required"`
+	StudentID         uuid.UUID `json:"student_id" binding:"required"`
+	OverrideConflicts bool      `json:"override_conflicts"` // Apenas admins: adiciona mesmo se o aluno tiver outra aula no horário
+}
+
+// StudentConflictResponse lista as aulas que impedem o agendamento dos alunos
+type StudentConflictResponse struct {
+	Error     string                   `json:"error"`
+	Conflicts []domain.StudentConflict `json:"conflicts"`
+}
+
+// UpdateClassStatusRequest para mudar o status da aula
+type UpdateClassStatusRequest struct {
+	Status domain.ClassStatus `json:"status" binding:"required,oneof=completed cancelled no_show"`
 }
 
 // AttendanceRequest para marcar presença
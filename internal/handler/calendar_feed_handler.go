@@ -0,0 +1,163 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hscHeric/go-potential-api/internal/middleware"
+	"github.com/hscHeric/go-potential-api/internal/repository"
+	"github.com/hscHeric/go-potential-api/internal/service"
+	"github.com/hscHeric/go-potential-api/pkg/ical"
+)
+
+type CalendarFeedHandler struct {
+	feedService service.CalendarFeedService
+}
+
+func NewCalendarFeedHandler(feedService service.CalendarFeedService) *CalendarFeedHandler {
+	return &CalendarFeedHandler{
+		feedService: feedService,
+	}
+}
+
+// GetMyFeed godoc
+// @Summary Get my calendar feed
+// @Description Get the subscription URL of the authenticated user's class calendar (.ics)
+// @Tags calendar
+// @Produce json
+// @Success 200 {object} domain.CalendarFeed
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v1/calendar/feed [get]
+// @Security BearerAuth
+func (h *CalendarFeedHandler) GetMyFeed(c *gin.Context) {
+	authID, err := middleware.GetAuthID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error: "Unauthorized",
+		})
+		return
+	}
+
+	feed, err := h.feedService.GetFeed(authID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			c.JSON(http.StatusNotFound, ErrorResponse{
+				Error: "Calendar feed not created yet",
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error: "Failed to get calendar feed",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, feed)
+}
+
+// RotateMyFeed godoc
+// @Summary Create or rotate my calendar feed
+// @Description Create the calendar subscription URL, or replace it with a new one. The previous URL stops working.
+// @Tags calendar
+// @Produce json
+// @Success 201 {object} domain.CalendarFeed
+// @Failure 403 {object} ErrorResponse
+// @Router /api/v1/calendar/feed [post]
+// @Security BearerAuth
+func (h *CalendarFeedHandler) RotateMyFeed(c *gin.Context) {
+	authID, err := middleware.GetAuthID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error: "Unauthorized",
+		})
+		return
+	}
+
+	feed, err := h.feedService.RotateFeed(authID)
+	if err != nil {
+		if errors.Is(err, service.ErrFeedNotAvailable) {
+			c.JSON(http.StatusForbidden, ErrorResponse{
+				Error: err.Error(),
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error: "Failed to create calendar feed",
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, feed)
+}
+
+// RevokeMyFeed godoc
+// @Summary Revoke my calendar feed
+// @Description Revoke the calendar subscription URL
+// @Tags calendar
+// @Produce json
+// @Success 200 {object} MessageResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v1/calendar/feed [delete]
+// @Security BearerAuth
+func (h *CalendarFeedHandler) RevokeMyFeed(c *gin.Context) {
+	authID, err := middleware.GetAuthID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error: "Unauthorized",
+		})
+		return
+	}
+
+	if err := h.feedService.RevokeFeed(authID); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			c.JSON(http.StatusNotFound, ErrorResponse{
+				Error: "Calendar feed not found",
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error: "Failed to revoke calendar feed",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, MessageResponse{
+		Message: "Calendar feed revoked successfully",
+	})
+}
+
+// GetFeed godoc
+// @Summary Calendar feed (.ics)
+// @Description Public iCalendar (RFC 5545) feed with the classes of the feed owner. The token in the URL is the credential.
+// @Tags calendar
+// @Produce text/calendar
+// @Param token path string true "Feed token (the .ics suffix is optional)"
+// @Success 200 {string} string "iCalendar data"
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v1/feeds/{token} [get]
+func (h *CalendarFeedHandler) GetFeed(c *gin.Context) {
+	feedToken := strings.TrimSuffix(c.Param("token"), ".ics")
+
+	data, err := h.feedService.RenderFeed(feedToken)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) || errors.Is(err, service.ErrFeedNotAvailable) {
+			c.JSON(http.StatusNotFound, ErrorResponse{
+				Error: "Calendar feed not found",
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error: "Failed to render calendar feed",
+		})
+		return
+	}
+
+	c.Header("Content-Disposition", `inline; filename="aulas.ics"`)
+	c.Data(http.StatusOK, ical.ContentType, data)
+}
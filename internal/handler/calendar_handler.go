@@ -0,0 +1,115 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/hscHeric/go-potential-api/internal/domain"
+	"github.com/hscHeric/go-potential-api/internal/middleware"
+	"github.com/hscHeric/go-potential-api/internal/repository"
+	"github.com/hscHeric/go-potential-api/internal/service"
+)
+
+type CalendarHandler struct {
+	calendarService service.CalendarService
+	timezones       service.TimezoneService
+}
+
+func NewCalendarHandler(calendarService service.CalendarService, timezones service.TimezoneService) *CalendarHandler {
+	return &CalendarHandler{
+		calendarService: calendarService,
+		timezones:       timezones,
+	}
+}
+
+// GetCalendar godoc
+// @Summary Get calendar
+// @Description Get a normalized list of calendar events (classes, free slot occurrences, blackouts and holidays) for a week or month. Teachers see their own calendar, admins any teacher's calendar and students their classes plus the free slots of the given teacher.
+// @Tags calendar
+// @Produce json
+// @Param view query string false "Period: week (Monday to Sunday, default) or month"
+// @Param from query string false "Any date within the period (YYYY-MM-DD, default today)"
+// @Param teacher_id query string false "Teacher to display (required for admins; optional for students)"
+// @Param tz query string false "IANA time zone for starts_at/ends_at (default: user time zone)"
+// @Success 200 {object} domain.Calendar
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v1/calendar [get]
+// @Security BearerAuth
+func (h *CalendarHandler) GetCalendar(c *gin.Context) {
+	authID, err := middleware.GetAuthID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error: "Unauthorized",
+		})
+		return
+	}
+
+	loc, ok := viewerLocation(c, h.timezones)
+	if !ok {
+		return
+	}
+
+	input := &service.CalendarInput{
+		View:     domain.CalendarView(c.DefaultQuery("view", string(domain.CalendarViewWeek))),
+		Date:     h.timezones.Today(),
+		Location: loc,
+	}
+
+	if !input.View.IsValid() {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "Invalid view (must be week or month)",
+		})
+		return
+	}
+
+	if fromStr := c.Query("from"); fromStr != "" {
+		input.Date, err = time.Parse("2006-01-02", fromStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error: "Invalid from date (use YYYY-MM-DD)",
+			})
+			return
+		}
+	}
+
+	if teacherIDStr := c.Query("teacher_id"); teacherIDStr != "" {
+		teacherID, err := uuid.Parse(teacherIDStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error: "Invalid teacher ID",
+			})
+			return
+		}
+		input.TeacherID = &teacherID
+	}
+
+	calendar, err := h.calendarService.GetCalendar(authID, input)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrCalendarTeacherRequired):
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error: err.Error(),
+			})
+		case errors.Is(err, service.ErrCalendarForbidden):
+			c.JSON(http.StatusForbidden, ErrorResponse{
+				Error: err.Error(),
+			})
+		case errors.Is(err, repository.ErrNotFound):
+			c.JSON(http.StatusNotFound, ErrorResponse{
+				Error: "Teacher not found",
+			})
+		default:
+			c.JSON(http.StatusInternalServerError, ErrorResponse{
+				Error: "Failed to get calendar",
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, calendar)
+}
@@ -1,24 +1,33 @@
 package handler
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/hscHeric/go-potential-api/internal/domain"
 	"github.com/hscHeric/go-potential-api/internal/middleware"
+	"github.com/hscHeric/go-potential-api/internal/repository"
 	"github.com/hscHeric/go-potential-api/internal/service"
+	"github.com/hscHeric/go-potential-api/pkg/cancellation"
 	"github.com/hscHeric/go-potential-api/pkg/validator"
 )
 
 type ClassHandler struct {
-	classService service.ClassService
+	classService       service.ClassService
+	classSeriesService service.ClassSeriesService
+	timezones          service.TimezoneService
 }
 
-func NewClassHandler(classService service.ClassService) *ClassHandler {
+func NewClassHandler(classService service.ClassService, classSeriesService service.ClassSeriesService, timezones service.TimezoneService) *ClassHandler {
 	return &ClassHandler{
-		classService: classService,
+		classService:       classService,
+		classSeriesService: classSeriesService,
+		timezones:          timezones,
 	}
 }
 
@@ -29,9 +38,12 @@ func NewClassHandler(classService service.ClassService) *ClassHandler {
 // @Accept json
 // @Produce json
 // @Param request body service.CreateClassInput true "Class data"
+// @Param tz query string false "IANA time zone for starts_at/ends_at (default: user time zone)"
 // @Success 201 {object} domain.Class
 // @Failure 400 {object} ErrorResponse
 // @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 409 {object} StudentConflictResponse
 // @Router /api/v1/classes [post]
 // @Security BearerAuth
 func (h *ClassHandler) CreateClass(c *gin.Context) {
@@ -43,6 +55,11 @@ func (h *ClassHandler) CreateClass(c *gin.Context) {
 		return
 	}
 
+	loc, ok := viewerLocation(c, h.timezones)
+	if !ok {
+		return
+	}
+
 	var req service.CreateClassInput
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, ErrorResponse{
@@ -61,12 +78,17 @@ func (h *ClassHandler) CreateClass(c *gin.Context) {
 
 	class, err := h.classService.CreateClass(authID, &req)
 	if err != nil {
+		if respondStudentConflict(c, err, loc) {
+			return
+		}
+
 		c.JSON(http.StatusBadRequest, ErrorResponse{
 			Error: err.Error(),
 		})
 		return
 	}
 
+	class.Localize(loc)
 	c.JSON(http.StatusCreated, class)
 }
 
@@ -78,8 +100,12 @@ func (h *ClassHandler) CreateClass(c *gin.Context) {
 // @Produce json
 // @Param id path string true "Class ID"
 // @Param request body AddStudentRequest true "Student ID"
+// @Param tz query string false "IANA time zone for conflicting classes (default: user time zone)"
 // @Success 200 {object} MessageResponse
 // @Failure 400 {object} ErrorResponse
+// @Failure 402 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 409 {object} StudentConflictResponse
 // @Router /api/v1/classes/{id}/students [post]
 // @Security BearerAuth
 func (h *ClassHandler) AddStudentToClass(c *gin.Context) {
@@ -99,6 +125,11 @@ func (h *ClassHandler) AddStudentToClass(c *gin.Context) {
 		return
 	}
 
+	loc, ok := viewerLocation(c, h.timezones)
+	if !ok {
+		return
+	}
+
 	var req AddStudentRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, ErrorResponse{
@@ -107,7 +138,25 @@ func (h *ClassHandler) AddStudentToClass(c *gin.Context) {
 		return
 	}
 
-	if err := h.classService.AddStudentToClass(classID, req.StudentID, authID); err != nil {
+	if err := h.classService.AddStudentToClass(classID, req.StudentID, authID, req.OverrideConflicts); err != nil {
+		if respondStudentConflict(c, err, loc) {
+			return
+		}
+
+		if errors.Is(err, service.ErrClassFull) {
+			c.JSON(http.StatusConflict, ErrorResponse{
+				Error: "Class is full, students can join the waitlist",
+			})
+			return
+		}
+
+		if errors.Is(err, repository.ErrNoCredits) {
+			c.JSON(http.StatusPaymentRequired, ErrorResponse{
+				Error: "No class credits available",
+			})
+			return
+		}
+
 		c.JSON(http.StatusBadRequest, ErrorResponse{
 			Error: err.Error(),
 		})
@@ -165,11 +214,17 @@ func (h *ClassHandler) RemoveStudentFromClass(c *gin.Context) {
 // @Tags classes
 // @Produce json
 // @Param id path string true "Class ID"
+// @Param tz query string false "IANA time zone for starts_at/ends_at (default: user time zone)"
 // @Success 200 {object} domain.ClassWithDetails
 // @Failure 400 {object} ErrorResponse
 // @Router /api/v1/classes/{id} [get]
 // @Security BearerAuth
 func (h *ClassHandler) GetClass(c *gin.Context) {
+	loc, ok := viewerLocation(c, h.timezones)
+	if !ok {
+		return
+	}
+
 	id, err := uuid.Parse(c.Param("id"))
 	if err != nil {
 		c.JSON(http.StatusBadRequest, ErrorResponse{
@@ -186,18 +241,23 @@ func (h *ClassHandler) GetClass(c *gin.Context) {
 		return
 	}
 
+	class.Localize(loc)
 	c.JSON(http.StatusOK, class)
 }
 
 // GetMyClasses godoc
 // @Summary Get my classes
-// @Description Get classes for authenticated user (as teacher or student)
+// @Description Get classes for authenticated user (as teacher or student). Admins use GET /classes.
 // @Tags classes
 // @Produce json
 // @Param start_date query string false "Start date (YYYY-MM-DD)"
 // @Param end_date query string false "End date (YYYY-MM-DD)"
+// @Param language query string false "Filter by language"
+// @Param level query string false "Filter by CEFR level (A1-C2)"
+// @Param tz query string false "IANA time zone for starts_at/ends_at (default: user time zone)"
 // @Success 200 {array} domain.Class
 // @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
 // @Router /api/v1/classes/me [get]
 // @Security BearerAuth
 func (h *ClassHandler) GetMyClasses(c *gin.Context) {
@@ -209,8 +269,21 @@ func (h *ClassHandler) GetMyClasses(c *gin.Context) {
 		return
 	}
 
+	loc, ok := viewerLocation(c, h.timezones)
+	if !ok {
+		return
+	}
+
 	role, _ := middleware.GetRole(c)
 
+	// Admins não têm aulas próprias; a agenda de todos os professores fica em GET /classes
+	if role == string(domain.RoleAdmin) {
+		c.JSON(http.StatusForbidden, ErrorResponse{
+			Error: "Admins have no classes of their own, use GET /classes",
+		})
+		return
+	}
+
 	// Parse dates
 	startDate := time.Now().AddDate(0, 0, -30) // Default: 30 days ago
 	endDate := time.Now().AddDate(0, 0, 30)    // Default: 30 days ahead
@@ -227,11 +300,23 @@ func (h *ClassHandler) GetMyClasses(c *gin.Context) {
 		}
 	}
 
+	filter := domain.ClassFilter{
+		Language: c.Query("language"),
+		Level:    domain.LanguageLevel(c.Query("level")),
+	}
+
+	if filter.Level != "" && !filter.Level.IsValid() {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "Invalid level (must be A1, A2, B1, B2, C1 or C2)",
+		})
+		return
+	}
+
 	var classes []domain.Class
 	if role == string(domain.RoleTeacher) {
-		classes, err = h.classService.GetTeacherClasses(authID, startDate, endDate)
+		classes, err = h.classService.GetTeacherClasses(authID, startDate, endDate, filter)
 	} else {
-		classes, err = h.classService.GetStudentClasses(authID, startDate, endDate)
+		classes, err = h.classService.GetStudentClasses(authID, startDate, endDate, filter)
 	}
 
 	if err != nil {
@@ -241,9 +326,168 @@ func (h *ClassHandler) GetMyClasses(c *gin.Context) {
 		return
 	}
 
+	for i := range classes {
+		classes[i].Localize(loc)
+	}
+
 	c.JSON(http.StatusOK, classes)
 }
 
+// ListClasses godoc
+// @Summary List all classes
+// @Description List the classes of all teachers with filters and pagination, ordered by start time (Admin only)
+// @Tags classes
+// @Produce json
+// @Param teacher_id query string false "Filter by teacher"
+// @Param student_id query string false "Filter by enrolled student"
+// @Param status query string false "Filter by status (scheduled, completed, cancelled or no_show)"
+// @Param start_date query string false "Start date (YYYY-MM-DD)"
+// @Param end_date query string false "End date (YYYY-MM-DD)"
+// @Param language query string false "Filter by language"
+// @Param page query int false "Page number (default 1)"
+// @Param page_size query int false "Page size (default 50, max 200)"
+// @Param tz query string false "IANA time zone for starts_at/ends_at (default: user time zone)"
+// @Success 200 {object} domain.ClassPage
+// @Failure 400 {object} ErrorResponse
+// @Router /api/v1/classes [get]
+// @Security BearerAuth
+func (h *ClassHandler) ListClasses(c *gin.Context) {
+	loc, ok := viewerLocation(c, h.timezones)
+	if !ok {
+		return
+	}
+
+	filter, ok := parseClassListFilter(c)
+	if !ok {
+		return
+	}
+
+	var err error
+	if filter.StartDate, err = parseOptionalDate(c.Query("start_date")); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "Invalid start_date (use YYYY-MM-DD)",
+		})
+		return
+	}
+
+	if filter.EndDate, err = parseOptionalDate(c.Query("end_date")); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "Invalid end_date (use YYYY-MM-DD)",
+		})
+		return
+	}
+
+	if pageStr := c.Query("page"); pageStr != "" {
+		if filter.Page, err = strconv.Atoi(pageStr); err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error: "Invalid page",
+			})
+			return
+		}
+	}
+
+	if pageSizeStr := c.Query("page_size"); pageSizeStr != "" {
+		if filter.PageSize, err = strconv.Atoi(pageSizeStr); err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error: "Invalid page_size",
+			})
+			return
+		}
+	}
+
+	page, err := h.classService.ListClasses(filter)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidDateRange) {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error: err.Error(),
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error: "Failed to list classes",
+		})
+		return
+	}
+
+	for i := range page.Classes {
+		page.Classes[i].Localize(loc)
+	}
+
+	c.JSON(http.StatusOK, page)
+}
+
+// GetScheduleGrid godoc
+// @Summary Get schedule grid
+// @Description Get the classes of all teachers grouped by teacher and day, with the number of students of each class (Admin only)
+// @Tags classes
+// @Produce json
+// @Param from query string false "Start date (YYYY-MM-DD, default today)"
+// @Param to query string false "End date (YYYY-MM-DD, default from + 6 days, max 14 days)"
+// @Param teacher_id query string false "Filter by teacher"
+// @Param student_id query string false "Filter by enrolled student"
+// @Param status query string false "Filter by status (scheduled, completed, cancelled or no_show)"
+// @Param language query string false "Filter by language"
+// @Param tz query string false "IANA time zone for starts_at/ends_at (default: user time zone)"
+// @Success 200 {object} domain.ScheduleGrid
+// @Failure 400 {object} ErrorResponse
+// @Router /api/v1/classes/grid [get]
+// @Security BearerAuth
+func (h *ClassHandler) GetScheduleGrid(c *gin.Context) {
+	loc, ok := viewerLocation(c, h.timezones)
+	if !ok {
+		return
+	}
+
+	filter, ok := parseClassListFilter(c)
+	if !ok {
+		return
+	}
+
+	from := h.timezones.Today()
+	if fromStr := c.Query("from"); fromStr != "" {
+		parsed, err := time.Parse("2006-01-02", fromStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error: "Invalid from date (use YYYY-MM-DD)",
+			})
+			return
+		}
+		from = parsed
+	}
+
+	to := from.AddDate(0, 0, 6)
+	if toStr := c.Query("to"); toStr != "" {
+		parsed, err := time.Parse("2006-01-02", toStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error: "Invalid to date (use YYYY-MM-DD)",
+			})
+			return
+		}
+		to = parsed
+	}
+	filter.StartDate, filter.EndDate = &from, &to
+
+	grid, err := h.classService.GetScheduleGrid(filter)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidDateRange) {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error: err.Error(),
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error: "Failed to get schedule grid",
+		})
+		return
+	}
+
+	grid.Localize(loc)
+	c.JSON(http.StatusOK, grid)
+}
+
 // UpdateClass godoc
 // @Summary Update class
 // @Description Update class details (title, description, link, material)
@@ -251,6 +495,7 @@ func (h *ClassHandler) GetMyClasses(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param id path string true "Class ID"
+// @Param scope query string false "Series scope: this (default), following or all"
 // @Param request body service.UpdateClassInput true "Class data"
 // @Success 200 {object} MessageResponse
 // @Failure 400 {object} ErrorResponse
@@ -273,6 +518,22 @@ func (h *ClassHandler) UpdateClass(c *gin.Context) {
 		return
 	}
 
+	scope := domain.SeriesScope(c.DefaultQuery("scope", string(domain.SeriesScopeThis)))
+	if scope != domain.SeriesScopeThis {
+		count, err := h.classSeriesService.UpdateOccurrences(id, scope, &req)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error: err.Error(),
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, MessageResponse{
+			Message: fmt.Sprintf("%d classes updated successfully", count),
+		})
+		return
+	}
+
 	if err := h.classService.UpdateClass(id, &req); err != nil {
 		c.JSON(http.StatusBadRequest, ErrorResponse{
 			Error: err.Error(),
@@ -291,8 +552,10 @@ func (h *ClassHandler) UpdateClass(c *gin.Context) {
 // @Tags classes
 // @Produce json
 // @Param id path string true "Class ID"
+// @Param scope query string false "Series scope: this (default), following or all"
 // @Success 200 {object} MessageResponse
 // @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
 // @Router /api/v1/classes/{id}/cancel [patch]
 // @Security BearerAuth
 func (h *ClassHandler) CancelClass(c *gin.Context) {
@@ -312,7 +575,30 @@ func (h *ClassHandler) CancelClass(c *gin.Context) {
 		return
 	}
 
+	scope := domain.SeriesScope(c.DefaultQuery("scope", string(domain.SeriesScopeThis)))
+	if scope != domain.SeriesScopeThis {
+		count, err := h.classSeriesService.CancelOccurrences(id, scope, authID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error: err.Error(),
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, MessageResponse{
+			Message: fmt.Sprintf("%d classes cancelled successfully", count),
+		})
+		return
+	}
+
 	if err := h.classService.CancelClass(id, authID); err != nil {
+		if errors.Is(err, cancellation.ErrNotAllowed) {
+			c.JSON(http.StatusForbidden, ErrorResponse{
+				Error: err.Error(),
+			})
+			return
+		}
+
 		c.JSON(http.StatusBadRequest, ErrorResponse{
 			Error: err.Error(),
 		})
@@ -324,6 +610,133 @@ func (h *ClassHandler) CancelClass(c *gin.Context) {
 	})
 }
 
+// UpdateClassStatus godoc
+// @Summary Update class status
+// @Description Move a class to completed, no_show or cancelled (Teacher of the class or Admin). Cancelled classes cannot change status; completed and no_show only apply to classes that already started and can be corrected into each other.
+// @Tags classes
+// @Accept json
+// @Produce json
+// @Param id path string true "Class ID"
+// @Param request body UpdateClassStatusRequest true "New status"
+// @Param tz query string false "IANA time zone for starts_at/ends_at (default: user time zone)"
+// @Success 200 {object} domain.Class
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /api/v1/classes/{id}/status [patch]
+// @Security BearerAuth
+func (h *ClassHandler) UpdateClassStatus(c *gin.Context) {
+	authID, err := middleware.GetAuthID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error: "Unauthorized",
+		})
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "Invalid class ID",
+		})
+		return
+	}
+
+	loc, ok := viewerLocation(c, h.timezones)
+	if !ok {
+		return
+	}
+
+	var req UpdateClassStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request body",
+			Details: validator.FormatValidationErrors(err),
+		})
+		return
+	}
+
+	class, err := h.classService.UpdateClassStatus(id, authID, req.Status)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrNotFound):
+			c.JSON(http.StatusNotFound, ErrorResponse{
+				Error: "Class not found",
+			})
+		case errors.Is(err, service.ErrNotClassTeacher), errors.Is(err, cancellation.ErrNotAllowed):
+			c.JSON(http.StatusForbidden, ErrorResponse{
+				Error: err.Error(),
+			})
+		case errors.Is(err, service.ErrInvalidStatusTransition):
+			c.JSON(http.StatusConflict, ErrorResponse{
+				Error: err.Error(),
+			})
+		default:
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error: err.Error(),
+			})
+		}
+		return
+	}
+
+	class.Localize(loc)
+	c.JSON(http.StatusOK, class)
+}
+
+// CancelParticipation godoc
+// @Summary Cancel own participation
+// @Description Cancel the authenticated student's participation in a class (Student only). The cancellation policy decides whether a late cancellation counts as a used class or a no-show.
+// @Tags classes
+// @Produce json
+// @Param id path string true "Class ID"
+// @Success 200 {object} domain.ClassStudent
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v1/classes/{id}/participation/cancel [patch]
+// @Security BearerAuth
+func (h *ClassHandler) CancelParticipation(c *gin.Context) {
+	authID, err := middleware.GetAuthID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error: "Unauthorized",
+		})
+		return
+	}
+
+	classID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "Invalid class ID",
+		})
+		return
+	}
+
+	classStudent, err := h.classService.CancelParticipation(classID, authID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			c.JSON(http.StatusNotFound, ErrorResponse{
+				Error: "Enrollment not found",
+			})
+			return
+		}
+		if errors.Is(err, cancellation.ErrNotAllowed) {
+			c.JSON(http.StatusForbidden, ErrorResponse{
+				Error: err.Error(),
+			})
+			return
+		}
+
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, classStudent)
+}
+
 // MarkAttendance godoc
 // @Summary Mark student attendance
 // @Description Mark if a student attended the class
@@ -373,3 +786,364 @@ func (h *ClassHandler) MarkAttendance(c *gin.Context) {
 		Message: "Attendance marked successfully",
 	})
 }
+
+// DeleteClass godoc
+// @Summary Delete class
+// @Description Soft delete a class (Admin only). It can be restored until it is purged.
+// @Tags classes
+// @Produce json
+// @Param id path string true "Class ID"
+// @Success 200 {object} MessageResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v1/classes/{id} [delete]
+// @Security BearerAuth
+func (h *ClassHandler) DeleteClass(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "Invalid class ID",
+		})
+		return
+	}
+
+	if err := h.classService.DeleteClass(id); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			c.JSON(http.StatusNotFound, ErrorResponse{
+				Error: "Class not found",
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error: "Failed to delete class",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, MessageResponse{
+		Message: "Class deleted successfully",
+	})
+}
+
+// RestoreClass godoc
+// @Summary Restore class
+// @Description Restore a soft-deleted class (Admin only)
+// @Tags admin
+// @Produce json
+// @Param id path string true "Class ID"
+// @Success 200 {object} MessageResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v1/admin/classes/{id}/restore [post]
+// @Security BearerAuth
+func (h *ClassHandler) RestoreClass(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "Invalid class ID",
+		})
+		return
+	}
+
+	if err := h.classService.RestoreClass(id); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			c.JSON(http.StatusNotFound, ErrorResponse{
+				Error: "Deleted class not found",
+			})
+			return
+		}
+
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, MessageResponse{
+		Message: "Class restored successfully",
+	})
+}
+
+// CreateBooking godoc
+// @Summary Book a time slot occurrence
+// @Description Book a seat in a teacher's time slot on a given date (Student only). Creates the class on the first booking and joins the existing group class afterwards.
+// @Tags bookings
+// @Accept json
+// @Produce json
+// @Param request body service.BookOccurrenceInput true "Occurrence to book"
+// @Param tz query string false "IANA time zone for starts_at/ends_at (default: user time zone)"
+// @Success 201 {object} domain.Class
+// @Failure 400 {object} ErrorResponse
+// @Failure 402 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /api/v1/bookings [post]
+// @Security BearerAuth
+func (h *ClassHandler) CreateBooking(c *gin.Context) {
+	authID, err := middleware.GetAuthID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error: "Unauthorized",
+		})
+		return
+	}
+
+	loc, ok := viewerLocation(c, h.timezones)
+	if !ok {
+		return
+	}
+
+	var req service.BookOccurrenceInput
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "Invalid request body",
+		})
+		return
+	}
+
+	class, err := h.classService.BookOccurrence(authID, &req)
+	if err != nil {
+		if respondStudentConflict(c, err, loc) {
+			return
+		}
+
+		switch {
+		case errors.Is(err, repository.ErrNotFound):
+			c.JSON(http.StatusNotFound, ErrorResponse{
+				Error: "Time slot not found",
+			})
+		case errors.Is(err, repository.ErrSlotFull):
+			c.JSON(http.StatusConflict, ErrorResponse{
+				Error: "Time slot is full",
+			})
+		case errors.Is(err, repository.ErrAlreadyBooked):
+			c.JSON(http.StatusConflict, ErrorResponse{
+				Error: "You are already booked in this class",
+			})
+		case errors.Is(err, repository.ErrTeacherUnavailable):
+			c.JSON(http.StatusConflict, ErrorResponse{
+				Error: "Teacher not available at this time",
+			})
+		case errors.Is(err, repository.ErrNoCredits):
+			c.JSON(http.StatusPaymentRequired, ErrorResponse{
+				Error: "No class credits available",
+			})
+		case errors.Is(err, service.ErrHoliday):
+			c.JSON(http.StatusConflict, ErrorResponse{
+				Error: err.Error(),
+			})
+		default:
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error: err.Error(),
+			})
+		}
+		return
+	}
+
+	class.Localize(loc)
+	c.JSON(http.StatusCreated, class)
+}
+
+// CreateClassSeries godoc
+// @Summary Create class series
+// @Description Create a weekly recurring series and generate its classes (Teacher or Admin). Dates in the past, excluded or in conflict are skipped and reported.
+// @Tags classes
+// @Accept json
+// @Produce json
+// @Param request body service.CreateClassSeriesInput true "Series data"
+// @Param tz query string false "IANA time zone for starts_at/ends_at (default: user time zone)"
+// @Success 201 {object} domain.ClassSeriesReport
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /api/v1/class-series [post]
+// @Security BearerAuth
+func (h *ClassHandler) CreateClassSeries(c *gin.Context) {
+	authID, err := middleware.GetAuthID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error: "Unauthorized",
+		})
+		return
+	}
+
+	loc, ok := viewerLocation(c, h.timezones)
+	if !ok {
+		return
+	}
+
+	var req service.CreateClassSeriesInput
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request body",
+			Details: validator.FormatValidationErrors(err),
+		})
+		return
+	}
+
+	report, err := h.classSeriesService.CreateSeries(authID, &req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	for i := range report.Created {
+		report.Created[i].Localize(loc)
+	}
+
+	c.JSON(http.StatusCreated, report)
+}
+
+// GetClassSeries godoc
+// @Summary Get class series
+// @Description Get a class series with its scheduled classes
+// @Tags classes
+// @Produce json
+// @Param id path string true "Series ID"
+// @Param tz query string false "IANA time zone for starts_at/ends_at (default: user time zone)"
+// @Success 200 {object} domain.ClassSeriesWithClasses
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v1/class-series/{id} [get]
+// @Security BearerAuth
+func (h *ClassHandler) GetClassSeries(c *gin.Context) {
+	loc, ok := viewerLocation(c, h.timezones)
+	if !ok {
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "Invalid series ID",
+		})
+		return
+	}
+
+	series, err := h.classSeriesService.GetSeries(id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			c.JSON(http.StatusNotFound, ErrorResponse{
+				Error: "Class series not found",
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error: "Failed to get class series",
+		})
+		return
+	}
+
+	for i := range series.Classes {
+		series.Classes[i].Localize(loc)
+	}
+
+	c.JSON(http.StatusOK, series)
+}
+
+// viewerLocation resolve o fuso em que os horários da resposta são exibidos: o
+// parâmetro ?tz= ou, na falta dele, o fuso cadastrado pelo usuário autenticado
+func viewerLocation(c *gin.Context, timezones service.TimezoneService) (*time.Location, bool) {
+	authID, _ := middleware.GetAuthID(c)
+
+	loc, err := timezones.Resolve(authID, c.Query("tz"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "Invalid time zone (use an IANA name such as America/Sao_Paulo)",
+		})
+		return nil, false
+	}
+
+	return loc, true
+}
+
+// parseClassListFilter lê os filtros de professor, aluno, status e idioma da agenda geral
+func parseClassListFilter(c *gin.Context) (domain.ClassListFilter, bool) {
+	filter := domain.ClassListFilter{
+		Status:   domain.ClassStatus(c.Query("status")),
+		Language: c.Query("language"),
+	}
+
+	if filter.Status != "" && !filter.Status.IsValid() {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "Invalid status (must be scheduled, completed, cancelled or no_show)",
+		})
+		return filter, false
+	}
+
+	var err error
+	if filter.TeacherID, err = parseOptionalUUID(c.Query("teacher_id")); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "Invalid teacher ID",
+		})
+		return filter, false
+	}
+
+	if filter.StudentID, err = parseOptionalUUID(c.Query("student_id")); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "Invalid student ID",
+		})
+		return filter, false
+	}
+
+	return filter, true
+}
+
+// parseOptionalUUID converte um ID opcional (vazio retorna nil)
+func parseOptionalUUID(value string) (*uuid.UUID, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	id, err := uuid.Parse(value)
+	if err != nil {
+		return nil, err
+	}
+
+	return &id, nil
+}
+
+// parseOptionalDate converte uma data YYYY-MM-DD opcional (vazia retorna nil)
+func parseOptionalDate(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	date, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return nil, err
+	}
+
+	return &date, nil
+}
+
+// respondStudentConflict responde 409 com as aulas em conflito, ou 403 para override
+// sem permissão. Retorna false se o erro não for de conflito de alunos.
+func respondStudentConflict(c *gin.Context, err error, loc *time.Location) bool {
+	if errors.Is(err, service.ErrOverrideNotAllowed) {
+		c.JSON(http.StatusForbidden, ErrorResponse{
+			Error: err.Error(),
+		})
+		return true
+	}
+
+	var conflict *service.StudentConflictError
+	if !errors.As(err, &conflict) {
+		return false
+	}
+
+	for i := range conflict.Conflicts {
+		for j := range conflict.Conflicts[i].Classes {
+			conflict.Conflicts[i].Classes[j].Localize(loc)
+		}
+	}
+
+	c.JSON(http.StatusConflict, StudentConflictResponse{
+		Error:     "Student schedule conflict",
+		Conflicts: conflict.Conflicts,
+	})
+	return true
+}
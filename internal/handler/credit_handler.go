@@ -0,0 +1,188 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/hscHeric/go-potential-api/internal/middleware"
+	"github.com/hscHeric/go-potential-api/internal/repository"
+	"github.com/hscHeric/go-potential-api/internal/service"
+)
+
+type CreditHandler struct {
+	creditService service.CreditService
+}
+
+func NewCreditHandler(creditService service.CreditService) *CreditHandler {
+	return &CreditHandler{
+		creditService: creditService,
+	}
+}
+
+// CreatePackage godoc
+// @Summary Add credit package
+// @Description Add a package of class credits to a student (Admin only)
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path string true "Student ID"
+// @Param request body service.CreatePackageInput true "Package data"
+// @Success 201 {object} domain.CreditPackage
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v1/admin/students/{id}/credits [post]
+// @Security BearerAuth
+func (h *CreditHandler) CreatePackage(c *gin.Context) {
+	authID, err := middleware.GetAuthID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error: "Unauthorized",
+		})
+		return
+	}
+
+	studentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "Invalid student ID",
+		})
+		return
+	}
+
+	var req service.CreatePackageInput
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "Invalid request body",
+		})
+		return
+	}
+
+	pkg, err := h.creditService.CreatePackage(studentID, authID, &req)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			c.JSON(http.StatusNotFound, ErrorResponse{
+				Error: "Student not found",
+			})
+			return
+		}
+
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, pkg)
+}
+
+// GetStudentCredits godoc
+// @Summary Get student credits
+// @Description Get the class credit balance and packages of a student (Admin only)
+// @Tags students
+// @Produce json
+// @Param id path string true "Student ID"
+// @Success 200 {object} domain.CreditBalance
+// @Failure 400 {object} ErrorResponse
+// @Router /api/v1/students/{id}/credits [get]
+// @Security BearerAuth
+func (h *CreditHandler) GetStudentCredits(c *gin.Context) {
+	studentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "Invalid student ID",
+		})
+		return
+	}
+
+	h.respondBalance(c, studentID)
+}
+
+// GetMyCredits godoc
+// @Summary Get my credits
+// @Description Get the class credit balance and packages of the authenticated student
+// @Tags students
+// @Produce json
+// @Success 200 {object} domain.CreditBalance
+// @Failure 401 {object} ErrorResponse
+// @Router /api/v1/students/me/credits [get]
+// @Security BearerAuth
+func (h *CreditHandler) GetMyCredits(c *gin.Context) {
+	authID, err := middleware.GetAuthID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error: "Unauthorized",
+		})
+		return
+	}
+
+	h.respondBalance(c, authID)
+}
+
+// GetStudentLedger godoc
+// @Summary Get student credit ledger
+// @Description Get the class credit ledger of a student, newest first (Admin only)
+// @Tags students
+// @Produce json
+// @Param id path string true "Student ID"
+// @Success 200 {array} domain.CreditEntry
+// @Failure 400 {object} ErrorResponse
+// @Router /api/v1/students/{id}/credits/ledger [get]
+// @Security BearerAuth
+func (h *CreditHandler) GetStudentLedger(c *gin.Context) {
+	studentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "Invalid student ID",
+		})
+		return
+	}
+
+	h.respondLedger(c, studentID)
+}
+
+// GetMyLedger godoc
+// @Summary Get my credit ledger
+// @Description Get the class credit ledger of the authenticated student, newest first
+// @Tags students
+// @Produce json
+// @Success 200 {array} domain.CreditEntry
+// @Failure 401 {object} ErrorResponse
+// @Router /api/v1/students/me/credits/ledger [get]
+// @Security BearerAuth
+func (h *CreditHandler) GetMyLedger(c *gin.Context) {
+	authID, err := middleware.GetAuthID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error: "Unauthorized",
+		})
+		return
+	}
+
+	h.respondLedger(c, authID)
+}
+
+func (h *CreditHandler) respondBalance(c *gin.Context, studentID uuid.UUID) {
+	balance, err := h.creditService.GetBalance(studentID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error: "Failed to get credit balance",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, balance)
+}
+
+func (h *CreditHandler) respondLedger(c *gin.Context, studentID uuid.UUID) {
+	entries, err := h.creditService.GetLedger(studentID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error: "Failed to get credit ledger",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, entries)
+}
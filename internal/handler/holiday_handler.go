@@ -0,0 +1,345 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/hscHeric/go-potential-api/internal/domain"
+	"github.com/hscHeric/go-potential-api/internal/middleware"
+	"github.com/hscHeric/go-potential-api/internal/repository"
+	"github.com/hscHeric/go-potential-api/internal/service"
+)
+
+type HolidayHandler struct {
+	holidayService service.HolidayService
+}
+
+func NewHolidayHandler(holidayService service.HolidayService) *HolidayHandler {
+	return &HolidayHandler{
+		holidayService: holidayService,
+	}
+}
+
+// ListHolidays godoc
+// @Summary List holidays
+// @Description List school holidays in a date range (default: current year)
+// @Tags holidays
+// @Produce json
+// @Param from query string false "Start date (YYYY-MM-DD)"
+// @Param to query string false "End date (YYYY-MM-DD)"
+// @Success 200 {array} domain.Holiday
+// @Failure 400 {object} ErrorResponse
+// @Router /api/v1/holidays [get]
+// @Security BearerAuth
+func (h *HolidayHandler) ListHolidays(c *gin.Context) {
+	year := time.Now().Year()
+	from := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(year, time.December, 31, 0, 0, 0, 0, time.UTC)
+
+	var err error
+	if fromStr := c.Query("from"); fromStr != "" {
+		from, err = time.Parse("2006-01-02", fromStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error: "Invalid from date (use YYYY-MM-DD)",
+			})
+			return
+		}
+	}
+
+	if toStr := c.Query("to"); toStr != "" {
+		to, err = time.Parse("2006-01-02", toStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error: "Invalid to date (use YYYY-MM-DD)",
+			})
+			return
+		}
+	}
+
+	holidays, err := h.holidayService.GetHolidays(from, to)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidDateRange) {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error: err.Error(),
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error: "Failed to get holidays",
+		})
+		return
+	}
+
+	if holidays == nil {
+		holidays = []domain.Holiday{}
+	}
+
+	c.JSON(http.StatusOK, holidays)
+}
+
+// CreateHoliday godoc
+// @Summary Create holiday
+// @Description Add a holiday to the school calendar (Admin only). The response lists classes already scheduled on the date.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param request body service.HolidayInput true "Holiday data"
+// @Success 201 {object} domain.HolidayReport
+// @Failure 400 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /api/v1/admin/holidays [post]
+// @Security BearerAuth
+func (h *HolidayHandler) CreateHoliday(c *gin.Context) {
+	authID, err := middleware.GetAuthID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error: "Unauthorized",
+		})
+		return
+	}
+
+	var req service.HolidayInput
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "Invalid request body",
+		})
+		return
+	}
+
+	report, err := h.holidayService.CreateHoliday(authID, &req)
+	if err != nil {
+		if errors.Is(err, repository.ErrAlreadyExists) {
+			c.JSON(http.StatusConflict, ErrorResponse{
+				Error: "Holiday already exists",
+			})
+			return
+		}
+
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, report)
+}
+
+// GetHoliday godoc
+// @Summary Get holiday
+// @Description Get a holiday with the classes still scheduled on its date (Admin only)
+// @Tags admin
+// @Produce json
+// @Param id path string true "Holiday ID"
+// @Success 200 {object} domain.HolidayReport
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v1/admin/holidays/{id} [get]
+// @Security BearerAuth
+func (h *HolidayHandler) GetHoliday(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "Invalid holiday ID",
+		})
+		return
+	}
+
+	report, err := h.holidayService.GetHoliday(id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			c.JSON(http.StatusNotFound, ErrorResponse{
+				Error: "Holiday not found",
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error: "Failed to get holiday",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// UpdateHoliday godoc
+// @Summary Update holiday
+// @Description Update a holiday (Admin only)
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path string true "Holiday ID"
+// @Param request body service.HolidayInput true "Holiday data"
+// @Success 200 {object} domain.Holiday
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /api/v1/admin/holidays/{id} [put]
+// @Security BearerAuth
+func (h *HolidayHandler) UpdateHoliday(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "Invalid holiday ID",
+		})
+		return
+	}
+
+	var req service.HolidayInput
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "Invalid request body",
+		})
+		return
+	}
+
+	holiday, err := h.holidayService.UpdateHoliday(id, &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrNotFound):
+			c.JSON(http.StatusNotFound, ErrorResponse{
+				Error: "Holiday not found",
+			})
+		case errors.Is(err, repository.ErrAlreadyExists):
+			c.JSON(http.StatusConflict, ErrorResponse{
+				Error: "Holiday already exists",
+			})
+		default:
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error: err.Error(),
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, holiday)
+}
+
+// DeleteHoliday godoc
+// @Summary Delete holiday
+// @Description Remove a holiday from the school calendar (Admin only)
+// @Tags admin
+// @Produce json
+// @Param id path string true "Holiday ID"
+// @Success 200 {object} MessageResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v1/admin/holidays/{id} [delete]
+// @Security BearerAuth
+func (h *HolidayHandler) DeleteHoliday(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "Invalid holiday ID",
+		})
+		return
+	}
+
+	if err := h.holidayService.DeleteHoliday(id); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			c.JSON(http.StatusNotFound, ErrorResponse{
+				Error: "Holiday not found",
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error: "Failed to delete holiday",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, MessageResponse{
+		Message: "Holiday deleted successfully",
+	})
+}
+
+// GenerateNationalHolidays godoc
+// @Summary Generate national holidays
+// @Description Add the Brazilian national holidays of a year, including Carnaval, Good Friday and Corpus Christi (Admin only). Existing holidays are kept.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param request body service.GenerateHolidaysInput true "Year"
+// @Success 201 {array} domain.Holiday
+// @Failure 400 {object} ErrorResponse
+// @Router /api/v1/admin/holidays/generate [post]
+// @Security BearerAuth
+func (h *HolidayHandler) GenerateNationalHolidays(c *gin.Context) {
+	var req service.GenerateHolidaysInput
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "Invalid request body",
+		})
+		return
+	}
+
+	holidays, err := h.holidayService.GenerateNational(req.Year)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error: "Failed to generate holidays",
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, holidays)
+}
+
+// ResolveHolidayClasses godoc
+// @Summary Cancel or reschedule holiday classes
+// @Description Cancel or reschedule in bulk every class scheduled on the holiday (Admin only). Rescheduled classes with students wait for their confirmation.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path string true "Holiday ID"
+// @Param request body service.ResolveHolidayClassesInput true "Action"
+// @Success 200 {array} domain.HolidayClassResult
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v1/admin/holidays/{id}/classes [post]
+// @Security BearerAuth
+func (h *HolidayHandler) ResolveHolidayClasses(c *gin.Context) {
+	authID, err := middleware.GetAuthID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error: "Unauthorized",
+		})
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "Invalid holiday ID",
+		})
+		return
+	}
+
+	var req service.ResolveHolidayClassesInput
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "Invalid request body",
+		})
+		return
+	}
+
+	results, err := h.holidayService.ResolveClasses(id, authID, &req)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			c.JSON(http.StatusNotFound, ErrorResponse{
+				Error: "Holiday not found",
+			})
+			return
+		}
+
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, results)
+}
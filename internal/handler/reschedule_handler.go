@@ -0,0 +1,206 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/hscHeric/go-potential-api/internal/domain"
+	"github.com/hscHeric/go-potential-api/internal/middleware"
+	"github.com/hscHeric/go-potential-api/internal/repository"
+	"github.com/hscHeric/go-potential-api/internal/service"
+)
+
+type RescheduleHandler struct {
+	rescheduleService service.ClassRescheduleService
+	timezones         service.TimezoneService
+}
+
+func NewRescheduleHandler(rescheduleService service.ClassRescheduleService, timezones service.TimezoneService) *RescheduleHandler {
+	return &RescheduleHandler{
+		rescheduleService: rescheduleService,
+		timezones:         timezones,
+	}
+}
+
+type RespondRescheduleRequest struct {
+	Accepted *bool `json:"accepted" binding:"required"`
+}
+
+// ProposeReschedule godoc
+// @Summary Propose class reschedule
+// @Description Propose a new date and time for a class (Teacher or Admin). Classes without students are moved immediately; otherwise every enrolled student must confirm.
+// @Tags classes
+// @Accept json
+// @Produce json
+// @Param id path string true "Class ID"
+// @Param request body service.RescheduleClassInput true "New date and time"
+// @Param tz query string false "IANA time zone for conflicting classes (default: user time zone)"
+// @Success 201 {object} domain.ClassReschedule
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} StudentConflictResponse
+// @Router /api/v1/classes/{id}/reschedule [post]
+// @Security BearerAuth
+func (h *RescheduleHandler) ProposeReschedule(c *gin.Context) {
+	authID, err := middleware.GetAuthID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error: "Unauthorized",
+		})
+		return
+	}
+
+	classID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "Invalid class ID",
+		})
+		return
+	}
+
+	loc, ok := viewerLocation(c, h.timezones)
+	if !ok {
+		return
+	}
+
+	var input service.RescheduleClassInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "Invalid request body",
+		})
+		return
+	}
+
+	reschedule, err := h.rescheduleService.ProposeReschedule(classID, authID, &input)
+	if err != nil {
+		h.handleRescheduleError(c, err, loc)
+		return
+	}
+
+	c.JSON(http.StatusCreated, reschedule)
+}
+
+// RespondReschedule godoc
+// @Summary Respond to class reschedule
+// @Description Accept or decline the pending reschedule proposal of a class (Student only)
+// @Tags classes
+// @Accept json
+// @Produce json
+// @Param id path string true "Class ID"
+// @Param request body RespondRescheduleRequest true "Response"
+// @Param tz query string false "IANA time zone for conflicting classes (default: user time zone)"
+// @Success 200 {object} domain.ClassReschedule
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} StudentConflictResponse
+// @Router /api/v1/classes/{id}/reschedule/respond [post]
+// @Security BearerAuth
+func (h *RescheduleHandler) RespondReschedule(c *gin.Context) {
+	authID, err := middleware.GetAuthID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error: "Unauthorized",
+		})
+		return
+	}
+
+	classID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "Invalid class ID",
+		})
+		return
+	}
+
+	loc, ok := viewerLocation(c, h.timezones)
+	if !ok {
+		return
+	}
+
+	var req RespondRescheduleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "Invalid request body",
+		})
+		return
+	}
+
+	reschedule, err := h.rescheduleService.RespondReschedule(classID, authID, *req.Accepted)
+	if err != nil {
+		h.handleRescheduleError(c, err, loc)
+		return
+	}
+
+	c.JSON(http.StatusOK, reschedule)
+}
+
+// GetRescheduleHistory godoc
+// @Summary Get class reschedule history
+// @Description Get every reschedule proposal of a class with student responses, newest first
+// @Tags classes
+// @Produce json
+// @Param id path string true "Class ID"
+// @Success 200 {array} domain.ClassReschedule
+// @Failure 400 {object} ErrorResponse
+// @Router /api/v1/classes/{id}/reschedules [get]
+// @Security BearerAuth
+func (h *RescheduleHandler) GetRescheduleHistory(c *gin.Context) {
+	classID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "Invalid class ID",
+		})
+		return
+	}
+
+	reschedules, err := h.rescheduleService.GetRescheduleHistory(classID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error: "Failed to get reschedule history",
+		})
+		return
+	}
+
+	if reschedules == nil {
+		reschedules = []domain.ClassReschedule{}
+	}
+
+	c.JSON(http.StatusOK, reschedules)
+}
+
+func (h *RescheduleHandler) handleRescheduleError(c *gin.Context, err error, loc *time.Location) {
+	if respondStudentConflict(c, err, loc) {
+		return
+	}
+
+	var conflict *service.ScheduleConflictError
+	if errors.As(err, &conflict) {
+		details := map[string]string{}
+		if conflict.TeacherUnavailable {
+			details["teacher"] = "not available at this time"
+		}
+		if conflict.RoomUnavailable {
+			details["room"] = "not available at this time"
+		}
+
+		c.JSON(http.StatusConflict, ErrorResponse{
+			Error:   "Schedule conflict",
+			Details: details,
+		})
+		return
+	}
+
+	if errors.Is(err, repository.ErrNotFound) {
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error: "Class or pending reschedule not found",
+		})
+		return
+	}
+
+	c.JSON(http.StatusBadRequest, ErrorResponse{
+		Error: err.Error(),
+	})
+}
@@ -0,0 +1,569 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/hscHeric/go-potential-api/internal/domain"
+	"github.com/hscHeric/go-potential-api/internal/middleware"
+	"github.com/hscHeric/go-potential-api/internal/repository"
+	"github.com/hscHeric/go-potential-api/internal/service"
+)
+
+type RoomHandler struct {
+	roomService service.RoomService
+	timezones   service.TimezoneService
+}
+
+func NewRoomHandler(roomService service.RoomService, timezones service.TimezoneService) *RoomHandler {
+	return &RoomHandler{
+		roomService: roomService,
+		timezones:   timezones,
+	}
+}
+
+// ListBranches godoc
+// @Summary List branches
+// @Description List the school branches where in-person classes happen
+// @Tags rooms
+// @Produce json
+// @Param include_inactive query bool false "Include inactive branches"
+// @Success 200 {array} domain.Branch
+// @Router /api/v1/branches [get]
+// @Security BearerAuth
+func (h *RoomHandler) ListBranches(c *gin.Context) {
+	branches, err := h.roomService.GetBranches(c.Query("include_inactive") == "true")
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error: "Failed to get branches",
+		})
+		return
+	}
+
+	if branches == nil {
+		branches = []domain.Branch{}
+	}
+
+	c.JSON(http.StatusOK, branches)
+}
+
+// CreateBranch godoc
+// @Summary Create branch
+// @Description Add a school branch (Admin only)
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param request body service.BranchInput true "Branch data"
+// @Success 201 {object} domain.Branch
+// @Failure 400 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /api/v1/admin/branches [post]
+// @Security BearerAuth
+func (h *RoomHandler) CreateBranch(c *gin.Context) {
+	var req service.BranchInput
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "Invalid request body",
+		})
+		return
+	}
+
+	branch, err := h.roomService.CreateBranch(&req)
+	if err != nil {
+		h.handleRoomError(c, err, "Branch")
+		return
+	}
+
+	c.JSON(http.StatusCreated, branch)
+}
+
+// UpdateBranch godoc
+// @Summary Update branch
+// @Description Update a school branch (Admin only). Inactive branches are left out of room suggestions.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path string true "Branch ID"
+// @Param request body service.BranchInput true "Branch data"
+// @Success 200 {object} domain.Branch
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /api/v1/admin/branches/{id} [put]
+// @Security BearerAuth
+func (h *RoomHandler) UpdateBranch(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "Invalid branch ID",
+		})
+		return
+	}
+
+	var req service.BranchInput
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "Invalid request body",
+		})
+		return
+	}
+
+	branch, err := h.roomService.UpdateBranch(id, &req)
+	if err != nil {
+		h.handleRoomError(c, err, "Branch")
+		return
+	}
+
+	c.JSON(http.StatusOK, branch)
+}
+
+// DeleteBranch godoc
+// @Summary Delete branch
+// @Description Remove a branch and its rooms (Admin only). Classes that reserved those rooms are left without a room.
+// @Tags admin
+// @Produce json
+// @Param id path string true "Branch ID"
+// @Success 200 {object} MessageResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v1/admin/branches/{id} [delete]
+// @Security BearerAuth
+func (h *RoomHandler) DeleteBranch(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "Invalid branch ID",
+		})
+		return
+	}
+
+	if err := h.roomService.DeleteBranch(id); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			c.JSON(http.StatusNotFound, ErrorResponse{
+				Error: "Branch not found",
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error: "Failed to delete branch",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, MessageResponse{
+		Message: "Branch deleted successfully",
+	})
+}
+
+// ListRooms godoc
+// @Summary List branch rooms
+// @Description List the rooms of a branch with capacity and equipment
+// @Tags rooms
+// @Produce json
+// @Param id path string true "Branch ID"
+// @Param include_inactive query bool false "Include inactive rooms"
+// @Success 200 {array} domain.Room
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v1/branches/{id}/rooms [get]
+// @Security BearerAuth
+func (h *RoomHandler) ListRooms(c *gin.Context) {
+	branchID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "Invalid branch ID",
+		})
+		return
+	}
+
+	rooms, err := h.roomService.GetRooms(branchID, c.Query("include_inactive") == "true")
+	if err != nil {
+		h.handleRoomError(c, err, "Branch")
+		return
+	}
+
+	if rooms == nil {
+		rooms = []domain.Room{}
+	}
+
+	c.JSON(http.StatusOK, rooms)
+}
+
+// CreateRoom godoc
+// @Summary Create room
+// @Description Add a room to a branch (Admin only)
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path string true "Branch ID"
+// @Param request body service.RoomInput true "Room data"
+// @Success 201 {object} domain.Room
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /api/v1/admin/branches/{id}/rooms [post]
+// @Security BearerAuth
+func (h *RoomHandler) CreateRoom(c *gin.Context) {
+	branchID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "Invalid branch ID",
+		})
+		return
+	}
+
+	var req service.RoomInput
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "Invalid request body",
+		})
+		return
+	}
+
+	room, err := h.roomService.CreateRoom(branchID, &req)
+	if err != nil {
+		h.handleRoomError(c, err, "Branch")
+		return
+	}
+
+	c.JSON(http.StatusCreated, room)
+}
+
+// GetRoom godoc
+// @Summary Get room
+// @Description Get a room with its capacity and equipment
+// @Tags rooms
+// @Produce json
+// @Param id path string true "Room ID"
+// @Success 200 {object} domain.Room
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v1/rooms/{id} [get]
+// @Security BearerAuth
+func (h *RoomHandler) GetRoom(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "Invalid room ID",
+		})
+		return
+	}
+
+	room, err := h.roomService.GetRoom(id)
+	if err != nil {
+		h.handleRoomError(c, err, "Room")
+		return
+	}
+
+	c.JSON(http.StatusOK, room)
+}
+
+// UpdateRoom godoc
+// @Summary Update room
+// @Description Update a room's name, capacity, equipment or status (Admin only). Existing reservations are kept.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path string true "Room ID"
+// @Param request body service.RoomInput true "Room data"
+// @Success 200 {object} domain.Room
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /api/v1/admin/rooms/{id} [put]
+// @Security BearerAuth
+func (h *RoomHandler) UpdateRoom(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "Invalid room ID",
+		})
+		return
+	}
+
+	var req service.RoomInput
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "Invalid request body",
+		})
+		return
+	}
+
+	room, err := h.roomService.UpdateRoom(id, &req)
+	if err != nil {
+		h.handleRoomError(c, err, "Room")
+		return
+	}
+
+	c.JSON(http.StatusOK, room)
+}
+
+// DeleteRoom godoc
+// @Summary Delete room
+// @Description Remove a room (Admin only). Classes that reserved it are left without a room.
+// @Tags admin
+// @Produce json
+// @Param id path string true "Room ID"
+// @Success 200 {object} MessageResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v1/admin/rooms/{id} [delete]
+// @Security BearerAuth
+func (h *RoomHandler) DeleteRoom(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "Invalid room ID",
+		})
+		return
+	}
+
+	if err := h.roomService.DeleteRoom(id); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			c.JSON(http.StatusNotFound, ErrorResponse{
+				Error: "Room not found",
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error: "Failed to delete room",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, MessageResponse{
+		Message: "Room deleted successfully",
+	})
+}
+
+// SuggestRooms godoc
+// @Summary Suggest free rooms
+// @Description List active rooms that are free at the given time and meet the capacity and equipment requirements, smallest first (Teacher or Admin)
+// @Tags rooms
+// @Produce json
+// @Param date query string true "Date (YYYY-MM-DD)"
+// @Param start_time query string true "Start time (HH:MM)"
+// @Param end_time query string true "End time (HH:MM)"
+// @Param tz query string false "IANA time zone of date/times (default: school time zone)"
+// @Param branch_id query string false "Branch ID"
+// @Param min_capacity query int false "Minimum capacity"
+// @Param equipment query string false "Required equipment, comma separated (e.g. projector,whiteboard)"
+// @Success 200 {array} domain.Room
+// @Failure 400 {object} ErrorResponse
+// @Router /api/v1/rooms/available [get]
+// @Security BearerAuth
+func (h *RoomHandler) SuggestRooms(c *gin.Context) {
+	date, err := time.Parse("2006-01-02", c.Query("date"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "Invalid date (use YYYY-MM-DD)",
+		})
+		return
+	}
+
+	input := service.SuggestRoomsInput{
+		Date:      date,
+		StartTime: c.Query("start_time"),
+		EndTime:   c.Query("end_time"),
+	}
+
+	if input.StartTime == "" || input.EndTime == "" {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "start_time and end_time are required",
+		})
+		return
+	}
+
+	if tz := c.Query("tz"); tz != "" {
+		input.Timezone = &tz
+	}
+
+	if branchStr := c.Query("branch_id"); branchStr != "" {
+		branchID, err := uuid.Parse(branchStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error: "Invalid branch ID",
+			})
+			return
+		}
+		input.BranchID = &branchID
+	}
+
+	if capacityStr := c.Query("min_capacity"); capacityStr != "" {
+		input.MinCapacity, err = strconv.Atoi(capacityStr)
+		if err != nil || input.MinCapacity < 0 {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error: "Invalid min_capacity",
+			})
+			return
+		}
+	}
+
+	if equipment := c.Query("equipment"); equipment != "" {
+		input.Equipment = strings.Split(equipment, ",")
+	}
+
+	rooms, err := h.roomService.SuggestRooms(&input)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, rooms)
+}
+
+// GetBranchOccupancy godoc
+// @Summary Room occupancy
+// @Description List, for each room of a branch, the classes booked in the period and the total booked minutes (Teacher or Admin). Range is limited to 31 days.
+// @Tags rooms
+// @Produce json
+// @Param id path string true "Branch ID"
+// @Param from query string false "Start date (YYYY-MM-DD, default: today)"
+// @Param to query string false "End date (YYYY-MM-DD, default: from + 6 days)"
+// @Param tz query string false "IANA time zone for starts_at/ends_at (default: user time zone)"
+// @Success 200 {array} domain.RoomOccupancy
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v1/branches/{id}/occupancy [get]
+// @Security BearerAuth
+func (h *RoomHandler) GetBranchOccupancy(c *gin.Context) {
+	branchID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "Invalid branch ID",
+		})
+		return
+	}
+
+	from := h.timezones.Today()
+	if fromStr := c.Query("from"); fromStr != "" {
+		from, err = time.Parse("2006-01-02", fromStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error: "Invalid from date (use YYYY-MM-DD)",
+			})
+			return
+		}
+	}
+
+	to := from.AddDate(0, 0, 6)
+	if toStr := c.Query("to"); toStr != "" {
+		to, err = time.Parse("2006-01-02", toStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error: "Invalid to date (use YYYY-MM-DD)",
+			})
+			return
+		}
+	}
+
+	loc, ok := viewerLocation(c, h.timezones)
+	if !ok {
+		return
+	}
+
+	occupancy, err := h.roomService.GetOccupancy(branchID, from, to)
+	if err != nil {
+		h.handleRoomError(c, err, "Branch")
+		return
+	}
+
+	for i := range occupancy {
+		for j := range occupancy[i].Classes {
+			occupancy[i].Classes[j].Localize(loc)
+		}
+	}
+
+	c.JSON(http.StatusOK, occupancy)
+}
+
+// AssignClassRoom godoc
+// @Summary Reserve class room
+// @Description Reserve, change or release the room of a scheduled class (Teacher of the class or Admin). With only branch_id the smallest free room that fits the students is picked; with neither the room is released.
+// @Tags classes
+// @Accept json
+// @Produce json
+// @Param id path string true "Class ID"
+// @Param request body service.AssignRoomInput true "Room or branch"
+// @Param tz query string false "IANA time zone for starts_at/ends_at (default: user time zone)"
+// @Success 200 {object} domain.Class
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /api/v1/classes/{id}/room [put]
+// @Security BearerAuth
+func (h *RoomHandler) AssignClassRoom(c *gin.Context) {
+	authID, err := middleware.GetAuthID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error: "Unauthorized",
+		})
+		return
+	}
+
+	classID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "Invalid class ID",
+		})
+		return
+	}
+
+	loc, ok := viewerLocation(c, h.timezones)
+	if !ok {
+		return
+	}
+
+	var req service.AssignRoomInput
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "Invalid request body",
+		})
+		return
+	}
+
+	class, err := h.roomService.AssignClassRoom(classID, authID, &req)
+	if err != nil {
+		if errors.Is(err, service.ErrNotClassTeacher) {
+			c.JSON(http.StatusForbidden, ErrorResponse{
+				Error: err.Error(),
+			})
+			return
+		}
+
+		h.handleRoomError(c, err, "Class or room")
+		return
+	}
+
+	class.Localize(loc)
+	c.JSON(http.StatusOK, class)
+}
+
+// handleRoomError traduz os erros de unidades e salas; resource nomeia o registro
+// procurado na mensagem de "não encontrado"
+func (h *RoomHandler) handleRoomError(c *gin.Context, err error, resource string) {
+	switch {
+	case errors.Is(err, repository.ErrNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error: resource + " not found",
+		})
+	case errors.Is(err, repository.ErrAlreadyExists):
+		c.JSON(http.StatusConflict, ErrorResponse{
+			Error: "Name already in use",
+		})
+	case errors.Is(err, service.ErrRoomUnavailable), errors.Is(err, service.ErrNoRoomAvailable):
+		c.JSON(http.StatusConflict, ErrorResponse{
+			Error: err.Error(),
+		})
+	default:
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: err.Error(),
+		})
+	}
+}
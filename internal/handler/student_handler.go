@@ -0,0 +1,131 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/hscHeric/go-potential-api/internal/domain"
+	"github.com/hscHeric/go-potential-api/internal/middleware"
+	"github.com/hscHeric/go-potential-api/internal/service"
+)
+
+type StudentHandler struct {
+	studentLevelService service.StudentLevelService
+}
+
+func NewStudentHandler(studentLevelService service.StudentLevelService) *StudentHandler {
+	return &StudentHandler{
+		studentLevelService: studentLevelService,
+	}
+}
+
+// RecordLevel godoc
+// @Summary Record student level
+// @Description Record a CEFR level assessment for a student (Teacher or Admin)
+// @Tags students
+// @Accept json
+// @Produce json
+// @Param id path string true "Student ID"
+// @Param request body service.RecordStudentLevelInput true "Assessment data"
+// @Success 201 {object} domain.StudentLevel
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /api/v1/students/{id}/levels [post]
+// @Security BearerAuth
+func (h *StudentHandler) RecordLevel(c *gin.Context) {
+	authID, err := middleware.GetAuthID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error: "Unauthorized",
+		})
+		return
+	}
+
+	studentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "Invalid student ID",
+		})
+		return
+	}
+
+	var req service.RecordStudentLevelInput
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "Invalid request body",
+		})
+		return
+	}
+
+	level, err := h.studentLevelService.RecordLevel(studentID, authID, &req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, level)
+}
+
+// GetLevelHistory godoc
+// @Summary Get student level history
+// @Description Get the CEFR level history of a student (Teacher or Admin)
+// @Tags students
+// @Produce json
+// @Param id path string true "Student ID"
+// @Param language query string false "Filter by language"
+// @Success 200 {array} domain.StudentLevel
+// @Failure 400 {object} ErrorResponse
+// @Router /api/v1/students/{id}/levels [get]
+// @Security BearerAuth
+func (h *StudentHandler) GetLevelHistory(c *gin.Context) {
+	studentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "Invalid student ID",
+		})
+		return
+	}
+
+	h.respondLevelHistory(c, studentID)
+}
+
+// GetMyLevelHistory godoc
+// @Summary Get my level history
+// @Description Get the CEFR level history of the authenticated student
+// @Tags students
+// @Produce json
+// @Param language query string false "Filter by language"
+// @Success 200 {array} domain.StudentLevel
+// @Failure 401 {object} ErrorResponse
+// @Router /api/v1/students/me/levels [get]
+// @Security BearerAuth
+func (h *StudentHandler) GetMyLevelHistory(c *gin.Context) {
+	authID, err := middleware.GetAuthID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error: "Unauthorized",
+		})
+		return
+	}
+
+	h.respondLevelHistory(c, authID)
+}
+
+func (h *StudentHandler) respondLevelHistory(c *gin.Context, studentID uuid.UUID) {
+	levels, err := h.studentLevelService.GetLevelHistory(studentID, c.Query("language"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error: "Failed to get level history",
+		})
+		return
+	}
+
+	if levels == nil {
+		levels = []domain.StudentLevel{}
+	}
+
+	c.JSON(http.StatusOK, levels)
+}
@@ -0,0 +1,148 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/hscHeric/go-potential-api/internal/middleware"
+	"github.com/hscHeric/go-potential-api/internal/repository"
+	"github.com/hscHeric/go-potential-api/internal/service"
+	"github.com/hscHeric/go-potential-api/pkg/validator"
+)
+
+type SubstitutionHandler struct {
+	substitutionService service.ClassSubstitutionService
+	timezones           service.TimezoneService
+}
+
+func NewSubstitutionHandler(substitutionService service.ClassSubstitutionService, timezones service.TimezoneService) *SubstitutionHandler {
+	return &SubstitutionHandler{
+		substitutionService: substitutionService,
+		timezones:           timezones,
+	}
+}
+
+// SuggestSubstitutes godoc
+// @Summary Suggest substitute teachers
+// @Description List active teachers who teach the class language and are free at the class time (Teacher of the class or Admin)
+// @Tags classes
+// @Produce json
+// @Param id path string true "Class ID"
+// @Success 200 {array} domain.SubstituteCandidate
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v1/classes/{id}/substitute [get]
+// @Security BearerAuth
+func (h *SubstitutionHandler) SuggestSubstitutes(c *gin.Context) {
+	authID, err := middleware.GetAuthID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error: "Unauthorized",
+		})
+		return
+	}
+
+	classID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "Invalid class ID",
+		})
+		return
+	}
+
+	candidates, err := h.substitutionService.SuggestSubstitutes(classID, authID)
+	if err != nil {
+		h.handleSubstitutionError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, candidates)
+}
+
+// AssignSubstitute godoc
+// @Summary Assign substitute teacher
+// @Description Hand the class over to a substitute teacher (Teacher of the class or Admin). The original teacher is kept in original_teacher_id; students and both teachers are notified.
+// @Tags classes
+// @Accept json
+// @Produce json
+// @Param id path string true "Class ID"
+// @Param request body service.AssignSubstituteInput true "Substitute teacher"
+// @Param tz query string false "IANA time zone for starts_at/ends_at (default: user time zone)"
+// @Success 200 {object} domain.Class
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /api/v1/classes/{id}/substitute [post]
+// @Security BearerAuth
+func (h *SubstitutionHandler) AssignSubstitute(c *gin.Context) {
+	authID, err := middleware.GetAuthID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error: "Unauthorized",
+		})
+		return
+	}
+
+	classID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "Invalid class ID",
+		})
+		return
+	}
+
+	loc, ok := viewerLocation(c, h.timezones)
+	if !ok {
+		return
+	}
+
+	var req service.AssignSubstituteInput
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "Invalid request body",
+		})
+		return
+	}
+
+	if err := validator.Validate(req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Validation failed",
+			Details: validator.FormatValidationErrors(err),
+		})
+		return
+	}
+
+	class, err := h.substitutionService.AssignSubstitute(classID, authID, &req)
+	if err != nil {
+		h.handleSubstitutionError(c, err)
+		return
+	}
+
+	class.Localize(loc)
+	c.JSON(http.StatusOK, class)
+}
+
+func (h *SubstitutionHandler) handleSubstitutionError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, repository.ErrNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error: "Class or teacher not found",
+		})
+	case errors.Is(err, service.ErrNotClassTeacher):
+		c.JSON(http.StatusForbidden, ErrorResponse{
+			Error: err.Error(),
+		})
+	case errors.Is(err, service.ErrSubstituteUnavailable), errors.Is(err, service.ErrSubstituteLanguage):
+		c.JSON(http.StatusConflict, ErrorResponse{
+			Error: err.Error(),
+		})
+	default:
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: err.Error(),
+		})
+	}
+}
@@ -0,0 +1,164 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/hscHeric/go-potential-api/internal/domain"
+	"github.com/hscHeric/go-potential-api/internal/middleware"
+	"github.com/hscHeric/go-potential-api/internal/repository"
+	"github.com/hscHeric/go-potential-api/internal/service"
+)
+
+type TeacherHandler struct {
+	teacherService service.TeacherService
+}
+
+func NewTeacherHandler(teacherService service.TeacherService) *TeacherHandler {
+	return &TeacherHandler{
+		teacherService: teacherService,
+	}
+}
+
+// UpdateMyProfile godoc
+// @Summary Update teacher profile
+// @Description Teacher creates or updates their professional profile
+// @Tags teachers
+// @Accept json
+// @Produce json
+// @Param request body service.UpdateTeacherProfileInput true "Teacher profile data"
+// @Success 200 {object} domain.TeacherProfile
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /api/v1/teachers/me/profile [put]
+// @Security BearerAuth
+func (h *TeacherHandler) UpdateMyProfile(c *gin.Context) {
+	authID, err := middleware.GetAuthID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error: "Unauthorized",
+		})
+		return
+	}
+
+	var req service.UpdateTeacherProfileInput
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "Invalid request body",
+		})
+		return
+	}
+
+	profile, err := h.teacherService.UpdateProfile(authID, &req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, profile)
+}
+
+// GetMyProfile godoc
+// @Summary Get my teacher profile
+// @Description Get the professional profile of the authenticated teacher
+// @Tags teachers
+// @Produce json
+// @Success 200 {object} domain.TeacherProfile
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v1/teachers/me/profile [get]
+// @Security BearerAuth
+func (h *TeacherHandler) GetMyProfile(c *gin.Context) {
+	authID, err := middleware.GetAuthID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error: "Unauthorized",
+		})
+		return
+	}
+
+	h.respondProfile(c, authID)
+}
+
+// GetTeacherProfile godoc
+// @Summary Get teacher profile
+// @Description Get the professional profile of a teacher
+// @Tags teachers
+// @Produce json
+// @Param id path string true "Teacher ID"
+// @Success 200 {object} domain.TeacherProfile
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v1/teachers/{id} [get]
+// @Security BearerAuth
+func (h *TeacherHandler) GetTeacherProfile(c *gin.Context) {
+	teacherID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "Invalid teacher ID",
+		})
+		return
+	}
+
+	h.respondProfile(c, teacherID)
+}
+
+// ListTeachers godoc
+// @Summary List teachers
+// @Description Teacher directory filtered by language and CEFR level
+// @Tags teachers
+// @Produce json
+// @Param language query string false "Language taught (ex: english)"
+// @Param level query string false "CEFR level (A1, A2, B1, B2, C1, C2)"
+// @Success 200 {array} domain.TeacherDirectoryEntry
+// @Failure 400 {object} ErrorResponse
+// @Router /api/v1/teachers [get]
+// @Security BearerAuth
+func (h *TeacherHandler) ListTeachers(c *gin.Context) {
+	filter := domain.TeacherDirectoryFilter{
+		Language: c.Query("language"),
+		Level:    domain.LanguageLevel(c.Query("level")),
+	}
+
+	if filter.Level != "" && !filter.Level.IsValid() {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "Invalid level (must be A1, A2, B1, B2, C1 or C2)",
+		})
+		return
+	}
+
+	teachers, err := h.teacherService.ListTeachers(filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error: "Failed to list teachers",
+		})
+		return
+	}
+
+	if teachers == nil {
+		teachers = []domain.TeacherDirectoryEntry{}
+	}
+
+	c.JSON(http.StatusOK, teachers)
+}
+
+func (h *TeacherHandler) respondProfile(c *gin.Context, teacherID uuid.UUID) {
+	profile, err := h.teacherService.GetProfile(teacherID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			c.JSON(http.StatusNotFound, ErrorResponse{
+				Error: "Teacher profile not found",
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error: "Failed to get teacher profile",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, profile)
+}
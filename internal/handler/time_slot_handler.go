@@ -1,30 +1,35 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/hscHeric/go-potential-api/internal/domain"
 	"github.com/hscHeric/go-potential-api/internal/middleware"
+	"github.com/hscHeric/go-potential-api/internal/repository"
 	"github.com/hscHeric/go-potential-api/internal/service"
 	"github.com/hscHeric/go-potential-api/pkg/validator"
 )
 
 type TimeSlotHandler struct {
 	timeSlotService service.TimeSlotService
+	timezones       service.TimezoneService
 }
 
-func NewTimeSlotHandler(timeSlotService service.TimeSlotService) *TimeSlotHandler {
+func NewTimeSlotHandler(timeSlotService service.TimeSlotService, timezones service.TimezoneService) *TimeSlotHandler {
 	return &TimeSlotHandler{
 		timeSlotService: timeSlotService,
+		timezones:       timezones,
 	}
 }
 
 // CreateTimeSlot godoc
 // @Summary Create time slot
-// @Description Teacher creates a recurring time slot
+// @Description Teacher creates a recurring time slot, valid from valid_from (default today) until valid_until (optional)
 // @Tags time-slots
 // @Accept json
 // @Produce json
@@ -32,6 +37,7 @@ func NewTimeSlotHandler(timeSlotService service.TimeSlotService) *TimeSlotHandle
 // @Success 201 {object} domain.TimeSlot
 // @Failure 400 {object} ErrorResponse
 // @Failure 401 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
 // @Router /api/v1/time-slots [post]
 // @Security BearerAuth
 func (h *TimeSlotHandler) CreateTimeSlot(c *gin.Context) {
@@ -61,6 +67,13 @@ func (h *TimeSlotHandler) CreateTimeSlot(c *gin.Context) {
 
 	timeSlot, err := h.timeSlotService.CreateTimeSlot(authID, &req)
 	if err != nil {
+		if errors.Is(err, service.ErrSlotOverlap) {
+			c.JSON(http.StatusConflict, ErrorResponse{
+				Error: err.Error(),
+			})
+			return
+		}
+
 		c.JSON(http.StatusBadRequest, ErrorResponse{
 			Error: err.Error(),
 		})
@@ -131,11 +144,12 @@ func (h *TimeSlotHandler) GetTeacherTimeSlots(c *gin.Context) {
 
 // GetAvailableSlots godoc
 // @Summary Get available slots
-// @Description Get available time slots for a teacher on a specific day
+// @Description Get available time slots for a teacher on a specific day. When date is given, the day of week comes from it and nothing is returned during the teacher's blackouts.
 // @Tags time-slots
 // @Produce json
 // @Param teacher_id path string true "Teacher ID"
-// @Param day_of_week query int true "Day of week (0=Sunday, 1=Monday, ..., 6=Saturday)"
+// @Param day_of_week query int false "Day of week (0=Sunday, 1=Monday, ..., 6=Saturday), required without date"
+// @Param date query string false "Specific date (YYYY-MM-DD)"
 // @Success 200 {array} domain.TimeSlot
 // @Failure 400 {object} ErrorResponse
 // @Router /api/v1/time-slots/teacher/{teacher_id}/available [get]
@@ -149,18 +163,33 @@ func (h *TimeSlotHandler) GetAvailableSlots(c *gin.Context) {
 		return
 	}
 
-	dayOfWeekStr := c.Query("day_of_week")
-	dayOfWeekInt, err := strconv.Atoi(dayOfWeekStr)
-	if err != nil || dayOfWeekInt < 0 || dayOfWeekInt > 6 {
-		c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error: "Invalid day_of_week (must be 0-6)",
-		})
-		return
+	var date *time.Time
+	if dateStr := c.Query("date"); dateStr != "" {
+		parsed, err := time.Parse("2006-01-02", dateStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error: "Invalid date (use YYYY-MM-DD)",
+			})
+			return
+		}
+		date = &parsed
 	}
 
-	dayOfWeek := domain.DayOfWeek(dayOfWeekInt)
+	var dayOfWeek domain.DayOfWeek
+	if date == nil {
+		dayOfWeekStr := c.Query("day_of_week")
+		dayOfWeekInt, err := strconv.Atoi(dayOfWeekStr)
+		if err != nil || dayOfWeekInt < 0 || dayOfWeekInt > 6 {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error: "Invalid day_of_week (must be 0-6)",
+			})
+			return
+		}
+
+		dayOfWeek = domain.DayOfWeek(dayOfWeekInt)
+	}
 
-	timeSlots, err := h.timeSlotService.GetAvailableSlots(teacherID, dayOfWeek)
+	timeSlots, err := h.timeSlotService.GetAvailableSlots(teacherID, dayOfWeek, date)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, ErrorResponse{
 			Error: "Failed to get available slots",
@@ -171,17 +200,78 @@ func (h *TimeSlotHandler) GetAvailableSlots(c *gin.Context) {
 	c.JSON(http.StatusOK, timeSlots)
 }
 
+// ReplaceMyTimeSlots godoc
+// @Summary Replace weekly time slots
+// @Description Teacher replaces the whole weekly availability grid from effective_from (default today) in one transaction. Slots with the same day and times are kept, changed slots get a new version and slots left out stop being valid. Slots that still have future classes report them in future_classes. Use dry_run to preview the diff.
+// @Tags time-slots
+// @Accept json
+// @Produce json
+// @Param request body service.ReplaceTimeSlotsInput true "Weekly grid"
+// @Success 200 {object} domain.TimeSlotDiff
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /api/v1/time-slots/me [put]
+// @Security BearerAuth
+func (h *TimeSlotHandler) ReplaceMyTimeSlots(c *gin.Context) {
+	authID, err := middleware.GetAuthID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error: "Unauthorized",
+		})
+		return
+	}
+
+	var req service.ReplaceTimeSlotsInput
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "Invalid request body",
+		})
+		return
+	}
+
+	if err := validator.Validate(req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Validation failed",
+			Details: validator.FormatValidationErrors(err),
+		})
+		return
+	}
+
+	diff, err := h.timeSlotService.ReplaceWeeklySlots(authID, &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrSlotOverlap):
+			c.JSON(http.StatusConflict, ErrorResponse{
+				Error: err.Error(),
+			})
+		case errors.Is(err, repository.ErrNotFound):
+			c.JSON(http.StatusConflict, ErrorResponse{
+				Error: "Time slots changed during the update, please try again",
+			})
+		default:
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error: err.Error(),
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, diff)
+}
+
 // UpdateTimeSlot godoc
 // @Summary Update time slot
-// @Description Update a time slot
+// @Description Update a time slot from effective_from (default today). Changing the day, times or capacity of a slot already in effect ends it the day before and creates a new version, so past classes keep the slot they were given in.
 // @Tags time-slots
 // @Accept json
 // @Produce json
 // @Param id path string true "Time Slot ID"
 // @Param request body service.UpdateTimeSlotInput true "Time slot data"
-// @Success 200 {object} MessageResponse
+// @Success 200 {object} domain.TimeSlot
 // @Failure 400 {object} ErrorResponse
 // @Failure 401 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
 // @Router /api/v1/time-slots/{id} [put]
 // @Security BearerAuth
 func (h *TimeSlotHandler) UpdateTimeSlot(c *gin.Context) {
@@ -209,21 +299,27 @@ func (h *TimeSlotHandler) UpdateTimeSlot(c *gin.Context) {
 		return
 	}
 
-	if err := h.timeSlotService.UpdateTimeSlot(id, &req); err != nil {
+	slot, err := h.timeSlotService.UpdateTimeSlot(id, &req)
+	if err != nil {
+		if errors.Is(err, service.ErrSlotOverlap) {
+			c.JSON(http.StatusConflict, ErrorResponse{
+				Error: err.Error(),
+			})
+			return
+		}
+
 		c.JSON(http.StatusBadRequest, ErrorResponse{
 			Error: err.Error(),
 		})
 		return
 	}
 
-	c.JSON(http.StatusOK, MessageResponse{
-		Message: "Time slot updated successfully",
-	})
+	c.JSON(http.StatusOK, slot)
 }
 
 // DeleteTimeSlot godoc
 // @Summary Delete time slot
-// @Description Delete a time slot
+// @Description Soft delete a time slot. Admins can restore it until it is purged.
 // @Tags time-slots
 // @Produce json
 // @Param id path string true "Time Slot ID"
@@ -293,3 +389,124 @@ func (h *TimeSlotHandler) ToggleTimeSlotAvailability(c *gin.Context) {
 		Message: "Availability updated successfully",
 	})
 }
+
+// RestoreTimeSlot godoc
+// @Summary Restore time slot
+// @Description Restore a soft-deleted time slot (Admin only)
+// @Tags admin
+// @Produce json
+// @Param id path string true "Time Slot ID"
+// @Success 200 {object} MessageResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /api/v1/admin/time-slots/{id}/restore [post]
+// @Security BearerAuth
+func (h *TimeSlotHandler) RestoreTimeSlot(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "Invalid time slot ID",
+		})
+		return
+	}
+
+	if err := h.timeSlotService.RestoreTimeSlot(id); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			c.JSON(http.StatusNotFound, ErrorResponse{
+				Error: "Deleted time slot not found",
+			})
+			return
+		}
+
+		if errors.Is(err, service.ErrSlotOverlap) {
+			c.JSON(http.StatusConflict, ErrorResponse{
+				Error: err.Error(),
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error: "Failed to restore time slot",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, MessageResponse{
+		Message: "Time slot restored successfully",
+	})
+}
+
+// GetTeacherOpenings godoc
+// @Summary Get teacher openings
+// @Description Expand the teacher's recurring time slots into dated occurrences that can still be booked, with the remaining capacity of each one
+// @Tags time-slots
+// @Produce json
+// @Param id path string true "Teacher ID"
+// @Param from query string false "Start date (YYYY-MM-DD, default today)"
+// @Param to query string false "End date (YYYY-MM-DD, default from + 13 days)"
+// @Param tz query string false "IANA time zone for starts_at/ends_at (default: user time zone)"
+// @Success 200 {array} domain.Opening
+// @Failure 400 {object} ErrorResponse
+// @Router /api/v1/teachers/{id}/openings [get]
+// @Security BearerAuth
+func (h *TimeSlotHandler) GetTeacherOpenings(c *gin.Context) {
+	teacherID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "Invalid teacher ID",
+		})
+		return
+	}
+
+	loc, ok := viewerLocation(c, h.timezones)
+	if !ok {
+		return
+	}
+
+	// Datas sem horário, como as colunas DATE (default: hoje)
+	now := time.Now()
+	from := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	if fromStr := c.Query("from"); fromStr != "" {
+		from, err = time.Parse("2006-01-02", fromStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error: "Invalid from date (use YYYY-MM-DD)",
+			})
+			return
+		}
+	}
+
+	to := from.AddDate(0, 0, 13)
+	if toStr := c.Query("to"); toStr != "" {
+		to, err = time.Parse("2006-01-02", toStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error: "Invalid to date (use YYYY-MM-DD)",
+			})
+			return
+		}
+	}
+
+	openings, err := h.timeSlotService.GetOpenings(teacherID, from, to)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidDateRange) {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error: err.Error(),
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error: "Failed to get openings",
+		})
+		return
+	}
+
+	for i := range openings {
+		openings[i].Localize(loc)
+	}
+
+	c.JSON(http.StatusOK, openings)
+}
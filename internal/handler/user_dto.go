@@ -8,4 +8,10 @@ type UpdateProfileRequest struct {
 	BirthDate string         `json:"birth_date" binding:"required"`
 	Address   domain.Address `json:"address" binding:"required"`
 	Contact   domain.Contact `json:"contact" binding:"required"`
+	Timezone  *string        `json:"timezone"` // Nome IANA; ausente mantém o atual
+}
+
+// UpdateClassRemindersRequest liga ou desliga os lembretes de aula
+type UpdateClassRemindersRequest struct {
+	Enabled *bool `json:"enabled" binding:"required"`
 }
@@ -1,11 +1,15 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"github.com/hscHeric/go-potential-api/internal/middleware"
+	"github.com/hscHeric/go-potential-api/internal/repository"
 	"github.com/hscHeric/go-potential-api/internal/service"
+	"github.com/hscHeric/go-potential-api/pkg/timezone"
 	"github.com/hscHeric/go-potential-api/pkg/validator"
 )
 
@@ -71,6 +75,14 @@ func (h *UserHandler) UpdateProfile(c *gin.Context) {
 
 	var req UpdateProfileRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
+		// Erros de validação (telefone, etc.) retornam os detalhes por campo
+		if details := validator.FormatValidationErrors(err); len(details) > 0 {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error:   "Falha na validação dos dados",
+				Details: details,
+			})
+			return
+		}
 		c.JSON(http.StatusBadRequest, ErrorResponse{
 			Error: "Formato de requisição inválido",
 		})
@@ -90,9 +102,17 @@ func (h *UserHandler) UpdateProfile(c *gin.Context) {
 		BirthDate: req.BirthDate,
 		Address:   req.Address,
 		Contact:   req.Contact,
+		Timezone:  req.Timezone,
 	}
 
 	if err := h.userService.UpdateProfile(authID, input); err != nil {
+		if errors.Is(err, service.ErrInvalidAddress) || errors.Is(err, service.ErrInvalidContact) ||
+			errors.Is(err, timezone.ErrInvalidZone) {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error: err.Error(),
+			})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, ErrorResponse{
 			Error: "Falha ao atualizar o perfil",
 		})
@@ -103,3 +123,127 @@ func (h *UserHandler) UpdateProfile(c *gin.Context) {
 		Message: "Perfil atualizado com sucesso",
 	})
 }
+
+// UpdateClassReminders godoc
+// @Summary Lembretes de aula
+// @Description Liga ou desliga os lembretes por e-mail enviados antes das aulas do usuário autenticado
+// @Tags usuários
+// @Accept json
+// @Produce json
+// @Param request body UpdateClassRemindersRequest true "Preferência"
+// @Success 200 {object} MessageResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /users/me/reminders [put]
+// @Security BearerAuth
+func (h *UserHandler) UpdateClassReminders(c *gin.Context) {
+	authID, err := middleware.GetAuthID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error: "Não autorizado",
+		})
+		return
+	}
+
+	var req UpdateClassRemindersRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "Formato de requisição inválido",
+		})
+		return
+	}
+
+	if err := h.userService.SetClassReminders(authID, *req.Enabled); err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error: "Falha ao atualizar os lembretes",
+		})
+		return
+	}
+
+	message := "Lembretes de aula desativados"
+	if *req.Enabled {
+		message = "Lembretes de aula ativados"
+	}
+
+	c.JSON(http.StatusOK, MessageResponse{
+		Message: message,
+	})
+}
+
+// DeleteUser godoc
+// @Summary Excluir usuário
+// @Description Faz o soft delete do usuário e desativa o login (apenas Admin). Pode ser restaurado até a purga.
+// @Tags admin
+// @Produce json
+// @Param id path string true "ID de autenticação do usuário"
+// @Success 200 {object} MessageResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /admin/users/{id} [delete]
+// @Security BearerAuth
+func (h *UserHandler) DeleteUser(c *gin.Context) {
+	authID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "ID de usuário inválido",
+		})
+		return
+	}
+
+	if err := h.userService.DeleteUser(authID); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			c.JSON(http.StatusNotFound, ErrorResponse{
+				Error: "Usuário não encontrado",
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error: "Falha ao excluir o usuário",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, MessageResponse{
+		Message: "Usuário excluído com sucesso",
+	})
+}
+
+// RestoreUser godoc
+// @Summary Restaurar usuário
+// @Description Restaura um usuário excluído e devolve o login ao status anterior à exclusão (apenas Admin)
+// @Tags admin
+// @Produce json
+// @Param id path string true "ID de autenticação do usuário"
+// @Success 200 {object} MessageResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /admin/users/{id}/restore [post]
+// @Security BearerAuth
+func (h *UserHandler) RestoreUser(c *gin.Context) {
+	authID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "ID de usuário inválido",
+		})
+		return
+	}
+
+	if err := h.userService.RestoreUser(authID); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			c.JSON(http.StatusNotFound, ErrorResponse{
+				Error: "Usuário excluído não encontrado",
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error: "Falha ao restaurar o usuário",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, MessageResponse{
+		Message: "Usuário restaurado com sucesso",
+	})
+}
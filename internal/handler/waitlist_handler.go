@@ -0,0 +1,222 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/hscHeric/go-potential-api/internal/domain"
+	"github.com/hscHeric/go-potential-api/internal/middleware"
+	"github.com/hscHeric/go-potential-api/internal/repository"
+	"github.com/hscHeric/go-potential-api/internal/service"
+)
+
+type WaitlistHandler struct {
+	waitlistService service.WaitlistService
+}
+
+func NewWaitlistHandler(waitlistService service.WaitlistService) *WaitlistHandler {
+	return &WaitlistHandler{
+		waitlistService: waitlistService,
+	}
+}
+
+type ConfirmWaitlistOfferRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+// JoinWaitlist godoc
+// @Summary Join class waitlist
+// @Description Join the waitlist of a full class (Student only)
+// @Tags waitlist
+// @Produce json
+// @Param id path string true "Class ID"
+// @Success 201 {object} domain.WaitlistEntry
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v1/classes/{id}/waitlist [post]
+// @Security BearerAuth
+func (h *WaitlistHandler) JoinWaitlist(c *gin.Context) {
+	authID, err := middleware.GetAuthID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error: "Unauthorized",
+		})
+		return
+	}
+
+	classID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "Invalid class ID",
+		})
+		return
+	}
+
+	entry, err := h.waitlistService.Join(classID, authID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			c.JSON(http.StatusNotFound, ErrorResponse{
+				Error: "Class not found",
+			})
+			return
+		}
+
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, entry)
+}
+
+// LeaveWaitlist godoc
+// @Summary Leave class waitlist
+// @Description Leave the waitlist of a class (Student only). A pending offer is passed to the next student.
+// @Tags waitlist
+// @Produce json
+// @Param id path string true "Class ID"
+// @Success 200 {object} MessageResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v1/classes/{id}/waitlist [delete]
+// @Security BearerAuth
+func (h *WaitlistHandler) LeaveWaitlist(c *gin.Context) {
+	authID, err := middleware.GetAuthID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error: "Unauthorized",
+		})
+		return
+	}
+
+	classID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "Invalid class ID",
+		})
+		return
+	}
+
+	if err := h.waitlistService.Leave(classID, authID); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			c.JSON(http.StatusNotFound, ErrorResponse{
+				Error: "You are not on this waitlist",
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error: "Failed to leave waitlist",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, MessageResponse{
+		Message: "Left waitlist successfully",
+	})
+}
+
+// GetWaitlist godoc
+// @Summary Get class waitlist
+// @Description Get the active waitlist of a class in queue order (Teacher or Admin)
+// @Tags waitlist
+// @Produce json
+// @Param id path string true "Class ID"
+// @Success 200 {array} domain.WaitlistEntry
+// @Failure 400 {object} ErrorResponse
+// @Router /api/v1/classes/{id}/waitlist [get]
+// @Security BearerAuth
+func (h *WaitlistHandler) GetWaitlist(c *gin.Context) {
+	classID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "Invalid class ID",
+		})
+		return
+	}
+
+	entries, err := h.waitlistService.GetWaitlist(classID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error: "Failed to get waitlist",
+		})
+		return
+	}
+
+	if entries == nil {
+		entries = []domain.WaitlistEntry{}
+	}
+
+	c.JSON(http.StatusOK, entries)
+}
+
+// ConfirmOffer godoc
+// @Summary Confirm waitlist offer
+// @Description Confirm the seat offered by email to the authenticated student
+// @Tags waitlist
+// @Accept json
+// @Produce json
+// @Param request body ConfirmWaitlistOfferRequest true "Offer token"
+// @Success 200 {object} domain.WaitlistEntry
+// @Failure 400 {object} ErrorResponse
+// @Failure 402 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 410 {object} ErrorResponse
+// @Router /api/v1/waitlist/confirm [post]
+// @Security BearerAuth
+func (h *WaitlistHandler) ConfirmOffer(c *gin.Context) {
+	authID, err := middleware.GetAuthID(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error: "Unauthorized",
+		})
+		return
+	}
+
+	var req ConfirmWaitlistOfferRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "Invalid request body",
+		})
+		return
+	}
+
+	entry, err := h.waitlistService.ConfirmOffer(req.Token, authID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			c.JSON(http.StatusNotFound, ErrorResponse{
+				Error: "Offer not found",
+			})
+			return
+		}
+		if errors.Is(err, repository.ErrOfferExpired) {
+			c.JSON(http.StatusGone, ErrorResponse{
+				Error: "Offer has expired",
+			})
+			return
+		}
+
+		if errors.Is(err, repository.ErrClassUnavailable) {
+			c.JSON(http.StatusGone, ErrorResponse{
+				Error: "Class is no longer available",
+			})
+			return
+		}
+		if errors.Is(err, repository.ErrNoCredits) {
+			c.JSON(http.StatusPaymentRequired, ErrorResponse{
+				Error: "No class credits available",
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error: "Failed to confirm offer",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, entry)
+}
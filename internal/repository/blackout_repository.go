@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hscHeric/go-potential-api/internal/domain"
+	"github.com/jmoiron/sqlx"
+)
+
+type BlackoutRepository interface {
+	Create(blackout *domain.TeacherBlackout) error
+	GetByID(id uuid.UUID) (*domain.TeacherBlackout, error)
+	GetByTeacher(teacherID uuid.UUID, from, to time.Time) ([]domain.TeacherBlackout, error)
+	Delete(id uuid.UUID) error
+}
+
+const blackoutColumns = `id, teacher_id, start_date, end_date, reason, created_by, created_at, updated_at`
+
+type blackoutRepository struct {
+	db *sqlx.DB
+}
+
+func NewBlackoutRepository(db *sqlx.DB) BlackoutRepository {
+	return &blackoutRepository{db: db}
+}
+
+func (r *blackoutRepository) Create(blackout *domain.TeacherBlackout) error {
+	query := `
+		INSERT INTO teacher_blackouts (id, teacher_id, start_date, end_date, reason, created_by, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+
+	blackout.ID = uuid.New()
+	blackout.CreatedAt = time.Now()
+	blackout.UpdatedAt = time.Now()
+
+	_, err := r.db.Exec(
+		query,
+		blackout.ID,
+		blackout.TeacherID,
+		blackout.StartDate,
+		blackout.EndDate,
+		blackout.Reason,
+		blackout.CreatedBy,
+		blackout.CreatedAt,
+		blackout.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create blackout: %w", err)
+	}
+
+	return nil
+}
+
+func (r *blackoutRepository) GetByID(id uuid.UUID) (*domain.TeacherBlackout, error) {
+	query := `SELECT ` + blackoutColumns + ` FROM teacher_blackouts WHERE id = $1`
+
+	var blackout domain.TeacherBlackout
+	err := r.db.Get(&blackout, query, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get blackout: %w", err)
+	}
+
+	return &blackout, nil
+}
+
+// GetByTeacher retorna os períodos do professor que se sobrepõem ao intervalo [from, to]
+func (r *blackoutRepository) GetByTeacher(teacherID uuid.UUID, from, to time.Time) ([]domain.TeacherBlackout, error) {
+	query := `
+		SELECT ` + blackoutColumns + `
+		FROM teacher_blackouts
+		WHERE teacher_id = $1
+		  AND start_date <= $3
+		  AND end_date >= $2
+		ORDER BY start_date
+	`
+
+	var blackouts []domain.TeacherBlackout
+	err := r.db.Select(&blackouts, query, teacherID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blackouts by teacher: %w", err)
+	}
+
+	return blackouts, nil
+}
+
+func (r *blackoutRepository) Delete(id uuid.UUID) error {
+	query := `DELETE FROM teacher_blackouts WHERE id = $1`
+
+	result, err := r.db.Exec(query, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete blackout: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,421 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hscHeric/go-potential-api/internal/domain"
+	"github.com/jmoiron/sqlx"
+)
+
+// BookingRepository concentra as matrículas que disputam as vagas de um horário.
+// Todas bloqueiam o horário com FOR UPDATE, então são serializadas entre si.
+type BookingRepository interface {
+	BookOccurrence(slotID uuid.UUID, date time.Time, studentID uuid.UUID, startsAt, endsAt time.Time) (*domain.Class, bool, error)
+	CreateClass(class *domain.Class, studentIDs []uuid.UUID) error
+	AddStudent(classID, studentID, addedBy uuid.UUID, chargeCredit bool) error
+}
+
+type bookingRepository struct {
+	db *sqlx.DB
+}
+
+func NewBookingRepository(db *sqlx.DB) BookingRepository {
+	return &bookingRepository{db: db}
+}
+
+// BookOccurrence matricula o aluno na ocorrência (slot + data) dentro de uma transação.
+// O horário é bloqueado com FOR UPDATE, então reservas simultâneas do mesmo horário
+// são serializadas e a última vaga não pode ser ocupada duas vezes.
+// Se já houver turma na ocorrência o aluno entra nela; senão uma nova aula é criada.
+// startsAt/endsAt são os instantes da ocorrência, gravados na aula criada.
+// Um crédito do aluno é debitado na mesma transação (ErrNoCredits desfaz a reserva).
+// Retorna a aula e se ela foi criada nesta reserva.
+func (r *bookingRepository) BookOccurrence(slotID uuid.UUID, date time.Time, studentID uuid.UUID, startsAt, endsAt time.Time) (*domain.Class, bool, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	// Rollback não tem efeito após o Commit
+	defer tx.Rollback()
+
+	var slot domain.TimeSlot
+	err = tx.Get(&slot, `
+		SELECT `+timeSlotColumns+`
+		FROM time_slots
+		WHERE id = $1 AND deleted_at IS NULL AND is_available = true
+		FOR UPDATE
+	`, slotID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, false, ErrNotFound
+		}
+		return nil, false, fmt.Errorf("failed to lock time slot: %w", err)
+	}
+
+	// Professor em período de indisponibilidade não recebe reservas
+	var blackedOut bool
+	err = tx.Get(&blackedOut, `
+		SELECT EXISTS(
+			SELECT 1 FROM teacher_blackouts
+			WHERE teacher_id = $1 AND start_date <= $2 AND end_date >= $2
+		)
+	`, slot.TeacherID, date)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to check blackouts: %w", err)
+	}
+	if blackedOut {
+		return nil, false, ErrTeacherUnavailable
+	}
+
+	var class domain.Class
+	created := false
+
+	err = tx.Get(&class, `
+		SELECT `+classColumns+`
+		FROM classes
+		WHERE time_slot_id = $1
+		  AND scheduled_date = $2
+		  AND deleted_at IS NULL
+		  AND status != 'cancelled'
+		ORDER BY created_at
+		LIMIT 1
+	`, slotID, date)
+
+	switch {
+	case err == nil:
+		var enrolled bool
+		if err := tx.Get(&enrolled, `SELECT EXISTS(SELECT 1 FROM class_students WHERE class_id = $1 AND student_id = $2 AND cancelled_at IS NULL)`, class.ID, studentID); err != nil {
+			return nil, false, fmt.Errorf("failed to check enrollment: %w", err)
+		}
+		if enrolled {
+			return nil, false, ErrAlreadyBooked
+		}
+
+		// Aula com sala reservada também é limitada pela capacidade da sala
+		limit := slot.MaxStudents
+		capacity, hasRoom, err := lockClassRoom(tx, class.ID)
+		if err != nil {
+			return nil, false, err
+		}
+		if hasRoom && capacity < limit {
+			limit = capacity
+		}
+
+		taken, err := takenSeats(tx, class.ID)
+		if err != nil {
+			return nil, false, err
+		}
+		if taken >= limit {
+			return nil, false, ErrSlotFull
+		}
+
+	case errors.Is(err, sql.ErrNoRows):
+		// Nenhuma turma ainda: o professor não pode ter outra aula no mesmo intervalo
+		var conflicts int
+		err := tx.Get(&conflicts, `
+			SELECT COUNT(*)
+			FROM classes c
+			INNER JOIN time_slots ts ON ts.id = $1
+			WHERE c.teacher_id = ts.teacher_id
+			  AND c.scheduled_date = $2
+			  AND c.deleted_at IS NULL
+			  AND c.status != 'cancelled'
+			  AND c.start_time < ts.end_time AND c.end_time > ts.start_time
+		`, slot.ID, date)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to check availability: %w", err)
+		}
+		if conflicts > 0 {
+			return nil, false, ErrTeacherUnavailable
+		}
+
+		class = domain.Class{
+			ID:            uuid.New(),
+			TeacherID:     slot.TeacherID,
+			TimeSlotID:    &slot.ID,
+			ScheduledDate: date,
+			StartTime:     slot.StartTime,
+			EndTime:       slot.EndTime,
+			StartsAt:      startsAt,
+			EndsAt:        endsAt,
+			Status:        domain.ClassStatusScheduled,
+			CreatedBy:     studentID,
+			CreatedAt:     time.Now(),
+			UpdatedAt:     time.Now(),
+		}
+
+		// Horários copiados direto da linha do slot para manter o tipo TIME
+		_, err = tx.Exec(`
+			INSERT INTO classes (id, teacher_id, time_slot_id, scheduled_date, start_time, end_time, starts_at, ends_at, status, created_by, created_at, updated_at)
+			SELECT $1, teacher_id, id, $2, start_time, end_time, $3, $4, $5, $6, $7, $8
+			FROM time_slots
+			WHERE id = $9
+		`, class.ID, class.ScheduledDate, class.StartsAt, class.EndsAt, class.Status, class.CreatedBy, class.CreatedAt, class.UpdatedAt, slot.ID)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to create class: %w", err)
+		}
+		created = true
+
+	default:
+		return nil, false, fmt.Errorf("failed to get class for occurrence: %w", err)
+	}
+
+	if err := insertClassStudent(tx, class.ID, studentID, studentID); err != nil {
+		return nil, false, err
+	}
+
+	if _, err := debitCredit(tx, studentID, class.ID, time.Now()); err != nil {
+		return nil, false, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, false, fmt.Errorf("failed to commit booking: %w", err)
+	}
+
+	return &class, created, nil
+}
+
+// CreateClass grava a aula marcada pela escola com os alunos iniciais. Aulas ligadas a um
+// horário bloqueiam o horário, para que uma reserva simultânea não crie outra turma na
+// mesma ocorrência nem ultrapasse a capacidade. Aulas com sala bloqueiam a sala e conferem
+// de novo se ela está livre e comporta os alunos.
+func (r *bookingRepository) CreateClass(class *domain.Class, studentIDs []uuid.UUID) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if class.TimeSlotID != nil {
+		var maxStudents int
+		err := tx.Get(&maxStudents, `SELECT max_students FROM time_slots WHERE id = $1 AND deleted_at IS NULL FOR UPDATE`, *class.TimeSlotID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrNotFound
+			}
+			return fmt.Errorf("failed to lock time slot: %w", err)
+		}
+
+		if len(studentIDs) > maxStudents {
+			return ErrSlotFull
+		}
+
+		if err := checkTeacherFree(tx, class.TeacherID, class.ScheduledDate, class.StartTime, class.EndTime); err != nil {
+			return err
+		}
+	}
+
+	if class.RoomID != nil {
+		capacity, err := lockRoom(tx, *class.RoomID)
+		if err != nil {
+			return err
+		}
+
+		if len(studentIDs) > capacity {
+			return ErrSlotFull
+		}
+
+		if err := checkRoomFree(tx, *class.RoomID, class.ScheduledDate, class.StartTime, class.EndTime, nil); err != nil {
+			return err
+		}
+	}
+
+	if err := insertClass(tx, class); err != nil {
+		return err
+	}
+
+	for _, studentID := range studentIDs {
+		if err := insertClassStudent(tx, class.ID, studentID, class.CreatedBy); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit class: %w", err)
+	}
+
+	return nil
+}
+
+// AddStudent matricula o aluno em uma aula existente. O horário da aula (mesmo excluído,
+// até a purga) e a sala são bloqueados antes da contagem de vagas; o limite é o menor
+// entre as vagas do horário e a capacidade da sala, e aulas sem nenhum dos dois não têm limite.
+// Com chargeCredit, um crédito do aluno é debitado na mesma transação.
+func (r *bookingRepository) AddStudent(classID, studentID, addedBy uuid.UUID, chargeCredit bool) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var maxStudents int
+	err = tx.Get(&maxStudents, `
+		SELECT ts.max_students
+		FROM classes c
+		INNER JOIN time_slots ts ON ts.id = c.time_slot_id
+		WHERE c.id = $1
+		FOR UPDATE OF ts
+	`, classID)
+
+	limit := -1
+	switch {
+	case err == nil:
+		limit = maxStudents
+	case !errors.Is(err, sql.ErrNoRows):
+		return fmt.Errorf("failed to lock time slot: %w", err)
+	}
+
+	capacity, hasRoom, err := lockClassRoom(tx, classID)
+	if err != nil {
+		return err
+	}
+	if hasRoom && (limit < 0 || capacity < limit) {
+		limit = capacity
+	}
+
+	if limit >= 0 {
+		taken, err := takenSeats(tx, classID)
+		if err != nil {
+			return err
+		}
+		if taken >= limit {
+			return ErrSlotFull
+		}
+	}
+
+	if err := insertClassStudent(tx, classID, studentID, addedBy); err != nil {
+		return err
+	}
+
+	if chargeCredit {
+		if _, err := debitCredit(tx, studentID, classID, time.Now()); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit enrollment: %w", err)
+	}
+
+	return nil
+}
+
+// takenSeats soma alunos matriculados e vagas oferecidas à lista de espera, que ficam
+// reservadas até o prazo de confirmação
+func takenSeats(tx *sqlx.Tx, classID uuid.UUID) (int, error) {
+	var taken int
+	err := tx.Get(&taken, `
+		SELECT (SELECT COUNT(*) FROM class_students WHERE class_id = $1 AND cancelled_at IS NULL) +
+		       (SELECT COUNT(*) FROM class_waitlist WHERE class_id = $1 AND status = 'offered' AND offer_expires_at > $2)
+	`, classID, time.Now())
+	if err != nil {
+		return 0, fmt.Errorf("failed to count students: %w", err)
+	}
+
+	return taken, nil
+}
+
+// checkTeacherFree recusa o intervalo (horários "15:04:05") se o professor já tiver outra aula nele
+func checkTeacherFree(tx *sqlx.Tx, teacherID uuid.UUID, date time.Time, startTime, endTime string) error {
+	var conflicts int
+	err := tx.Get(&conflicts, `
+		SELECT COUNT(*)
+		FROM classes
+		WHERE teacher_id = $1
+		  AND scheduled_date = $2
+		  AND deleted_at IS NULL
+		  AND status != 'cancelled'
+		  AND start_time < $4::time AND end_time > $3::time
+	`, teacherID, date, startTime, endTime)
+	if err != nil {
+		return fmt.Errorf("failed to check availability: %w", err)
+	}
+
+	if conflicts > 0 {
+		return ErrTeacherUnavailable
+	}
+
+	return nil
+}
+
+// lockRoom bloqueia a sala, serializando as reservas que disputam o mesmo espaço, e
+// retorna a capacidade
+func lockRoom(tx *sqlx.Tx, roomID uuid.UUID) (int, error) {
+	var capacity int
+	err := tx.Get(&capacity, `SELECT capacity FROM rooms WHERE id = $1 FOR UPDATE`, roomID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, ErrNotFound
+		}
+		return 0, fmt.Errorf("failed to lock room: %w", err)
+	}
+
+	return capacity, nil
+}
+
+// lockClassRoom bloqueia a sala reservada para a aula e retorna a capacidade;
+// ok é false quando a aula não tem sala
+func lockClassRoom(tx *sqlx.Tx, classID uuid.UUID) (capacity int, ok bool, err error) {
+	err = tx.Get(&capacity, `
+		SELECT r.capacity
+		FROM classes c
+		INNER JOIN rooms r ON r.id = c.room_id
+		WHERE c.id = $1
+		FOR UPDATE OF r
+	`, classID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, false, nil
+		}
+		return 0, false, fmt.Errorf("failed to lock room: %w", err)
+	}
+
+	return capacity, true, nil
+}
+
+// checkRoomFree recusa o intervalo se outra aula não cancelada ocupar a sala nele.
+// excludeClassID ignora a própria aula.
+func checkRoomFree(tx *sqlx.Tx, roomID uuid.UUID, date time.Time, startTime, endTime string, excludeClassID *uuid.UUID) error {
+	var conflicts int
+	err := tx.Get(&conflicts, `
+		SELECT COUNT(*)
+		FROM classes
+		WHERE room_id = $1
+		  AND scheduled_date = $2
+		  AND deleted_at IS NULL
+		  AND status != 'cancelled'
+		  AND ($5::uuid IS NULL OR id != $5::uuid)
+		  AND start_time < $4::time AND end_time > $3::time
+	`, roomID, date, startTime, endTime, excludeClassID)
+	if err != nil {
+		return fmt.Errorf("failed to check room availability: %w", err)
+	}
+
+	if conflicts > 0 {
+		return ErrRoomUnavailable
+	}
+
+	return nil
+}
+
+// insertClassStudent matricula o aluno; uma matrícula cancelada é reativada e uma
+// matrícula ativa fica como está
+func insertClassStudent(tx *sqlx.Tx, classID, studentID, addedBy uuid.UUID) error {
+	_, err := tx.Exec(`
+		INSERT INTO class_students (id, class_id, student_id, added_by, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (class_id, student_id) DO UPDATE
+		SET added_by = EXCLUDED.added_by, attended = NULL, created_at = EXCLUDED.created_at,
+		    cancelled_at = NULL, cancelled_by = NULL, cancellation_outcome = NULL
+		WHERE class_students.cancelled_at IS NOT NULL
+	`, uuid.New(), classID, studentID, addedBy, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to add student to class: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,141 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hscHeric/go-potential-api/internal/domain"
+	"github.com/jmoiron/sqlx"
+)
+
+type BranchRepository interface {
+	Create(branch *domain.Branch) error
+	GetByID(id uuid.UUID) (*domain.Branch, error)
+	List(includeInactive bool) ([]domain.Branch, error)
+	Update(branch *domain.Branch) error
+	Delete(id uuid.UUID) error
+}
+
+const branchColumns = `id, name, address, is_active, created_at, updated_at`
+
+type branchRepository struct {
+	db *sqlx.DB
+}
+
+func NewBranchRepository(db *sqlx.DB) BranchRepository {
+	return &branchRepository{db: db}
+}
+
+func (r *branchRepository) Create(branch *domain.Branch) error {
+	query := `
+		INSERT INTO branches (id, name, address, is_active, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+
+	branch.ID = uuid.New()
+	branch.CreatedAt = time.Now()
+	branch.UpdatedAt = time.Now()
+
+	_, err := r.db.Exec(
+		query,
+		branch.ID,
+		branch.Name,
+		branch.Address,
+		branch.IsActive,
+		branch.CreatedAt,
+		branch.UpdatedAt,
+	)
+	if err != nil {
+		if IsDuplicateKeyError(err) {
+			return ErrAlreadyExists
+		}
+		return fmt.Errorf("failed to create branch: %w", err)
+	}
+
+	return nil
+}
+
+func (r *branchRepository) GetByID(id uuid.UUID) (*domain.Branch, error) {
+	query := `SELECT ` + branchColumns + ` FROM branches WHERE id = $1`
+
+	var branch domain.Branch
+	err := r.db.Get(&branch, query, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get branch: %w", err)
+	}
+
+	return &branch, nil
+}
+
+func (r *branchRepository) List(includeInactive bool) ([]domain.Branch, error) {
+	query := `
+		SELECT ` + branchColumns + `
+		FROM branches
+		WHERE $1 OR is_active = true
+		ORDER BY name
+	`
+
+	var branches []domain.Branch
+	err := r.db.Select(&branches, query, includeInactive)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list branches: %w", err)
+	}
+
+	return branches, nil
+}
+
+func (r *branchRepository) Update(branch *domain.Branch) error {
+	query := `
+		UPDATE branches
+		SET name = $1, address = $2, is_active = $3, updated_at = $4
+		WHERE id = $5
+	`
+
+	branch.UpdatedAt = time.Now()
+
+	result, err := r.db.Exec(query, branch.Name, branch.Address, branch.IsActive, branch.UpdatedAt, branch.ID)
+	if err != nil {
+		if IsDuplicateKeyError(err) {
+			return ErrAlreadyExists
+		}
+		return fmt.Errorf("failed to update branch: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// Delete remove a unidade e suas salas; aulas que reservaram as salas ficam sem sala
+func (r *branchRepository) Delete(id uuid.UUID) error {
+	query := `DELETE FROM branches WHERE id = $1`
+
+	result, err := r.db.Exec(query, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete branch: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hscHeric/go-potential-api/internal/domain"
+	"github.com/jmoiron/sqlx"
+)
+
+type CalendarFeedRepository interface {
+	Save(feed *domain.CalendarFeed) error
+	GetByAuthID(authID uuid.UUID) (*domain.CalendarFeed, error)
+	GetByToken(token string) (*domain.CalendarFeed, error)
+	MarkAccessed(id uuid.UUID) error
+	Delete(authID uuid.UUID) error
+}
+
+const calendarFeedColumns = `id, auth_id, token, last_accessed_at, created_at`
+
+type calendarFeedRepository struct {
+	db *sqlx.DB
+}
+
+func NewCalendarFeedRepository(db *sqlx.DB) CalendarFeedRepository {
+	return &calendarFeedRepository{db: db}
+}
+
+// Save grava o feed do usuário. Se já houver um, o token é substituído e a URL
+// anterior deixa de funcionar.
+func (r *calendarFeedRepository) Save(feed *domain.CalendarFeed) error {
+	query := `
+		INSERT INTO calendar_feeds (id, auth_id, token, created_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (auth_id) DO UPDATE
+		SET token = EXCLUDED.token, created_at = EXCLUDED.created_at, last_accessed_at = NULL
+		RETURNING id
+	`
+
+	feed.CreatedAt = time.Now()
+	feed.LastAccessedAt = nil
+
+	if err := r.db.Get(&feed.ID, query, uuid.New(), feed.AuthID, feed.Token, feed.CreatedAt); err != nil {
+		if IsDuplicateKeyError(err) {
+			return ErrAlreadyExists
+		}
+		return fmt.Errorf("failed to save calendar feed: %w", err)
+	}
+
+	return nil
+}
+
+func (r *calendarFeedRepository) GetByAuthID(authID uuid.UUID) (*domain.CalendarFeed, error) {
+	query := `SELECT ` + calendarFeedColumns + ` FROM calendar_feeds WHERE auth_id = $1`
+
+	var feed domain.CalendarFeed
+	if err := r.db.Get(&feed, query, authID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get calendar feed: %w", err)
+	}
+
+	return &feed, nil
+}
+
+func (r *calendarFeedRepository) GetByToken(token string) (*domain.CalendarFeed, error) {
+	query := `SELECT ` + calendarFeedColumns + ` FROM calendar_feeds WHERE token = $1`
+
+	var feed domain.CalendarFeed
+	if err := r.db.Get(&feed, query, token); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get calendar feed: %w", err)
+	}
+
+	return &feed, nil
+}
+
+func (r *calendarFeedRepository) MarkAccessed(id uuid.UUID) error {
+	query := `UPDATE calendar_feeds SET last_accessed_at = $1 WHERE id = $2`
+
+	if _, err := r.db.Exec(query, time.Now(), id); err != nil {
+		return fmt.Errorf("failed to update calendar feed: %w", err)
+	}
+
+	return nil
+}
+
+func (r *calendarFeedRepository) Delete(authID uuid.UUID) error {
+	query := `DELETE FROM calendar_feeds WHERE auth_id = $1`
+
+	result, err := r.db.Exec(query, authID)
+	if err != nil {
+		return fmt.Errorf("failed to delete calendar feed: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
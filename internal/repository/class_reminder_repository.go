@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hscHeric/go-potential-api/internal/domain"
+	"github.com/jmoiron/sqlx"
+)
+
+type ClassReminderRepository interface {
+	GetDue(offsetMinutes int, from, to time.Time) ([]domain.ClassReminderTarget, error)
+	Claim(classID, authID uuid.UUID, offsetMinutes int) (bool, error)
+	Release(classID, authID uuid.UUID, offsetMinutes int) error
+}
+
+type classReminderRepository struct {
+	db *sqlx.DB
+}
+
+func NewClassReminderRepository(db *sqlx.DB) ClassReminderRepository {
+	return &classReminderRepository{db: db}
+}
+
+// GetDue retorna o professor e os alunos ativos das aulas agendadas que começam entre
+// from (exclusive) e to (inclusive) e ainda não receberam o lembrete dessa antecedência.
+// Usuários que desligaram os lembretes ficam de fora.
+func (r *classReminderRepository) GetDue(offsetMinutes int, from, to time.Time) ([]domain.ClassReminderTarget, error) {
+	query := `
+		SELECT c.id AS class_id, p.auth_id
+		FROM classes c
+		CROSS JOIN LATERAL (
+		  SELECT c.teacher_id AS auth_id
+		  UNION
+		  SELECT cs.student_id FROM class_students cs
+		  WHERE cs.class_id = c.id AND cs.cancelled_at IS NULL
+		) p
+		INNER JOIN users u ON u.auth_id = p.auth_id AND u.deleted_at IS NULL
+		WHERE c.status = 'scheduled'
+		  AND c.deleted_at IS NULL
+		  AND c.starts_at > $1
+		  AND c.starts_at <= $2
+		  AND u.class_reminders = true
+		  AND NOT EXISTS (
+		    SELECT 1 FROM class_reminders cr
+		    WHERE cr.class_id = c.id AND cr.auth_id = p.auth_id AND cr.offset_minutes = $3
+		  )
+		ORDER BY c.starts_at, c.id
+	`
+
+	var targets []domain.ClassReminderTarget
+	err := r.db.Select(&targets, query, from, to, offsetMinutes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get due class reminders: %w", err)
+	}
+
+	return targets, nil
+}
+
+// Claim registra o envio do lembrete antes de enviá-lo. Retorna false se ele já foi
+// registrado (por uma execução anterior ou por outra instância da API).
+func (r *classReminderRepository) Claim(classID, authID uuid.UUID, offsetMinutes int) (bool, error) {
+	query := `
+		INSERT INTO class_reminders (class_id, auth_id, offset_minutes, sent_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (class_id, auth_id, offset_minutes) DO NOTHING
+	`
+
+	result, err := r.db.Exec(query, classID, authID, offsetMinutes, time.Now())
+	if err != nil {
+		return false, fmt.Errorf("failed to claim class reminder: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// Release desfaz o registro de um lembrete cujo envio falhou, para nova tentativa
+func (r *classReminderRepository) Release(classID, authID uuid.UUID, offsetMinutes int) error {
+	query := `DELETE FROM class_reminders WHERE class_id = $1 AND auth_id = $2 AND offset_minutes = $3`
+
+	_, err := r.db.Exec(query, classID, authID, offsetMinutes)
+	if err != nil {
+		return fmt.Errorf("failed to release class reminder: %w", err)
+	}
+
+	return nil
+}
@@ -14,14 +14,58 @@ import (
 type ClassRepository interface {
 	Create(class *domain.Class) error
 	GetByID(id uuid.UUID) (*domain.Class, error)
-	GetByTeacher(teacherID uuid.UUID, startDate, endDate time.Time) ([]domain.Class, error)
-	GetByStudent(studentID uuid.UUID, startDate, endDate time.Time) ([]domain.Class, error)
+	GetByTeacher(teacherID uuid.UUID, startDate, endDate time.Time, filter domain.ClassFilter) ([]domain.Class, error)
+	GetByStudent(studentID uuid.UUID, startDate, endDate time.Time, filter domain.ClassFilter) ([]domain.Class, error)
+	GetFeedByTeacher(teacherID uuid.UUID, startDate, endDate time.Time) ([]domain.Class, error)
+	GetFeedByStudent(studentID uuid.UUID, startDate, endDate time.Time) ([]domain.Class, error)
+	GetBySeries(seriesID uuid.UUID, fromDate *time.Time) ([]domain.Class, error)
+	GetOccupancyByTeacher(teacherID uuid.UUID, startDate, endDate time.Time) ([]domain.ClassOccupancy, error)
+	List(filter domain.ClassListFilter) ([]domain.Class, int, error)
+	GetSchedule(filter domain.ClassListFilter) ([]domain.ScheduleEntry, error)
+	CountUpcomingBySlot(slotID uuid.UUID, from time.Time) (int, error)
+	GetScheduledByDate(date time.Time) ([]domain.Class, error)
 	Update(class *domain.Class) error
 	UpdateStatus(id uuid.UUID, status domain.ClassStatus) error
+	TransitionStatus(id uuid.UUID, from, to domain.ClassStatus) error
+	GetEndedAttendance(endedBefore time.Time) ([]domain.ClassAttendanceSummary, error)
+	MarkAttendanceReminded(id uuid.UUID) error
 	Delete(id uuid.UUID) error
-	CheckTeacherAvailability(teacherID uuid.UUID, date time.Time, startTime, endTime string) (bool, error)
+	Restore(id uuid.UUID) error
+	GetDeletedByID(id uuid.UUID) (*domain.Class, error)
+	PurgeDeleted(before time.Time) (int64, error)
+	AssignTeacher(id, teacherID uuid.UUID, reason *string) error
+	AssignRoom(id uuid.UUID, roomID *uuid.UUID) error
+	GetByRoom(roomID uuid.UUID, startDate, endDate time.Time) ([]domain.Class, error)
+	CheckTeacherAvailability(teacherID uuid.UUID, date time.Time, startTime, endTime string, excludeClassID *uuid.UUID) (bool, error)
+	GetStudentOverlaps(studentID uuid.UUID, date time.Time, startTime, endTime string, excludeClassID *uuid.UUID) ([]domain.Class, error)
 }
 
+// classColumns lista as colunas de classes na ordem usada pelos SELECTs
+const classColumns = `id, teacher_id, time_slot_id, series_id, scheduled_date, start_time, end_time,
+		       starts_at, ends_at, status, title, description, class_link, material_id, language, level,
+		       created_by, created_at, updated_at, deleted_at,
+		       original_teacher_id, substitution_reason, substituted_at, room_id`
+
+// prefixedClassColumns é classColumns com o alias "c." para consultas com JOIN
+const prefixedClassColumns = `c.id, c.teacher_id, c.time_slot_id, c.series_id, c.scheduled_date, c.start_time, c.end_time,
+		       c.starts_at, c.ends_at, c.status, c.title, c.description, c.class_link, c.material_id, c.language, c.level,
+		       c.created_by, c.created_at, c.updated_at, c.deleted_at,
+		       c.original_teacher_id, c.substitution_reason, c.substituted_at, c.room_id`
+
+// classListWhere aplica os filtros de domain.ClassListFilter; parâmetros nulos ou vazios não filtram
+const classListWhere = `
+		WHERE c.deleted_at IS NULL
+		  AND ($1::uuid IS NULL OR c.teacher_id = $1)
+		  AND ($2::uuid IS NULL OR EXISTS (
+		    SELECT 1 FROM class_students cs
+		    WHERE cs.class_id = c.id AND cs.student_id = $2 AND cs.cancelled_at IS NULL
+		  ))
+		  AND ($3::text = '' OR c.status::text = $3)
+		  AND ($4::date IS NULL OR c.scheduled_date >= $4)
+		  AND ($5::date IS NULL OR c.scheduled_date <= $5)
+		  AND ($6::text = '' OR c.language = $6)
+`
+
 type classRepository struct {
 	db *sqlx.DB
 }
@@ -31,35 +75,46 @@ func NewClassRepository(db *sqlx.DB) ClassRepository {
 }
 
 func (r *classRepository) Create(class *domain.Class) error {
+	return insertClass(r.db, class)
+}
+
+// insertClass grava uma nova aula; recebe o banco ou uma transação em andamento
+func insertClass(db sqlx.Execer, class *domain.Class) error {
 	query := `
 		INSERT INTO classes (
-			id, teacher_id, time_slot_id, scheduled_date, start_time, end_time,
-			status, title, description, class_link, material_id, created_by,
-			created_at, updated_at
+			id, teacher_id, time_slot_id, series_id, scheduled_date, start_time, end_time,
+			starts_at, ends_at, status, title, description, class_link, material_id, language, level,
+			created_by, created_at, updated_at, room_id
 		)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20)
 	`
 
 	class.ID = uuid.New()
 	class.CreatedAt = time.Now()
 	class.UpdatedAt = time.Now()
 
-	_, err := r.db.Exec(
+	_, err := db.Exec(
 		query,
 		class.ID,
 		class.TeacherID,
 		class.TimeSlotID,
+		class.SeriesID,
 		class.ScheduledDate,
 		class.StartTime,
 		class.EndTime,
+		class.StartsAt,
+		class.EndsAt,
 		class.Status,
 		class.Title,
 		class.Description,
 		class.ClassLink,
 		class.MaterialID,
+		class.Language,
+		class.Level,
 		class.CreatedBy,
 		class.CreatedAt,
 		class.UpdatedAt,
+		class.RoomID,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to create class: %w", err)
@@ -70,11 +125,9 @@ func (r *classRepository) Create(class *domain.Class) error {
 
 func (r *classRepository) GetByID(id uuid.UUID) (*domain.Class, error) {
 	query := `
-		SELECT id, teacher_id, time_slot_id, scheduled_date, start_time, end_time,
-		       status, title, description, class_link, material_id, created_by,
-		       created_at, updated_at
+		SELECT ` + classColumns + `
 		FROM classes
-		WHERE id = $1
+		WHERE id = $1 AND deleted_at IS NULL
 	`
 
 	var class domain.Class
@@ -89,20 +142,21 @@ func (r *classRepository) GetByID(id uuid.UUID) (*domain.Class, error) {
 	return &class, nil
 }
 
-func (r *classRepository) GetByTeacher(teacherID uuid.UUID, startDate, endDate time.Time) ([]domain.Class, error) {
+func (r *classRepository) GetByTeacher(teacherID uuid.UUID, startDate, endDate time.Time, filter domain.ClassFilter) ([]domain.Class, error) {
 	query := `
-		SELECT id, teacher_id, time_slot_id, scheduled_date, start_time, end_time,
-		       status, title, description, class_link, material_id, created_by,
-		       created_at, updated_at
+		SELECT ` + classColumns + `
 		FROM classes
 		WHERE teacher_id = $1
+		  AND deleted_at IS NULL
 		  AND scheduled_date >= $2
 		  AND scheduled_date <= $3
+		  AND ($4 = '' OR language = $4)
+		  AND ($5 = '' OR level = $5)
 		ORDER BY scheduled_date, start_time
 	`
 
 	var classes []domain.Class
-	err := r.db.Select(&classes, query, teacherID, startDate, endDate)
+	err := r.db.Select(&classes, query, teacherID, startDate, endDate, filter.Language, string(filter.Level))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get classes by teacher: %w", err)
 	}
@@ -110,21 +164,23 @@ func (r *classRepository) GetByTeacher(teacherID uuid.UUID, startDate, endDate t
 	return classes, nil
 }
 
-func (r *classRepository) GetByStudent(studentID uuid.UUID, startDate, endDate time.Time) ([]domain.Class, error) {
+func (r *classRepository) GetByStudent(studentID uuid.UUID, startDate, endDate time.Time, filter domain.ClassFilter) ([]domain.Class, error) {
 	query := `
-		SELECT c.id, c.teacher_id, c.time_slot_id, c.scheduled_date, c.start_time, c.end_time,
-		       c.status, c.title, c.description, c.class_link, c.material_id, c.created_by,
-		       c.created_at, c.updated_at
+		SELECT ` + prefixedClassColumns + `
 		FROM classes c
 		INNER JOIN class_students cs ON cs.class_id = c.id
 		WHERE cs.student_id = $1
+		  AND cs.cancelled_at IS NULL
+		  AND c.deleted_at IS NULL
 		  AND c.scheduled_date >= $2
 		  AND c.scheduled_date <= $3
+		  AND ($4 = '' OR c.language = $4)
+		  AND ($5 = '' OR c.level = $5)
 		ORDER BY c.scheduled_date, c.start_time
 	`
 
 	var classes []domain.Class
-	err := r.db.Select(&classes, query, studentID, startDate, endDate)
+	err := r.db.Select(&classes, query, studentID, startDate, endDate, filter.Language, string(filter.Level))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get classes by student: %w", err)
 	}
@@ -132,11 +188,200 @@ func (r *classRepository) GetByStudent(studentID uuid.UUID, startDate, endDate t
 	return classes, nil
 }
 
+// feedClassColumns são as colunas da aula no feed de calendário, sem status e updated_at:
+// cada consulta os calcula para que aulas excluídas e matrículas canceladas saiam como
+// canceladas e com a alteração registrada
+const feedClassColumns = `c.id, c.teacher_id, c.time_slot_id, c.series_id, c.scheduled_date, c.start_time, c.end_time,
+		       c.starts_at, c.ends_at, c.title, c.description, c.class_link, c.material_id, c.language, c.level,
+		       c.created_by, c.created_at, c.deleted_at,
+		       c.original_teacher_id, c.substitution_reason, c.substituted_at, c.room_id`
+
+// GetFeedByTeacher retorna as aulas do professor no intervalo para o feed de calendário,
+// incluindo as excluídas (até a purga)
+func (r *classRepository) GetFeedByTeacher(teacherID uuid.UUID, startDate, endDate time.Time) ([]domain.Class, error) {
+	query := `
+		SELECT ` + feedClassColumns + `,
+		       CASE WHEN c.deleted_at IS NOT NULL THEN 'cancelled' ELSE c.status END AS status,
+		       GREATEST(c.updated_at, c.deleted_at) AS updated_at
+		FROM classes c
+		WHERE c.teacher_id = $1
+		  AND c.scheduled_date >= $2
+		  AND c.scheduled_date <= $3
+		ORDER BY c.scheduled_date, c.start_time
+	`
+
+	var classes []domain.Class
+	err := r.db.Select(&classes, query, teacherID, startDate, endDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get feed classes by teacher: %w", err)
+	}
+
+	return classes, nil
+}
+
+// GetFeedByStudent retorna as aulas do aluno no intervalo para o feed de calendário,
+// incluindo as excluídas (até a purga) e as de matrículas canceladas
+func (r *classRepository) GetFeedByStudent(studentID uuid.UUID, startDate, endDate time.Time) ([]domain.Class, error) {
+	query := `
+		SELECT ` + feedClassColumns + `,
+		       CASE WHEN c.deleted_at IS NOT NULL OR cs.cancelled_at IS NOT NULL THEN 'cancelled' ELSE c.status END AS status,
+		       GREATEST(c.updated_at, c.deleted_at, cs.cancelled_at) AS updated_at
+		FROM classes c
+		INNER JOIN class_students cs ON cs.class_id = c.id
+		WHERE cs.student_id = $1
+		  AND c.scheduled_date >= $2
+		  AND c.scheduled_date <= $3
+		ORDER BY c.scheduled_date, c.start_time
+	`
+
+	var classes []domain.Class
+	err := r.db.Select(&classes, query, studentID, startDate, endDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get feed classes by student: %w", err)
+	}
+
+	return classes, nil
+}
+
+// GetBySeries retorna as aulas agendadas da série, opcionalmente a partir de uma data
+func (r *classRepository) GetBySeries(seriesID uuid.UUID, fromDate *time.Time) ([]domain.Class, error) {
+	query := `
+		SELECT ` + classColumns + `
+		FROM classes
+		WHERE series_id = $1
+		  AND deleted_at IS NULL
+		  AND status = 'scheduled'
+		  AND ($2::date IS NULL OR scheduled_date >= $2::date)
+		ORDER BY scheduled_date, start_time
+	`
+
+	var classes []domain.Class
+	err := r.db.Select(&classes, query, seriesID, fromDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get classes by series: %w", err)
+	}
+
+	return classes, nil
+}
+
+// GetOccupancyByTeacher retorna as aulas ativas (não canceladas) do professor com o total de
+// alunos, contando também as vagas reservadas para ofertas da lista de espera
+func (r *classRepository) GetOccupancyByTeacher(teacherID uuid.UUID, startDate, endDate time.Time) ([]domain.ClassOccupancy, error) {
+	query := `
+		SELECT ` + prefixedClassColumns + `,
+		       (SELECT COUNT(*) FROM class_students cs WHERE cs.class_id = c.id AND cs.cancelled_at IS NULL) +
+		       (SELECT COUNT(*) FROM class_waitlist cw
+		        WHERE cw.class_id = c.id AND cw.status = 'offered' AND cw.offer_expires_at > now()) AS student_count
+		FROM classes c
+		WHERE c.teacher_id = $1
+		  AND c.deleted_at IS NULL
+		  AND c.status != 'cancelled'
+		  AND c.scheduled_date >= $2
+		  AND c.scheduled_date <= $3
+		ORDER BY c.scheduled_date, c.start_time
+	`
+
+	var classes []domain.ClassOccupancy
+	err := r.db.Select(&classes, query, teacherID, startDate, endDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get class occupancy by teacher: %w", err)
+	}
+
+	return classes, nil
+}
+
+// List retorna uma página das aulas que atendem aos filtros e o total de aulas encontradas
+func (r *classRepository) List(filter domain.ClassListFilter) ([]domain.Class, int, error) {
+	args := []interface{}{filter.TeacherID, filter.StudentID, string(filter.Status), filter.StartDate, filter.EndDate, filter.Language}
+
+	var total int
+	err := r.db.Get(&total, `SELECT COUNT(*) FROM classes c`+classListWhere, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count classes: %w", err)
+	}
+
+	query := `
+		SELECT ` + prefixedClassColumns + `
+		FROM classes c` + classListWhere + `
+		ORDER BY c.starts_at, c.id
+		LIMIT $7 OFFSET $8
+	`
+
+	var classes []domain.Class
+	err = r.db.Select(&classes, query, append(args, filter.PageSize, (filter.Page-1)*filter.PageSize)...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list classes: %w", err)
+	}
+
+	return classes, total, nil
+}
+
+// GetSchedule retorna as aulas que atendem aos filtros com a lotação e o nome do professor,
+// ordenadas por professor, data e horário. A paginação do filtro não se aplica.
+func (r *classRepository) GetSchedule(filter domain.ClassListFilter) ([]domain.ScheduleEntry, error) {
+	query := `
+		SELECT ` + prefixedClassColumns + `,
+		       (SELECT COUNT(*) FROM class_students cs WHERE cs.class_id = c.id AND cs.cancelled_at IS NULL) AS student_count,
+		       COALESCE(u.full_name, '') AS teacher_name
+		FROM classes c
+		LEFT JOIN users u ON u.auth_id = c.teacher_id` + classListWhere + `
+		ORDER BY teacher_name, c.teacher_id, c.scheduled_date, c.start_time
+	`
+
+	var entries []domain.ScheduleEntry
+	err := r.db.Select(&entries, query, filter.TeacherID, filter.StudentID, string(filter.Status), filter.StartDate, filter.EndDate, filter.Language)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get class schedule: %w", err)
+	}
+
+	return entries, nil
+}
+
+// CountUpcomingBySlot conta as aulas agendadas do horário que começam a partir de from
+func (r *classRepository) CountUpcomingBySlot(slotID uuid.UUID, from time.Time) (int, error) {
+	query := `
+		SELECT COUNT(*)
+		FROM classes
+		WHERE time_slot_id = $1
+		  AND starts_at >= $2
+		  AND status = 'scheduled'
+		  AND deleted_at IS NULL
+	`
+
+	var count int
+	if err := r.db.Get(&count, query, slotID, from); err != nil {
+		return 0, fmt.Errorf("failed to count upcoming classes: %w", err)
+	}
+
+	return count, nil
+}
+
+// GetScheduledByDate retorna as aulas agendadas de todos os professores na data
+func (r *classRepository) GetScheduledByDate(date time.Time) ([]domain.Class, error) {
+	query := `
+		SELECT ` + classColumns + `
+		FROM classes
+		WHERE scheduled_date = $1
+		  AND deleted_at IS NULL
+		  AND status = 'scheduled'
+		ORDER BY start_time, teacher_id
+	`
+
+	var classes []domain.Class
+	err := r.db.Select(&classes, query, date)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get classes by date: %w", err)
+	}
+
+	return classes, nil
+}
+
 func (r *classRepository) Update(class *domain.Class) error {
 	query := `
 		UPDATE classes
-		SET title = $1, description = $2, class_link = $3, material_id = $4, updated_at = $5
-		WHERE id = $6
+		SET title = $1, description = $2, class_link = $3, material_id = $4,
+		    language = $5, level = $6, updated_at = $7
+		WHERE id = $8 AND deleted_at IS NULL
 	`
 
 	class.UpdatedAt = time.Now()
@@ -147,6 +392,8 @@ func (r *classRepository) Update(class *domain.Class) error {
 		class.Description,
 		class.ClassLink,
 		class.MaterialID,
+		class.Language,
+		class.Level,
 		class.UpdatedAt,
 		class.ID,
 	)
@@ -170,7 +417,7 @@ func (r *classRepository) UpdateStatus(id uuid.UUID, status domain.ClassStatus)
 	query := `
 		UPDATE classes
 		SET status = $1, updated_at = $2
-		WHERE id = $3
+		WHERE id = $3 AND deleted_at IS NULL
 	`
 
 	result, err := r.db.Exec(query, status, time.Now(), id)
@@ -190,10 +437,74 @@ func (r *classRepository) UpdateStatus(id uuid.UUID, status domain.ClassStatus)
 	return nil
 }
 
+// TransitionStatus muda o status da aula apenas se ela ainda estiver em from, evitando
+// sobrescrever uma mudança concorrente (ex.: cancelamento durante o job de encerramento)
+func (r *classRepository) TransitionStatus(id uuid.UUID, from, to domain.ClassStatus) error {
+	query := `
+		UPDATE classes
+		SET status = $1, updated_at = $2
+		WHERE id = $3 AND status = $4 AND deleted_at IS NULL
+	`
+
+	result, err := r.db.Exec(query, to, time.Now(), id, from)
+	if err != nil {
+		return fmt.Errorf("failed to update class status: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// GetEndedAttendance retorna as aulas ainda agendadas que terminaram antes de endedBefore,
+// com a contagem de presenças e de chamadas pendentes das participações ativas
+func (r *classRepository) GetEndedAttendance(endedBefore time.Time) ([]domain.ClassAttendanceSummary, error) {
+	query := `
+		SELECT c.id AS class_id, c.teacher_id, c.ends_at, c.attendance_reminded_at,
+		       COUNT(cs.id) FILTER (WHERE cs.attended = true) AS attended,
+		       COUNT(cs.id) FILTER (WHERE cs.attended IS NULL) AS pending
+		FROM classes c
+		LEFT JOIN class_students cs ON cs.class_id = c.id AND cs.cancelled_at IS NULL
+		WHERE c.status = 'scheduled'
+		  AND c.deleted_at IS NULL
+		  AND c.ends_at <= $1
+		GROUP BY c.id
+		ORDER BY c.ends_at
+	`
+
+	var summaries []domain.ClassAttendanceSummary
+	err := r.db.Select(&summaries, query, endedBefore)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ended classes: %w", err)
+	}
+
+	return summaries, nil
+}
+
+// MarkAttendanceReminded registra que o professor já foi lembrado da chamada da aula
+func (r *classRepository) MarkAttendanceReminded(id uuid.UUID) error {
+	query := `UPDATE classes SET attendance_reminded_at = $1 WHERE id = $2`
+
+	_, err := r.db.Exec(query, time.Now(), id)
+	if err != nil {
+		return fmt.Errorf("failed to mark attendance reminder: %w", err)
+	}
+
+	return nil
+}
+
+// Delete faz o soft delete da aula; o registro é removido definitivamente pelo PurgeDeleted
 func (r *classRepository) Delete(id uuid.UUID) error {
-	query := `DELETE FROM classes WHERE id = $1`
+	query := `UPDATE classes SET deleted_at = $1 WHERE id = $2 AND deleted_at IS NULL`
 
-	result, err := r.db.Exec(query, id)
+	result, err := r.db.Exec(query, time.Now(), id)
 	if err != nil {
 		return fmt.Errorf("failed to delete class: %w", err)
 	}
@@ -210,23 +521,237 @@ func (r *classRepository) Delete(id uuid.UUID) error {
 	return nil
 }
 
-func (r *classRepository) CheckTeacherAvailability(teacherID uuid.UUID, date time.Time, startTime, endTime string) (bool, error) {
+func (r *classRepository) Restore(id uuid.UUID) error {
+	query := `UPDATE classes SET deleted_at = NULL, updated_at = $1 WHERE id = $2 AND deleted_at IS NOT NULL`
+
+	result, err := r.db.Exec(query, time.Now(), id)
+	if err != nil {
+		return fmt.Errorf("failed to restore class: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *classRepository) GetDeletedByID(id uuid.UUID) (*domain.Class, error) {
 	query := `
-		SELECT COUNT(*) FROM classes
-		WHERE teacher_id = $1
-		  AND scheduled_date = $2
+		SELECT ` + classColumns + `
+		FROM classes
+		WHERE id = $1 AND deleted_at IS NOT NULL
+	`
+
+	var class domain.Class
+	err := r.db.Get(&class, query, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get deleted class: %w", err)
+	}
+
+	return &class, nil
+}
+
+// PurgeDeleted remove definitivamente as aulas excluídas antes de "before"
+func (r *classRepository) PurgeDeleted(before time.Time) (int64, error) {
+	query := `DELETE FROM classes WHERE deleted_at IS NOT NULL AND deleted_at < $1`
+
+	result, err := r.db.Exec(query, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge deleted classes: %w", err)
+	}
+
+	return result.RowsAffected()
+}
+
+// AssignTeacher passa a aula agendada para outro professor. O titular é preservado em
+// original_teacher_id mesmo após várias substituições; devolver a aula ao titular
+// desfaz a substituição.
+func (r *classRepository) AssignTeacher(id, teacherID uuid.UUID, reason *string) error {
+	query := `
+		UPDATE classes
+		SET original_teacher_id = NULLIF(COALESCE(original_teacher_id, teacher_id), $1::uuid),
+		    substitution_reason = CASE WHEN COALESCE(original_teacher_id, teacher_id) = $1::uuid THEN NULL ELSE $2::text END,
+		    substituted_at = CASE WHEN COALESCE(original_teacher_id, teacher_id) = $1::uuid THEN NULL ELSE $3::timestamptz END,
+		    teacher_id = $1,
+		    updated_at = $3
+		WHERE id = $4 AND deleted_at IS NULL AND status = 'scheduled'
+	`
+
+	result, err := r.db.Exec(query, teacherID, reason, time.Now(), id)
+	if err != nil {
+		return fmt.Errorf("failed to assign teacher: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// AssignRoom reserva a sala para a aula agendada (roomID nil libera a sala). A sala é
+// bloqueada e conferida de novo na mesma transação: ErrRoomUnavailable se outra aula a
+// ocupar no horário, ErrSlotFull se os alunos da aula não couberem nela.
+func (r *classRepository) AssignRoom(id uuid.UUID, roomID *uuid.UUID) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Sala antes da aula, na mesma ordem das matrículas, que bloqueiam a sala e depois
+	// referenciam a aula
+	capacity := 0
+	if roomID != nil {
+		if capacity, err = lockRoom(tx, *roomID); err != nil {
+			return err
+		}
+	}
+
+	// FOR UPDATE segura novas matrículas até a troca de sala
+	var locked uuid.UUID
+	err = tx.Get(&locked, `
+		SELECT id FROM classes
+		WHERE id = $1 AND deleted_at IS NULL AND status = 'scheduled'
+		FOR UPDATE
+	`, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrNotFound
+		}
+		return fmt.Errorf("failed to lock class: %w", err)
+	}
+
+	if roomID != nil {
+		var conflicts int
+		err = tx.Get(&conflicts, `
+			SELECT COUNT(*)
+			FROM classes c
+			INNER JOIN classes target ON target.id = $2
+			WHERE c.room_id = $1
+			  AND c.id != target.id
+			  AND c.scheduled_date = target.scheduled_date
+			  AND c.deleted_at IS NULL
+			  AND c.status != 'cancelled'
+			  AND c.start_time < target.end_time AND c.end_time > target.start_time
+		`, *roomID, id)
+		if err != nil {
+			return fmt.Errorf("failed to check room availability: %w", err)
+		}
+		if conflicts > 0 {
+			return ErrRoomUnavailable
+		}
+
+		taken, err := takenSeats(tx, id)
+		if err != nil {
+			return err
+		}
+		if taken > capacity {
+			return ErrSlotFull
+		}
+	}
+
+	_, err = tx.Exec(`UPDATE classes SET room_id = $1, updated_at = $2 WHERE id = $3`, roomID, time.Now(), id)
+	if err != nil {
+		return fmt.Errorf("failed to assign room: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit room assignment: %w", err)
+	}
+
+	return nil
+}
+
+// GetByRoom retorna as aulas não canceladas que ocupam a sala no período
+func (r *classRepository) GetByRoom(roomID uuid.UUID, startDate, endDate time.Time) ([]domain.Class, error) {
+	query := `
+		SELECT ` + classColumns + `
+		FROM classes
+		WHERE room_id = $1
+		  AND scheduled_date >= $2
+		  AND scheduled_date <= $3
+		  AND deleted_at IS NULL
 		  AND status != 'cancelled'
-		  AND (
-		    (start_time < $4 AND end_time > $3) OR
-		    (start_time >= $3 AND start_time < $4)
-		  )
+		ORDER BY scheduled_date, start_time
+	`
+
+	var classes []domain.Class
+	err := r.db.Select(&classes, query, roomID, startDate, endDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get classes by room: %w", err)
+	}
+
+	return classes, nil
+}
+
+// CheckTeacherAvailability verifica se o professor está livre no intervalo e fora
+// de períodos de indisponibilidade. excludeClassID ignora a própria aula ao remarcá-la.
+func (r *classRepository) CheckTeacherAvailability(teacherID uuid.UUID, date time.Time, startTime, endTime string, excludeClassID *uuid.UUID) (bool, error) {
+	query := `
+		SELECT (
+		  SELECT COUNT(*) FROM classes
+		  WHERE teacher_id = $1
+		    AND scheduled_date = $2
+		    AND deleted_at IS NULL
+		    AND status != 'cancelled'
+		    AND ($5::uuid IS NULL OR id != $5::uuid)
+		    AND (
+		      (start_time < $4 AND end_time > $3) OR
+		      (start_time >= $3 AND start_time < $4)
+		    )
+		) + (
+		  SELECT COUNT(*) FROM teacher_blackouts
+		  WHERE teacher_id = $1
+		    AND start_date <= $2
+		    AND end_date >= $2
+		)
 	`
 
 	var count int
-	err := r.db.Get(&count, query, teacherID, date, startTime, endTime)
+	err := r.db.Get(&count, query, teacherID, date, startTime, endTime, excludeClassID)
 	if err != nil {
 		return false, fmt.Errorf("failed to check availability: %w", err)
 	}
 
 	return count == 0, nil
 }
+
+// GetStudentOverlaps retorna as aulas agendadas do aluno que colidem com o intervalo
+func (r *classRepository) GetStudentOverlaps(studentID uuid.UUID, date time.Time, startTime, endTime string, excludeClassID *uuid.UUID) ([]domain.Class, error) {
+	query := `
+		SELECT ` + prefixedClassColumns + `
+		FROM classes c
+		INNER JOIN class_students cs ON cs.class_id = c.id
+		WHERE cs.student_id = $1
+		  AND cs.cancelled_at IS NULL
+		  AND c.scheduled_date = $2
+		  AND c.deleted_at IS NULL
+		  AND c.status != 'cancelled'
+		  AND ($5::uuid IS NULL OR c.id != $5::uuid)
+		  AND c.start_time < $4 AND c.end_time > $3
+		ORDER BY c.start_time
+	`
+
+	var classes []domain.Class
+	err := r.db.Select(&classes, query, studentID, date, startTime, endTime, excludeClassID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get student overlaps: %w", err)
+	}
+
+	return classes, nil
+}
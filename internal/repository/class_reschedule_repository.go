@@ -0,0 +1,328 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hscHeric/go-potential-api/internal/domain"
+	"github.com/jmoiron/sqlx"
+)
+
+type ClassRescheduleRepository interface {
+	Create(reschedule *domain.ClassReschedule) error
+	GetPendingByClass(classID uuid.UUID) (*domain.ClassReschedule, error)
+	GetByClass(classID uuid.UUID) ([]domain.ClassReschedule, error)
+	UpdateStatus(id uuid.UUID, status domain.RescheduleStatus) error
+	SaveResponse(response *domain.RescheduleResponse) error
+	GetResponses(rescheduleID uuid.UUID) ([]domain.RescheduleResponse, error)
+	Apply(reschedule *domain.ClassReschedule, startsAt, endsAt time.Time) error
+}
+
+const rescheduleColumns = `id, class_id, proposed_by, previous_date, previous_start_time, previous_end_time,
+		       new_date, new_start_time, new_end_time, reason, status, created_at, resolved_at`
+
+type classRescheduleRepository struct {
+	db *sqlx.DB
+}
+
+func NewClassRescheduleRepository(db *sqlx.DB) ClassRescheduleRepository {
+	return &classRescheduleRepository{db: db}
+}
+
+// Create grava a proposta; uma proposta pendente anterior da mesma aula é cancelada
+func (r *classRescheduleRepository) Create(reschedule *domain.ClassReschedule) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	// Rollback não tem efeito após o Commit
+	defer tx.Rollback()
+
+	now := time.Now()
+
+	_, err = tx.Exec(`
+		UPDATE class_reschedules
+		SET status = 'cancelled', resolved_at = $1
+		WHERE class_id = $2 AND status = 'pending'
+	`, now, reschedule.ClassID)
+	if err != nil {
+		return fmt.Errorf("failed to cancel previous reschedule: %w", err)
+	}
+
+	reschedule.ID = uuid.New()
+	reschedule.CreatedAt = now
+
+	_, err = tx.Exec(`
+		INSERT INTO class_reschedules (
+			id, class_id, proposed_by, previous_date, previous_start_time, previous_end_time,
+			new_date, new_start_time, new_end_time, reason, status, created_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+	`,
+		reschedule.ID,
+		reschedule.ClassID,
+		reschedule.ProposedBy,
+		reschedule.PreviousDate,
+		reschedule.PreviousStartTime,
+		reschedule.PreviousEndTime,
+		reschedule.NewDate,
+		reschedule.NewStartTime,
+		reschedule.NewEndTime,
+		reschedule.Reason,
+		reschedule.Status,
+		reschedule.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create reschedule: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit reschedule: %w", err)
+	}
+
+	return nil
+}
+
+func (r *classRescheduleRepository) GetPendingByClass(classID uuid.UUID) (*domain.ClassReschedule, error) {
+	query := `
+		SELECT ` + rescheduleColumns + `
+		FROM class_reschedules
+		WHERE class_id = $1 AND status = 'pending'
+	`
+
+	var reschedule domain.ClassReschedule
+	err := r.db.Get(&reschedule, query, classID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get pending reschedule: %w", err)
+	}
+
+	return &reschedule, nil
+}
+
+// GetByClass retorna o histórico de remarcações da aula, da mais recente para a mais antiga
+func (r *classRescheduleRepository) GetByClass(classID uuid.UUID) ([]domain.ClassReschedule, error) {
+	query := `
+		SELECT ` + rescheduleColumns + `
+		FROM class_reschedules
+		WHERE class_id = $1
+		ORDER BY created_at DESC
+	`
+
+	var reschedules []domain.ClassReschedule
+	err := r.db.Select(&reschedules, query, classID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reschedules: %w", err)
+	}
+
+	return reschedules, nil
+}
+
+func (r *classRescheduleRepository) UpdateStatus(id uuid.UUID, status domain.RescheduleStatus) error {
+	query := `
+		UPDATE class_reschedules
+		SET status = $1, resolved_at = $2
+		WHERE id = $3
+	`
+
+	result, err := r.db.Exec(query, status, time.Now(), id)
+	if err != nil {
+		return fmt.Errorf("failed to update reschedule status: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// SaveResponse grava a resposta do aluno; responder de novo substitui a anterior
+func (r *classRescheduleRepository) SaveResponse(response *domain.RescheduleResponse) error {
+	query := `
+		INSERT INTO class_reschedule_responses (reschedule_id, student_id, accepted, responded_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (reschedule_id, student_id) DO UPDATE
+		SET accepted = EXCLUDED.accepted, responded_at = EXCLUDED.responded_at
+	`
+
+	response.RespondedAt = time.Now()
+
+	_, err := r.db.Exec(query, response.RescheduleID, response.StudentID, response.Accepted, response.RespondedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save reschedule response: %w", err)
+	}
+
+	return nil
+}
+
+func (r *classRescheduleRepository) GetResponses(rescheduleID uuid.UUID) ([]domain.RescheduleResponse, error) {
+	query := `
+		SELECT reschedule_id, student_id, accepted, responded_at
+		FROM class_reschedule_responses
+		WHERE reschedule_id = $1
+		ORDER BY responded_at
+	`
+
+	var responses []domain.RescheduleResponse
+	err := r.db.Select(&responses, query, rescheduleID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reschedule responses: %w", err)
+	}
+
+	return responses, nil
+}
+
+// Apply move a aula para o horário proposto e marca a proposta como aplicada em uma única
+// transação. O horário de origem da aula, os horários do professor que cobrem o novo
+// intervalo e a sala são bloqueados com FOR UPDATE antes de conferir professor, sala e
+// alunos, então uma reserva simultânea não ocupa o novo horário entre a conferência e a
+// gravação. Se a nova data ou intervalo não coincidir mais com o horário de origem, a aula
+// é desligada dele (time_slot_id NULL) e a ocorrência original volta a aceitar reservas.
+func (r *classRescheduleRepository) Apply(reschedule *domain.ClassReschedule, startsAt, endsAt time.Time) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var status domain.RescheduleStatus
+	err = tx.Get(&status, `SELECT status FROM class_reschedules WHERE id = $1 FOR UPDATE`, reschedule.ID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrNotFound
+		}
+		return fmt.Errorf("failed to lock reschedule: %w", err)
+	}
+	if status != domain.RescheduleStatusPending {
+		return ErrNotFound
+	}
+
+	// FOR NO KEY UPDATE não bloqueia as matrículas (FK em class_students) que já seguram o horário
+	var class domain.Class
+	err = tx.Get(&class, `
+		SELECT `+classColumns+`
+		FROM classes
+		WHERE id = $1 AND deleted_at IS NULL AND status = 'scheduled'
+		FOR NO KEY UPDATE
+	`, reschedule.ClassID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrClassUnavailable
+		}
+		return fmt.Errorf("failed to lock class: %w", err)
+	}
+
+	date, startTime, endTime := reschedule.NewDate, reschedule.NewStartTime, reschedule.NewEndTime
+
+	var slotIDs []uuid.UUID
+	err = tx.Select(&slotIDs, `
+		SELECT id FROM time_slots
+		WHERE id = $1
+		   OR (teacher_id = $2
+		       AND day_of_week = EXTRACT(DOW FROM $3::date)
+		       AND deleted_at IS NULL
+		       AND start_time < $5::time AND end_time > $4::time)
+		ORDER BY id
+		FOR UPDATE
+	`, class.TimeSlotID, class.TeacherID, date, startTime, endTime)
+	if err != nil {
+		return fmt.Errorf("failed to lock time slots: %w", err)
+	}
+
+	var conflicts int
+	err = tx.Get(&conflicts, `
+		SELECT (
+		  SELECT COUNT(*) FROM classes
+		  WHERE teacher_id = $1
+		    AND scheduled_date = $2
+		    AND deleted_at IS NULL
+		    AND status != 'cancelled'
+		    AND id != $5
+		    AND start_time < $4::time AND end_time > $3::time
+		) + (
+		  SELECT COUNT(*) FROM teacher_blackouts
+		  WHERE teacher_id = $1 AND start_date <= $2 AND end_date >= $2
+		)
+	`, class.TeacherID, date, startTime, endTime, class.ID)
+	if err != nil {
+		return fmt.Errorf("failed to check availability: %w", err)
+	}
+	if conflicts > 0 {
+		return ErrTeacherUnavailable
+	}
+
+	if class.RoomID != nil {
+		if _, err := lockRoom(tx, *class.RoomID); err != nil {
+			return err
+		}
+		if err := checkRoomFree(tx, *class.RoomID, date, startTime, endTime, &class.ID); err != nil {
+			return err
+		}
+	}
+
+	err = tx.Get(&conflicts, `
+		SELECT COUNT(*)
+		FROM class_students mine
+		INNER JOIN class_students cs ON cs.student_id = mine.student_id AND cs.cancelled_at IS NULL
+		INNER JOIN classes c ON c.id = cs.class_id
+		WHERE mine.class_id = $1
+		  AND mine.cancelled_at IS NULL
+		  AND c.id != $1
+		  AND c.scheduled_date = $2
+		  AND c.deleted_at IS NULL
+		  AND c.status != 'cancelled'
+		  AND c.start_time < $4::time AND c.end_time > $3::time
+	`, class.ID, date, startTime, endTime)
+	if err != nil {
+		return fmt.Errorf("failed to check student availability: %w", err)
+	}
+	if conflicts > 0 {
+		return ErrStudentUnavailable
+	}
+
+	now := time.Now()
+
+	_, err = tx.Exec(`
+		UPDATE classes c
+		SET scheduled_date = $1, start_time = $2, end_time = $3, starts_at = $4, ends_at = $5, updated_at = $6,
+		    time_slot_id = (
+		      SELECT ts.id FROM time_slots ts
+		      WHERE ts.id = c.time_slot_id
+		        AND ts.day_of_week = EXTRACT(DOW FROM $1::date)
+		        AND ts.start_time = $2::time AND ts.end_time = $3::time
+		    )
+		WHERE c.id = $7
+	`, date, startTime, endTime, startsAt, endsAt, now, class.ID)
+	if err != nil {
+		return fmt.Errorf("failed to reschedule class: %w", err)
+	}
+
+	_, err = tx.Exec(`
+		UPDATE class_reschedules
+		SET status = $1, resolved_at = $2
+		WHERE id = $3
+	`, domain.RescheduleStatusApplied, now, reschedule.ID)
+	if err != nil {
+		return fmt.Errorf("failed to update reschedule status: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit reschedule: %w", err)
+	}
+
+	reschedule.Status = domain.RescheduleStatusApplied
+	reschedule.ResolvedAt = &now
+
+	return nil
+}
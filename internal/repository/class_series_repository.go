@@ -0,0 +1,161 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hscHeric/go-potential-api/internal/domain"
+	"github.com/jmoiron/sqlx"
+)
+
+type ClassSeriesRepository interface {
+	Create(series *domain.ClassSeries) error
+	GetByID(id uuid.UUID) (*domain.ClassSeries, error)
+	Update(series *domain.ClassSeries) error
+}
+
+type classSeriesRepository struct {
+	db *sqlx.DB
+}
+
+func NewClassSeriesRepository(db *sqlx.DB) ClassSeriesRepository {
+	return &classSeriesRepository{db: db}
+}
+
+func (r *classSeriesRepository) Create(series *domain.ClassSeries) error {
+	query := `
+		INSERT INTO class_series (
+			id, teacher_id, days_of_week, start_date, until_date, occurrences, start_time, end_time,
+			title, description, class_link, language, level, exclude_dates, created_by, created_at, updated_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)
+	`
+
+	series.ID = uuid.New()
+	series.CreatedAt = time.Now()
+	series.UpdatedAt = time.Now()
+
+	daysJSON, err := json.Marshal(series.DaysOfWeek)
+	if err != nil {
+		return fmt.Errorf("failed to marshal days of week: %w", err)
+	}
+
+	excludeJSON, err := json.Marshal(series.ExcludeDates)
+	if err != nil {
+		return fmt.Errorf("failed to marshal exclude dates: %w", err)
+	}
+
+	_, err = r.db.Exec(
+		query,
+		series.ID,
+		series.TeacherID,
+		daysJSON,
+		series.StartDate,
+		series.UntilDate,
+		series.Occurrences,
+		series.StartTime,
+		series.EndTime,
+		series.Title,
+		series.Description,
+		series.ClassLink,
+		series.Language,
+		series.Level,
+		excludeJSON,
+		series.CreatedBy,
+		series.CreatedAt,
+		series.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create class series: %w", err)
+	}
+
+	return nil
+}
+
+func (r *classSeriesRepository) GetByID(id uuid.UUID) (*domain.ClassSeries, error) {
+	query := `
+		SELECT id, teacher_id, days_of_week, start_date, until_date, occurrences, start_time, end_time,
+		       title, description, class_link, language, level, exclude_dates, created_by, created_at, updated_at
+		FROM class_series
+		WHERE id = $1
+	`
+
+	var series domain.ClassSeries
+	var daysJSON, excludeJSON []byte
+
+	err := r.db.QueryRow(query, id).Scan(
+		&series.ID,
+		&series.TeacherID,
+		&daysJSON,
+		&series.StartDate,
+		&series.UntilDate,
+		&series.Occurrences,
+		&series.StartTime,
+		&series.EndTime,
+		&series.Title,
+		&series.Description,
+		&series.ClassLink,
+		&series.Language,
+		&series.Level,
+		&excludeJSON,
+		&series.CreatedBy,
+		&series.CreatedAt,
+		&series.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get class series: %w", err)
+	}
+
+	if err := json.Unmarshal(daysJSON, &series.DaysOfWeek); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal days of week: %w", err)
+	}
+
+	if err := json.Unmarshal(excludeJSON, &series.ExcludeDates); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal exclude dates: %w", err)
+	}
+
+	return &series, nil
+}
+
+// Update altera os dados herdados pelas aulas da série (o padrão de datas não muda)
+func (r *classSeriesRepository) Update(series *domain.ClassSeries) error {
+	query := `
+		UPDATE class_series
+		SET title = $1, description = $2, class_link = $3, language = $4, level = $5, updated_at = $6
+		WHERE id = $7
+	`
+
+	series.UpdatedAt = time.Now()
+
+	result, err := r.db.Exec(
+		query,
+		series.Title,
+		series.Description,
+		series.ClassLink,
+		series.Language,
+		series.Level,
+		series.UpdatedAt,
+		series.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update class series: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
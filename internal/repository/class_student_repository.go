@@ -1,6 +1,8 @@
 package repository
 
 import (
+	"database/sql"
+	"errors"
 	"fmt"
 	"time"
 
@@ -17,8 +19,12 @@ type ClassStudentRepository interface {
 	CountStudentsInClass(classID uuid.UUID) (int, error)
 	MarkAttendance(classID, studentID uuid.UUID, attended bool) error
 	IsStudentInClass(classID, studentID uuid.UUID) (bool, error)
+	GetEnrollment(classID, studentID uuid.UUID) (*domain.ClassStudent, error)
+	CancelParticipation(classID, studentID, cancelledBy uuid.UUID, outcome domain.CancellationOutcome) (*domain.ClassStudent, error)
 }
 
+const classStudentColumns = `id, class_id, student_id, added_by, attended, created_at, cancelled_at, cancelled_by, cancellation_outcome`
+
 type classStudentRepository struct {
 	db *sqlx.DB
 }
@@ -31,7 +37,10 @@ func (r *classStudentRepository) AddStudent(classStudent *domain.ClassStudent) e
 	query := `
 		INSERT INTO class_students (id, class_id, student_id, added_by, created_at)
 		VALUES ($1, $2, $3, $4, $5)
-		ON CONFLICT (class_id, student_id) DO NOTHING
+		ON CONFLICT (class_id, student_id) DO UPDATE
+		SET added_by = EXCLUDED.added_by, attended = NULL, created_at = EXCLUDED.created_at,
+		    cancelled_at = NULL, cancelled_by = NULL, cancellation_outcome = NULL
+		WHERE class_students.cancelled_at IS NOT NULL
 	`
 
 	classStudent.ID = uuid.New()
@@ -73,7 +82,7 @@ func (r *classStudentRepository) RemoveStudent(classID, studentID uuid.UUID) err
 }
 
 func (r *classStudentRepository) GetStudentsByClass(classID uuid.UUID) ([]uuid.UUID, error) {
-	query := `SELECT student_id FROM class_students WHERE class_id = $1`
+	query := `SELECT student_id FROM class_students WHERE class_id = $1 AND cancelled_at IS NULL`
 
 	var studentIDs []uuid.UUID
 	err := r.db.Select(&studentIDs, query, classID)
@@ -85,7 +94,7 @@ func (r *classStudentRepository) GetStudentsByClass(classID uuid.UUID) ([]uuid.U
 }
 
 func (r *classStudentRepository) GetClassesByStudent(studentID uuid.UUID) ([]uuid.UUID, error) {
-	query := `SELECT class_id FROM class_students WHERE student_id = $1`
+	query := `SELECT class_id FROM class_students WHERE student_id = $1 AND cancelled_at IS NULL`
 
 	var classIDs []uuid.UUID
 	err := r.db.Select(&classIDs, query, studentID)
@@ -97,7 +106,7 @@ func (r *classStudentRepository) GetClassesByStudent(studentID uuid.UUID) ([]uui
 }
 
 func (r *classStudentRepository) CountStudentsInClass(classID uuid.UUID) (int, error) {
-	query := `SELECT COUNT(*) FROM class_students WHERE class_id = $1`
+	query := `SELECT COUNT(*) FROM class_students WHERE class_id = $1 AND cancelled_at IS NULL`
 
 	var count int
 	err := r.db.Get(&count, query, classID)
@@ -112,7 +121,7 @@ func (r *classStudentRepository) MarkAttendance(classID, studentID uuid.UUID, at
 	query := `
 		UPDATE class_students
 		SET attended = $1
-		WHERE class_id = $2 AND student_id = $3
+		WHERE class_id = $2 AND student_id = $3 AND cancelled_at IS NULL
 	`
 
 	result, err := r.db.Exec(query, attended, classID, studentID)
@@ -133,7 +142,7 @@ func (r *classStudentRepository) MarkAttendance(classID, studentID uuid.UUID, at
 }
 
 func (r *classStudentRepository) IsStudentInClass(classID, studentID uuid.UUID) (bool, error) {
-	query := `SELECT EXISTS(SELECT 1 FROM class_students WHERE class_id = $1 AND student_id = $2)`
+	query := `SELECT EXISTS(SELECT 1 FROM class_students WHERE class_id = $1 AND student_id = $2 AND cancelled_at IS NULL)`
 
 	var exists bool
 	err := r.db.Get(&exists, query, classID, studentID)
@@ -143,3 +152,43 @@ func (r *classStudentRepository) IsStudentInClass(classID, studentID uuid.UUID)
 
 	return exists, nil
 }
+
+// GetEnrollment retorna a matrícula do aluno na aula, inclusive se cancelada
+func (r *classStudentRepository) GetEnrollment(classID, studentID uuid.UUID) (*domain.ClassStudent, error) {
+	query := `SELECT ` + classStudentColumns + ` FROM class_students WHERE class_id = $1 AND student_id = $2`
+
+	var classStudent domain.ClassStudent
+	err := r.db.Get(&classStudent, query, classID, studentID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get enrollment: %w", err)
+	}
+
+	return &classStudent, nil
+}
+
+// CancelParticipation marca a participação do aluno como cancelada, guardando a decisão
+// da política. Uma falta (no_show) também é registrada na presença.
+func (r *classStudentRepository) CancelParticipation(classID, studentID, cancelledBy uuid.UUID, outcome domain.CancellationOutcome) (*domain.ClassStudent, error) {
+	query := `
+		UPDATE class_students
+		SET cancelled_at = $1,
+		    cancelled_by = $2,
+		    cancellation_outcome = $3,
+		    attended = CASE WHEN $3 = 'no_show' THEN false ELSE attended END
+		WHERE class_id = $4 AND student_id = $5 AND cancelled_at IS NULL
+		RETURNING ` + classStudentColumns
+
+	var classStudent domain.ClassStudent
+	err := r.db.Get(&classStudent, query, time.Now(), cancelledBy, outcome, classID, studentID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to cancel participation: %w", err)
+	}
+
+	return &classStudent, nil
+}
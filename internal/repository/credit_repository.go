@@ -0,0 +1,337 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hscHeric/go-potential-api/internal/domain"
+	"github.com/jmoiron/sqlx"
+)
+
+type CreditRepository interface {
+	CreatePackage(pkg *domain.CreditPackage) error
+	GetPackagesByStudent(studentID uuid.UUID) ([]domain.CreditPackage, error)
+	GetLedger(studentID uuid.UUID) ([]domain.CreditEntry, error)
+	Debit(studentID, classID uuid.UUID, now time.Time) (*domain.CreditEntry, error)
+	Refund(studentID, classID uuid.UUID) (*domain.CreditEntry, error)
+	GrantMakeup(studentID, classID uuid.UUID, name string, expiresAt time.Time) (*domain.CreditPackage, error)
+}
+
+// O saldo de cada lote é calculado a partir do extrato
+const creditPackageColumns = `p.id, p.student_id, p.kind, p.name, p.credits,
+	COALESCE((SELECT SUM(l.amount) FROM credit_ledger l WHERE l.package_id = p.id), 0) AS remaining,
+	p.expires_at, p.class_id, p.created_by, p.created_at, p.updated_at`
+
+const creditEntryColumns = `id, package_id, student_id, class_id, entry_type, amount, created_at`
+
+type creditRepository struct {
+	db *sqlx.DB
+}
+
+func NewCreditRepository(db *sqlx.DB) CreditRepository {
+	return &creditRepository{db: db}
+}
+
+// CreatePackage cria o lote e o lançamento com os créditos concedidos
+func (r *creditRepository) CreatePackage(pkg *domain.CreditPackage) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	// Rollback não tem efeito após o Commit
+	defer tx.Rollback()
+
+	if err := insertPackage(tx, pkg); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit credit package: %w", err)
+	}
+
+	return nil
+}
+
+func (r *creditRepository) GetPackagesByStudent(studentID uuid.UUID) ([]domain.CreditPackage, error) {
+	query := `
+		SELECT ` + creditPackageColumns + `
+		FROM credit_packages p
+		WHERE p.student_id = $1
+		ORDER BY p.expires_at ASC NULLS LAST, p.created_at
+	`
+
+	var packages []domain.CreditPackage
+	err := r.db.Select(&packages, query, studentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get credit packages: %w", err)
+	}
+
+	return packages, nil
+}
+
+func (r *creditRepository) GetLedger(studentID uuid.UUID) ([]domain.CreditEntry, error) {
+	query := `
+		SELECT ` + creditEntryColumns + `
+		FROM credit_ledger
+		WHERE student_id = $1
+		ORDER BY created_at DESC
+	`
+
+	var entries []domain.CreditEntry
+	err := r.db.Select(&entries, query, studentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get credit ledger: %w", err)
+	}
+
+	return entries, nil
+}
+
+// Debit debita um crédito do aluno pela aula, usando o lote válido que vence primeiro.
+// Retorna nil se a aula já está debitada e ErrNoCredits se não há saldo.
+func (r *creditRepository) Debit(studentID, classID uuid.UUID, now time.Time) (*domain.CreditEntry, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	entry, err := debitCredit(tx, studentID, classID, now)
+	if err != nil || entry == nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit debit: %w", err)
+	}
+
+	return entry, nil
+}
+
+// debitCredit faz o débito de Debit dentro de uma transação em andamento, para que a
+// matrícula e o débito sejam gravados (ou desfeitos) juntos
+func debitCredit(tx *sqlx.Tx, studentID, classID uuid.UUID, now time.Time) (*domain.CreditEntry, error) {
+	if err := lockStudentCredits(tx, studentID); err != nil {
+		return nil, err
+	}
+
+	charged, err := classCharged(tx, studentID, classID)
+	if err != nil {
+		return nil, err
+	}
+	if charged {
+		return nil, nil
+	}
+
+	var packageID uuid.UUID
+	err = tx.Get(&packageID, `
+		SELECT p.id
+		FROM credit_packages p
+		LEFT JOIN credit_ledger l ON l.package_id = p.id
+		WHERE p.student_id = $1
+		  AND (p.expires_at IS NULL OR p.expires_at > $2)
+		GROUP BY p.id
+		HAVING COALESCE(SUM(l.amount), 0) > 0
+		ORDER BY p.expires_at ASC NULLS LAST, p.created_at
+		LIMIT 1
+	`, studentID, now)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNoCredits
+		}
+		return nil, fmt.Errorf("failed to find credit package: %w", err)
+	}
+
+	return insertEntry(tx, packageID, studentID, &classID, domain.CreditEntryDebit, -1)
+}
+
+// Refund devolve o crédito debitado pela aula ao mesmo lote. Retorna nil se a aula
+// não está debitada (nunca foi ou já foi estornada).
+func (r *creditRepository) Refund(studentID, classID uuid.UUID) (*domain.CreditEntry, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := lockStudentCredits(tx, studentID); err != nil {
+		return nil, err
+	}
+
+	packageID, err := chargedPackage(tx, studentID, classID)
+	if err != nil || packageID == nil {
+		return nil, err
+	}
+
+	entry, err := insertEntry(tx, *packageID, studentID, &classID, domain.CreditEntryRefund, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit refund: %w", err)
+	}
+
+	return entry, nil
+}
+
+// GrantMakeup concede um crédito de reposição pela aula debitada. Retorna nil se a aula
+// não estava debitada ou se a reposição já foi concedida.
+func (r *creditRepository) GrantMakeup(studentID, classID uuid.UUID, name string, expiresAt time.Time) (*domain.CreditPackage, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := lockStudentCredits(tx, studentID); err != nil {
+		return nil, err
+	}
+
+	packageID, err := chargedPackage(tx, studentID, classID)
+	if err != nil || packageID == nil {
+		return nil, err
+	}
+
+	pkg := &domain.CreditPackage{
+		StudentID: studentID,
+		Kind:      domain.CreditPackageKindMakeup,
+		Name:      name,
+		Credits:   1,
+		ExpiresAt: &expiresAt,
+		ClassID:   &classID,
+	}
+
+	if err := insertPackage(tx, pkg); err != nil {
+		if errors.Is(err, ErrAlreadyExists) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit makeup credit: %w", err)
+	}
+
+	return pkg, nil
+}
+
+// insertPackage grava o lote e o lançamento inicial com os seus créditos
+func insertPackage(tx *sqlx.Tx, pkg *domain.CreditPackage) error {
+	pkg.ID = uuid.New()
+	pkg.CreatedAt = time.Now()
+	pkg.UpdatedAt = time.Now()
+
+	_, err := tx.Exec(`
+		INSERT INTO credit_packages (id, student_id, kind, name, credits, expires_at, class_id, created_by, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	`,
+		pkg.ID,
+		pkg.StudentID,
+		pkg.Kind,
+		pkg.Name,
+		pkg.Credits,
+		pkg.ExpiresAt,
+		pkg.ClassID,
+		pkg.CreatedBy,
+		pkg.CreatedAt,
+		pkg.UpdatedAt,
+	)
+	if err != nil {
+		if IsDuplicateKeyError(err) {
+			return ErrAlreadyExists
+		}
+		return fmt.Errorf("failed to create credit package: %w", err)
+	}
+
+	entryType := domain.CreditEntryPurchase
+	if pkg.Kind == domain.CreditPackageKindMakeup {
+		entryType = domain.CreditEntryMakeup
+	}
+
+	if _, err := insertEntry(tx, pkg.ID, pkg.StudentID, pkg.ClassID, entryType, pkg.Credits); err != nil {
+		return err
+	}
+	pkg.Remaining = pkg.Credits
+
+	return nil
+}
+
+func insertEntry(tx *sqlx.Tx, packageID, studentID uuid.UUID, classID *uuid.UUID, entryType domain.CreditEntryType, amount int) (*domain.CreditEntry, error) {
+	entry := &domain.CreditEntry{
+		ID:        uuid.New(),
+		PackageID: packageID,
+		StudentID: studentID,
+		ClassID:   classID,
+		EntryType: entryType,
+		Amount:    amount,
+		CreatedAt: time.Now(),
+	}
+
+	_, err := tx.Exec(`
+		INSERT INTO credit_ledger (id, package_id, student_id, class_id, entry_type, amount, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`,
+		entry.ID,
+		entry.PackageID,
+		entry.StudentID,
+		entry.ClassID,
+		entry.EntryType,
+		entry.Amount,
+		entry.CreatedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create credit entry: %w", err)
+	}
+
+	return entry, nil
+}
+
+// lockStudentCredits serializa as movimentações de créditos do mesmo aluno
+func lockStudentCredits(tx *sqlx.Tx, studentID uuid.UUID) error {
+	_, err := tx.Exec(`SELECT id FROM credit_packages WHERE student_id = $1 FOR UPDATE`, studentID)
+	if err != nil {
+		return fmt.Errorf("failed to lock credit packages: %w", err)
+	}
+
+	return nil
+}
+
+// classCharged informa se a aula está debitada do aluno (débito sem estorno)
+func classCharged(tx *sqlx.Tx, studentID, classID uuid.UUID) (bool, error) {
+	var net int
+	err := tx.Get(&net, `
+		SELECT COALESCE(SUM(amount), 0)
+		FROM credit_ledger
+		WHERE student_id = $1 AND class_id = $2 AND entry_type IN ('debit', 'refund')
+	`, studentID, classID)
+	if err != nil {
+		return false, fmt.Errorf("failed to check class debit: %w", err)
+	}
+
+	return net < 0, nil
+}
+
+// chargedPackage retorna o lote do débito em aberto da aula, ou nil se não houver
+func chargedPackage(tx *sqlx.Tx, studentID, classID uuid.UUID) (*uuid.UUID, error) {
+	charged, err := classCharged(tx, studentID, classID)
+	if err != nil || !charged {
+		return nil, err
+	}
+
+	var packageID uuid.UUID
+	err = tx.Get(&packageID, `
+		SELECT package_id
+		FROM credit_ledger
+		WHERE student_id = $1 AND class_id = $2 AND entry_type = 'debit'
+		ORDER BY created_at DESC
+		LIMIT 1
+	`, studentID, classID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get debited package: %w", err)
+	}
+
+	return &packageID, nil
+}
@@ -12,6 +12,14 @@ var (
 	ErrEmailAlreadyExists = errors.New("email já existe")
 	ErrCPFAlreadyExists   = errors.New("cpf já existe")
 	ErrInvalidData        = errors.New("dados inválidos")
+	ErrSlotFull           = errors.New("horário sem vagas")
+	ErrAlreadyBooked      = errors.New("aluno já matriculado na aula")
+	ErrTeacherUnavailable = errors.New("professor indisponível no horário")
+	ErrOfferExpired       = errors.New("oferta de vaga expirada")
+	ErrClassUnavailable   = errors.New("aula cancelada, excluída ou já iniciada")
+	ErrNoCredits          = errors.New("aluno sem créditos de aula")
+	ErrRoomUnavailable    = errors.New("sala ocupada no horário")
+	ErrStudentUnavailable = errors.New("aluno com outra aula no horário")
 )
 
 // IsDuplicateKeyError verifica se o erro do banco é de chave duplicada
@@ -0,0 +1,176 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hscHeric/go-potential-api/internal/domain"
+	"github.com/jmoiron/sqlx"
+)
+
+type HolidayRepository interface {
+	Create(holiday *domain.Holiday) error
+	CreateIfNotExists(holiday *domain.Holiday) (bool, error)
+	GetByID(id uuid.UUID) (*domain.Holiday, error)
+	GetByDate(date time.Time) (*domain.Holiday, error)
+	GetInRange(from, to time.Time) ([]domain.Holiday, error)
+	Update(holiday *domain.Holiday) error
+	Delete(id uuid.UUID) error
+}
+
+const holidayColumns = `id, date, name, scope, created_by, created_at, updated_at`
+
+type holidayRepository struct {
+	db *sqlx.DB
+}
+
+func NewHolidayRepository(db *sqlx.DB) HolidayRepository {
+	return &holidayRepository{db: db}
+}
+
+func (r *holidayRepository) Create(holiday *domain.Holiday) error {
+	created, err := r.CreateIfNotExists(holiday)
+	if err != nil {
+		return err
+	}
+
+	if !created {
+		return ErrAlreadyExists
+	}
+
+	return nil
+}
+
+// CreateIfNotExists insere o feriado, ignorando se já houver um com a mesma data e nome
+func (r *holidayRepository) CreateIfNotExists(holiday *domain.Holiday) (bool, error) {
+	query := `
+		INSERT INTO holidays (id, date, name, scope, created_by, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (date, name) DO NOTHING
+	`
+
+	holiday.ID = uuid.New()
+	holiday.CreatedAt = time.Now()
+	holiday.UpdatedAt = time.Now()
+
+	result, err := r.db.Exec(
+		query,
+		holiday.ID,
+		holiday.Date,
+		holiday.Name,
+		holiday.Scope,
+		holiday.CreatedBy,
+		holiday.CreatedAt,
+		holiday.UpdatedAt,
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to create holiday: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+func (r *holidayRepository) GetByID(id uuid.UUID) (*domain.Holiday, error) {
+	query := `SELECT ` + holidayColumns + ` FROM holidays WHERE id = $1`
+
+	var holiday domain.Holiday
+	err := r.db.Get(&holiday, query, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get holiday: %w", err)
+	}
+
+	return &holiday, nil
+}
+
+// GetByDate retorna o primeiro feriado cadastrado na data
+func (r *holidayRepository) GetByDate(date time.Time) (*domain.Holiday, error) {
+	query := `SELECT ` + holidayColumns + ` FROM holidays WHERE date = $1 ORDER BY name LIMIT 1`
+
+	var holiday domain.Holiday
+	err := r.db.Get(&holiday, query, date)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get holiday by date: %w", err)
+	}
+
+	return &holiday, nil
+}
+
+func (r *holidayRepository) GetInRange(from, to time.Time) ([]domain.Holiday, error) {
+	query := `
+		SELECT ` + holidayColumns + `
+		FROM holidays
+		WHERE date >= $1 AND date <= $2
+		ORDER BY date, name
+	`
+
+	var holidays []domain.Holiday
+	err := r.db.Select(&holidays, query, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get holidays: %w", err)
+	}
+
+	return holidays, nil
+}
+
+func (r *holidayRepository) Update(holiday *domain.Holiday) error {
+	query := `
+		UPDATE holidays
+		SET date = $1, name = $2, scope = $3, updated_at = $4
+		WHERE id = $5
+	`
+
+	holiday.UpdatedAt = time.Now()
+
+	result, err := r.db.Exec(query, holiday.Date, holiday.Name, holiday.Scope, holiday.UpdatedAt, holiday.ID)
+	if err != nil {
+		if IsDuplicateKeyError(err) {
+			return ErrAlreadyExists
+		}
+		return fmt.Errorf("failed to update holiday: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *holidayRepository) Delete(id uuid.UUID) error {
+	query := `DELETE FROM holidays WHERE id = $1`
+
+	result, err := r.db.Exec(query, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete holiday: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,265 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hscHeric/go-potential-api/internal/domain"
+	"github.com/jmoiron/sqlx"
+)
+
+type RoomRepository interface {
+	Create(room *domain.Room) error
+	GetByID(id uuid.UUID) (*domain.Room, error)
+	ListByBranch(branchID uuid.UUID, includeInactive bool) ([]domain.Room, error)
+	Update(room *domain.Room) error
+	Delete(id uuid.UUID) error
+	CheckAvailability(roomID uuid.UUID, date time.Time, startTime, endTime string, excludeClassID *uuid.UUID) (bool, error)
+	FindAvailable(filter domain.RoomFilter, date time.Time, startTime, endTime string, excludeClassID *uuid.UUID) ([]domain.Room, error)
+}
+
+const roomColumns = `r.id, r.branch_id, r.name, r.capacity, r.equipment, r.is_active, r.created_at, r.updated_at`
+
+type roomRepository struct {
+	db *sqlx.DB
+}
+
+func NewRoomRepository(db *sqlx.DB) RoomRepository {
+	return &roomRepository{db: db}
+}
+
+func (r *roomRepository) Create(room *domain.Room) error {
+	query := `
+		INSERT INTO rooms (id, branch_id, name, capacity, equipment, is_active, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+
+	equipmentJSON, err := marshalEquipment(room.Equipment)
+	if err != nil {
+		return err
+	}
+
+	room.ID = uuid.New()
+	room.CreatedAt = time.Now()
+	room.UpdatedAt = time.Now()
+
+	_, err = r.db.Exec(
+		query,
+		room.ID,
+		room.BranchID,
+		room.Name,
+		room.Capacity,
+		equipmentJSON,
+		room.IsActive,
+		room.CreatedAt,
+		room.UpdatedAt,
+	)
+	if err != nil {
+		if IsDuplicateKeyError(err) {
+			return ErrAlreadyExists
+		}
+		return fmt.Errorf("failed to create room: %w", err)
+	}
+
+	return nil
+}
+
+func (r *roomRepository) GetByID(id uuid.UUID) (*domain.Room, error) {
+	query := `SELECT ` + roomColumns + ` FROM rooms r WHERE r.id = $1`
+
+	room, err := scanRoom(r.db.QueryRow(query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get room: %w", err)
+	}
+
+	return room, nil
+}
+
+func (r *roomRepository) ListByBranch(branchID uuid.UUID, includeInactive bool) ([]domain.Room, error) {
+	query := `
+		SELECT ` + roomColumns + `
+		FROM rooms r
+		WHERE r.branch_id = $1 AND ($2 OR r.is_active = true)
+		ORDER BY r.name
+	`
+
+	return r.selectRooms(query, branchID, includeInactive)
+}
+
+func (r *roomRepository) Update(room *domain.Room) error {
+	query := `
+		UPDATE rooms
+		SET name = $1, capacity = $2, equipment = $3, is_active = $4, updated_at = $5
+		WHERE id = $6
+	`
+
+	equipmentJSON, err := marshalEquipment(room.Equipment)
+	if err != nil {
+		return err
+	}
+
+	room.UpdatedAt = time.Now()
+
+	result, err := r.db.Exec(query, room.Name, room.Capacity, equipmentJSON, room.IsActive, room.UpdatedAt, room.ID)
+	if err != nil {
+		if IsDuplicateKeyError(err) {
+			return ErrAlreadyExists
+		}
+		return fmt.Errorf("failed to update room: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// Delete remove a sala; aulas que a reservaram ficam com room_id NULL (ON DELETE SET NULL)
+func (r *roomRepository) Delete(id uuid.UUID) error {
+	query := `DELETE FROM rooms WHERE id = $1`
+
+	result, err := r.db.Exec(query, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete room: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// CheckAvailability verifica se nenhuma outra aula não cancelada ocupa a sala no intervalo.
+// excludeClassID ignora a própria aula ao remarcá-la.
+func (r *roomRepository) CheckAvailability(roomID uuid.UUID, date time.Time, startTime, endTime string, excludeClassID *uuid.UUID) (bool, error) {
+	query := `
+		SELECT COUNT(*) FROM classes
+		WHERE room_id = $1
+		  AND scheduled_date = $2
+		  AND deleted_at IS NULL
+		  AND status != 'cancelled'
+		  AND ($5::uuid IS NULL OR id != $5::uuid)
+		  AND start_time < $4 AND end_time > $3
+	`
+
+	var count int
+	err := r.db.Get(&count, query, roomID, date, startTime, endTime, excludeClassID)
+	if err != nil {
+		return false, fmt.Errorf("failed to check room availability: %w", err)
+	}
+
+	return count == 0, nil
+}
+
+// FindAvailable lista as salas ativas (de unidades ativas) que atendem ao filtro e estão
+// livres no intervalo, das menores para as maiores
+func (r *roomRepository) FindAvailable(filter domain.RoomFilter, date time.Time, startTime, endTime string, excludeClassID *uuid.UUID) ([]domain.Room, error) {
+	// O operador @> do JSONB verifica se a sala tem todos os equipamentos pedidos
+	query := `
+		SELECT ` + roomColumns + `
+		FROM rooms r
+		INNER JOIN branches b ON b.id = r.branch_id
+		WHERE r.is_active = true
+		  AND b.is_active = true
+		  AND ($1::uuid IS NULL OR r.branch_id = $1::uuid)
+		  AND r.capacity >= $2
+		  AND r.equipment @> $3::jsonb
+		  AND NOT EXISTS (
+		    SELECT 1 FROM classes c
+		    WHERE c.room_id = r.id
+		      AND c.scheduled_date = $4
+		      AND c.deleted_at IS NULL
+		      AND c.status != 'cancelled'
+		      AND ($7::uuid IS NULL OR c.id != $7::uuid)
+		      AND c.start_time < $6 AND c.end_time > $5
+		  )
+		ORDER BY r.capacity, b.name, r.name
+	`
+
+	equipmentJSON, err := marshalEquipment(filter.Equipment)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.selectRooms(query, filter.BranchID, filter.MinCapacity, equipmentJSON, date, startTime, endTime, excludeClassID)
+}
+
+func (r *roomRepository) selectRooms(query string, args ...any) ([]domain.Room, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list rooms: %w", err)
+	}
+
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var rooms []domain.Room
+	for rows.Next() {
+		room, err := scanRoom(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan room: %w", err)
+		}
+		rooms = append(rooms, *room)
+	}
+
+	return rooms, nil
+}
+
+// scanRoom lê uma sala convertendo a lista de equipamentos do JSONB
+func scanRoom(row interface{ Scan(dest ...any) error }) (*domain.Room, error) {
+	var room domain.Room
+	var equipmentJSON []byte
+
+	err := row.Scan(
+		&room.ID,
+		&room.BranchID,
+		&room.Name,
+		&room.Capacity,
+		&equipmentJSON,
+		&room.IsActive,
+		&room.CreatedAt,
+		&room.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(equipmentJSON, &room.Equipment); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal equipment: %w", err)
+	}
+
+	return &room, nil
+}
+
+func marshalEquipment(equipment []string) ([]byte, error) {
+	if equipment == nil {
+		equipment = []string{}
+	}
+
+	equipmentJSON, err := json.Marshal(equipment)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal equipment: %w", err)
+	}
+
+	return equipmentJSON, nil
+}
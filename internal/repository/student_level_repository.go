@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hscHeric/go-potential-api/internal/domain"
+	"github.com/jmoiron/sqlx"
+)
+
+type StudentLevelRepository interface {
+	Create(level *domain.StudentLevel) error
+	GetHistory(studentID uuid.UUID, language string) ([]domain.StudentLevel, error)
+	GetCurrentLevels(studentID uuid.UUID) ([]domain.StudentLevel, error)
+}
+
+type studentLevelRepository struct {
+	db *sqlx.DB
+}
+
+func NewStudentLevelRepository(db *sqlx.DB) StudentLevelRepository {
+	return &studentLevelRepository{db: db}
+}
+
+func (r *studentLevelRepository) Create(level *domain.StudentLevel) error {
+	query := `
+		INSERT INTO student_levels (id, student_id, language, level, assessed_at, assessed_by, notes, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+
+	level.ID = uuid.New()
+	level.CreatedAt = time.Now()
+
+	_, err := r.db.Exec(
+		query,
+		level.ID,
+		level.StudentID,
+		level.Language,
+		level.Level,
+		level.AssessedAt,
+		level.AssessedBy,
+		level.Notes,
+		level.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create student level: %w", err)
+	}
+
+	return nil
+}
+
+func (r *studentLevelRepository) GetHistory(studentID uuid.UUID, language string) ([]domain.StudentLevel, error) {
+	query := `
+		SELECT id, student_id, language, level, assessed_at, assessed_by, notes, created_at
+		FROM student_levels
+		WHERE student_id = $1
+		  AND ($2 = '' OR language = $2)
+		ORDER BY language, assessed_at DESC, created_at DESC
+	`
+
+	var levels []domain.StudentLevel
+	err := r.db.Select(&levels, query, studentID, language)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get student level history: %w", err)
+	}
+
+	return levels, nil
+}
+
+func (r *studentLevelRepository) GetCurrentLevels(studentID uuid.UUID) ([]domain.StudentLevel, error) {
+	// A avaliação mais recente de cada idioma é o nível atual
+	query := `
+		SELECT DISTINCT ON (language)
+		       id, student_id, language, level, assessed_at, assessed_by, notes, created_at
+		FROM student_levels
+		WHERE student_id = $1
+		ORDER BY language, assessed_at DESC, created_at DESC
+	`
+
+	var levels []domain.StudentLevel
+	err := r.db.Select(&levels, query, studentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current student levels: %w", err)
+	}
+
+	return levels, nil
+}
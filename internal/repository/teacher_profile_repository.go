@@ -0,0 +1,187 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hscHeric/go-potential-api/internal/domain"
+	"github.com/jmoiron/sqlx"
+)
+
+type TeacherProfileRepository interface {
+	Upsert(profile *domain.TeacherProfile) error
+	GetByTeacherID(teacherID uuid.UUID) (*domain.TeacherProfile, error)
+	List(filter domain.TeacherDirectoryFilter) ([]domain.TeacherDirectoryEntry, error)
+}
+
+type teacherProfileRepository struct {
+	db *sqlx.DB
+}
+
+func NewTeacherProfileRepository(db *sqlx.DB) TeacherProfileRepository {
+	return &teacherProfileRepository{db: db}
+}
+
+func (r *teacherProfileRepository) Upsert(profile *domain.TeacherProfile) error {
+	query := `
+		INSERT INTO teacher_profiles (
+			teacher_id, languages, levels, bio, specialties, video_intro_url, created_at, updated_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (teacher_id) DO UPDATE
+		SET languages = EXCLUDED.languages,
+		    levels = EXCLUDED.levels,
+		    bio = EXCLUDED.bio,
+		    specialties = EXCLUDED.specialties,
+		    video_intro_url = EXCLUDED.video_intro_url,
+		    updated_at = EXCLUDED.updated_at
+		RETURNING created_at
+	`
+
+	now := time.Now()
+	profile.CreatedAt = now
+	profile.UpdatedAt = now
+
+	languagesJSON, err := json.Marshal(profile.Languages)
+	if err != nil {
+		return fmt.Errorf("failed to marshal languages: %w", err)
+	}
+
+	levelsJSON, err := json.Marshal(profile.Levels)
+	if err != nil {
+		return fmt.Errorf("failed to marshal levels: %w", err)
+	}
+
+	specialtiesJSON, err := json.Marshal(profile.Specialties)
+	if err != nil {
+		return fmt.Errorf("failed to marshal specialties: %w", err)
+	}
+
+	err = r.db.QueryRow(
+		query,
+		profile.TeacherID,
+		languagesJSON,
+		levelsJSON,
+		profile.Bio,
+		specialtiesJSON,
+		profile.VideoIntroURL,
+		profile.CreatedAt,
+		profile.UpdatedAt,
+	).Scan(&profile.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to upsert teacher profile: %w", err)
+	}
+
+	return nil
+}
+
+func (r *teacherProfileRepository) GetByTeacherID(teacherID uuid.UUID) (*domain.TeacherProfile, error) {
+	query := `
+		SELECT teacher_id, languages, levels, bio, specialties, video_intro_url, created_at, updated_at
+		FROM teacher_profiles
+		WHERE teacher_id = $1
+	`
+
+	var profile domain.TeacherProfile
+	var languagesJSON, levelsJSON, specialtiesJSON []byte
+
+	err := r.db.QueryRow(query, teacherID).Scan(
+		&profile.TeacherID,
+		&languagesJSON,
+		&levelsJSON,
+		&profile.Bio,
+		&specialtiesJSON,
+		&profile.VideoIntroURL,
+		&profile.CreatedAt,
+		&profile.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get teacher profile: %w", err)
+	}
+
+	if err := unmarshalTeacherProfileLists(&profile, languagesJSON, levelsJSON, specialtiesJSON); err != nil {
+		return nil, err
+	}
+
+	return &profile, nil
+}
+
+func (r *teacherProfileRepository) List(filter domain.TeacherDirectoryFilter) ([]domain.TeacherDirectoryEntry, error) {
+	// Apenas professores ativos e com perfil preenchido aparecem no diretório.
+	// O operador ? do JSONB verifica se a string existe no array.
+	query := `
+		SELECT tp.teacher_id, u.full_name, tp.languages, tp.levels, tp.bio, tp.specialties,
+		       tp.video_intro_url, tp.created_at, tp.updated_at
+		FROM teacher_profiles tp
+		INNER JOIN auth a ON a.id = tp.teacher_id
+		INNER JOIN users u ON u.auth_id = tp.teacher_id
+		WHERE a.role = 'teacher'
+		  AND u.deleted_at IS NULL
+		  AND a.status = 'active'
+		  AND ($1 = '' OR tp.languages ? $1)
+		  AND ($2 = '' OR tp.levels ? $2)
+		ORDER BY u.full_name
+	`
+
+	rows, err := r.db.Query(query, filter.Language, string(filter.Level))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list teacher profiles: %w", err)
+	}
+
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var entries []domain.TeacherDirectoryEntry
+	for rows.Next() {
+		var entry domain.TeacherDirectoryEntry
+		var languagesJSON, levelsJSON, specialtiesJSON []byte
+
+		err := rows.Scan(
+			&entry.Profile.TeacherID,
+			&entry.FullName,
+			&languagesJSON,
+			&levelsJSON,
+			&entry.Profile.Bio,
+			&specialtiesJSON,
+			&entry.Profile.VideoIntroURL,
+			&entry.Profile.CreatedAt,
+			&entry.Profile.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan teacher profile: %w", err)
+		}
+
+		if err := unmarshalTeacherProfileLists(&entry.Profile, languagesJSON, levelsJSON, specialtiesJSON); err != nil {
+			return nil, err
+		}
+
+		entry.TeacherID = entry.Profile.TeacherID
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+func unmarshalTeacherProfileLists(profile *domain.TeacherProfile, languagesJSON, levelsJSON, specialtiesJSON []byte) error {
+	if err := json.Unmarshal(languagesJSON, &profile.Languages); err != nil {
+		return fmt.Errorf("failed to unmarshal languages: %w", err)
+	}
+
+	if err := json.Unmarshal(levelsJSON, &profile.Levels); err != nil {
+		return fmt.Errorf("failed to unmarshal levels: %w", err)
+	}
+
+	if err := json.Unmarshal(specialtiesJSON, &profile.Specialties); err != nil {
+		return fmt.Errorf("failed to unmarshal specialties: %w", err)
+	}
+
+	return nil
+}
@@ -14,13 +14,23 @@ import (
 type TimeSlotRepository interface {
 	Create(slot *domain.TimeSlot) error
 	GetByID(id uuid.UUID) (*domain.TimeSlot, error)
-	GetByTeacher(teacherID uuid.UUID) ([]domain.TimeSlot, error)
-	GetByTeacherAndDay(teacherID uuid.UUID, dayOfWeek domain.DayOfWeek) ([]domain.TimeSlot, error)
+	GetByTeacher(teacherID uuid.UUID, from time.Time) ([]domain.TimeSlot, error)
+	GetByTeacherAndDay(teacherID uuid.UUID, dayOfWeek domain.DayOfWeek, date time.Time) ([]domain.TimeSlot, error)
 	Update(slot *domain.TimeSlot) error
 	Delete(id uuid.UUID) error
+	Restore(id uuid.UUID) error
+	GetDeletedByID(id uuid.UUID) (*domain.TimeSlot, error)
+	GetByIDWithDeleted(id uuid.UUID) (*domain.TimeSlot, error)
+	PurgeDeleted(before time.Time) (int64, error)
 	ToggleAvailability(id uuid.UUID, isAvailable bool) error
+	Supersede(id uuid.UUID, validUntil time.Time, next *domain.TimeSlot) error
+	ReplaceWeekly(added, closed []domain.TimeSlot, deletedIDs []uuid.UUID) error
 }
 
+// timeSlotColumns lista as colunas de time_slots na ordem usada pelos SELECTs
+const timeSlotColumns = `id, teacher_id, day_of_week, start_time, end_time, max_students, is_available,
+		       valid_from, valid_until, created_at, updated_at, deleted_at`
+
 type timeSlotRepository struct {
 	db *sqlx.DB
 }
@@ -30,16 +40,22 @@ func NewTimeSlotRepository(db *sqlx.DB) TimeSlotRepository {
 }
 
 func (r *timeSlotRepository) Create(slot *domain.TimeSlot) error {
+	return insertTimeSlot(r.db, slot)
+}
+
+// insertTimeSlot grava um novo horário (ou nova versão de um horário) com o executor informado
+func insertTimeSlot(db sqlx.Execer, slot *domain.TimeSlot) error {
 	query := `
-		INSERT INTO time_slots (id, teacher_id, day_of_week, start_time, end_time, max_students, is_available, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		INSERT INTO time_slots (id, teacher_id, day_of_week, start_time, end_time, max_students, is_available,
+		                        valid_from, valid_until, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
 	`
 
 	slot.ID = uuid.New()
 	slot.CreatedAt = time.Now()
 	slot.UpdatedAt = time.Now()
 
-	_, err := r.db.Exec(
+	_, err := db.Exec(
 		query,
 		slot.ID,
 		slot.TeacherID,
@@ -48,6 +64,8 @@ func (r *timeSlotRepository) Create(slot *domain.TimeSlot) error {
 		slot.EndTime,
 		slot.MaxStudents,
 		slot.IsAvailable,
+		slot.ValidFrom,
+		slot.ValidUntil,
 		slot.CreatedAt,
 		slot.UpdatedAt,
 	)
@@ -60,9 +78,9 @@ func (r *timeSlotRepository) Create(slot *domain.TimeSlot) error {
 
 func (r *timeSlotRepository) GetByID(id uuid.UUID) (*domain.TimeSlot, error) {
 	query := `
-		SELECT id, teacher_id, day_of_week, start_time, end_time, max_students, is_available, created_at, updated_at
+		SELECT ` + timeSlotColumns + `
 		FROM time_slots
-		WHERE id = $1
+		WHERE id = $1 AND deleted_at IS NULL
 	`
 
 	var slot domain.TimeSlot
@@ -77,16 +95,19 @@ func (r *timeSlotRepository) GetByID(id uuid.UUID) (*domain.TimeSlot, error) {
 	return &slot, nil
 }
 
-func (r *timeSlotRepository) GetByTeacher(teacherID uuid.UUID) ([]domain.TimeSlot, error) {
+// GetByTeacher retorna as versões dos horários do professor que ainda valem a partir de from
+// (vigentes ou futuras). Versões encerradas antes de from ficam de fora.
+func (r *timeSlotRepository) GetByTeacher(teacherID uuid.UUID, from time.Time) ([]domain.TimeSlot, error) {
 	query := `
-		SELECT id, teacher_id, day_of_week, start_time, end_time, max_students, is_available, created_at, updated_at
+		SELECT ` + timeSlotColumns + `
 		FROM time_slots
-		WHERE teacher_id = $1
-		ORDER BY day_of_week, start_time
+		WHERE teacher_id = $1 AND deleted_at IS NULL
+		  AND (valid_until IS NULL OR valid_until >= $2)
+		ORDER BY day_of_week, start_time, valid_from
 	`
 
 	var slots []domain.TimeSlot
-	err := r.db.Select(&slots, query, teacherID)
+	err := r.db.Select(&slots, query, teacherID, from)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get time slots by teacher: %w", err)
 	}
@@ -94,16 +115,19 @@ func (r *timeSlotRepository) GetByTeacher(teacherID uuid.UUID) ([]domain.TimeSlo
 	return slots, nil
 }
 
-func (r *timeSlotRepository) GetByTeacherAndDay(teacherID uuid.UUID, dayOfWeek domain.DayOfWeek) ([]domain.TimeSlot, error) {
+// GetByTeacherAndDay retorna os horários ativos do professor no dia da semana, na versão
+// que vale na data informada
+func (r *timeSlotRepository) GetByTeacherAndDay(teacherID uuid.UUID, dayOfWeek domain.DayOfWeek, date time.Time) ([]domain.TimeSlot, error) {
 	query := `
-		SELECT id, teacher_id, day_of_week, start_time, end_time, max_students, is_available, created_at, updated_at
+		SELECT ` + timeSlotColumns + `
 		FROM time_slots
-		WHERE teacher_id = $1 AND day_of_week = $2 AND is_available = true
+		WHERE teacher_id = $1 AND day_of_week = $2 AND is_available = true AND deleted_at IS NULL
+		  AND valid_from <= $3 AND (valid_until IS NULL OR valid_until >= $3)
 		ORDER BY start_time
 	`
 
 	var slots []domain.TimeSlot
-	err := r.db.Select(&slots, query, teacherID, dayOfWeek)
+	err := r.db.Select(&slots, query, teacherID, dayOfWeek, date)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get time slots by teacher and day: %w", err)
 	}
@@ -114,8 +138,9 @@ func (r *timeSlotRepository) GetByTeacherAndDay(teacherID uuid.UUID, dayOfWeek d
 func (r *timeSlotRepository) Update(slot *domain.TimeSlot) error {
 	query := `
 		UPDATE time_slots
-		SET day_of_week = $1, start_time = $2, end_time = $3, max_students = $4, is_available = $5, updated_at = $6
-		WHERE id = $7
+		SET day_of_week = $1, start_time = $2, end_time = $3, max_students = $4, is_available = $5,
+		    valid_from = $6, valid_until = $7, updated_at = $8
+		WHERE id = $9 AND deleted_at IS NULL
 	`
 
 	slot.UpdatedAt = time.Now()
@@ -127,6 +152,8 @@ func (r *timeSlotRepository) Update(slot *domain.TimeSlot) error {
 		slot.EndTime,
 		slot.MaxStudents,
 		slot.IsAvailable,
+		slot.ValidFrom,
+		slot.ValidUntil,
 		slot.UpdatedAt,
 		slot.ID,
 	)
@@ -146,10 +173,11 @@ func (r *timeSlotRepository) Update(slot *domain.TimeSlot) error {
 	return nil
 }
 
+// Delete faz o soft delete do horário; o registro é removido definitivamente pelo PurgeDeleted
 func (r *timeSlotRepository) Delete(id uuid.UUID) error {
-	query := `DELETE FROM time_slots WHERE id = $1`
+	query := `UPDATE time_slots SET deleted_at = $1 WHERE id = $2 AND deleted_at IS NULL`
 
-	result, err := r.db.Exec(query, id)
+	result, err := r.db.Exec(query, time.Now(), id)
 	if err != nil {
 		return fmt.Errorf("failed to delete time slot: %w", err)
 	}
@@ -166,11 +194,84 @@ func (r *timeSlotRepository) Delete(id uuid.UUID) error {
 	return nil
 }
 
+func (r *timeSlotRepository) Restore(id uuid.UUID) error {
+	query := `UPDATE time_slots SET deleted_at = NULL, updated_at = $1 WHERE id = $2 AND deleted_at IS NOT NULL`
+
+	result, err := r.db.Exec(query, time.Now(), id)
+	if err != nil {
+		return fmt.Errorf("failed to restore time slot: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *timeSlotRepository) GetDeletedByID(id uuid.UUID) (*domain.TimeSlot, error) {
+	query := `
+		SELECT ` + timeSlotColumns + `
+		FROM time_slots
+		WHERE id = $1 AND deleted_at IS NOT NULL
+	`
+
+	var slot domain.TimeSlot
+	err := r.db.Get(&slot, query, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get deleted time slot: %w", err)
+	}
+
+	return &slot, nil
+}
+
+// GetByIDWithDeleted busca o horário mesmo que esteja excluído. Usado para a capacidade
+// das aulas já marcadas, que continua valendo até a purga do horário.
+func (r *timeSlotRepository) GetByIDWithDeleted(id uuid.UUID) (*domain.TimeSlot, error) {
+	query := `
+		SELECT ` + timeSlotColumns + `
+		FROM time_slots
+		WHERE id = $1
+	`
+
+	var slot domain.TimeSlot
+	err := r.db.Get(&slot, query, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get time slot: %w", err)
+	}
+
+	return &slot, nil
+}
+
+// PurgeDeleted remove definitivamente os horários excluídos antes de "before".
+// Aulas que referenciam o horário ficam com time_slot_id NULL (ON DELETE SET NULL).
+func (r *timeSlotRepository) PurgeDeleted(before time.Time) (int64, error) {
+	query := `DELETE FROM time_slots WHERE deleted_at IS NOT NULL AND deleted_at < $1`
+
+	result, err := r.db.Exec(query, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge deleted time slots: %w", err)
+	}
+
+	return result.RowsAffected()
+}
+
 func (r *timeSlotRepository) ToggleAvailability(id uuid.UUID, isAvailable bool) error {
 	query := `
 		UPDATE time_slots
 		SET is_available = $1, updated_at = $2
-		WHERE id = $3
+		WHERE id = $3 AND deleted_at IS NULL
 	`
 
 	result, err := r.db.Exec(query, isAvailable, time.Now(), id)
@@ -189,3 +290,94 @@ func (r *timeSlotRepository) ToggleAvailability(id uuid.UUID, isAvailable bool)
 
 	return nil
 }
+
+// Supersede encerra a versão id em validUntil e grava next como a versão seguinte,
+// na mesma transação. Aulas já marcadas continuam apontando para a versão encerrada.
+func (r *timeSlotRepository) Supersede(id uuid.UUID, validUntil time.Time, next *domain.TimeSlot) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	// Rollback não tem efeito após o Commit
+	defer tx.Rollback()
+
+	if err := closeTimeSlot(tx, id, validUntil); err != nil {
+		return err
+	}
+
+	if err := insertTimeSlot(tx, next); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// ReplaceWeekly aplica o diff da grade semanal em uma única transação: cria os novos
+// horários (e as novas versões dos alterados), encerra as versões em vigor e faz o soft
+// delete das que ainda não tinham começado a valer. Se algum horário não existir mais,
+// nada é aplicado e ErrNotFound é retornado.
+func (r *timeSlotRepository) ReplaceWeekly(added, closed []domain.TimeSlot, deletedIDs []uuid.UUID) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	// Rollback não tem efeito após o Commit
+	defer tx.Rollback()
+
+	for _, id := range deletedIDs {
+		result, err := tx.Exec(`UPDATE time_slots SET deleted_at = $1 WHERE id = $2 AND deleted_at IS NULL`, time.Now(), id)
+		if err := expectRow(result, err, "failed to delete time slot"); err != nil {
+			return err
+		}
+	}
+
+	for _, slot := range closed {
+		if err := closeTimeSlot(tx, slot.ID, *slot.ValidUntil); err != nil {
+			return err
+		}
+	}
+
+	for i := range added {
+		if err := insertTimeSlot(tx, &added[i]); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// closeTimeSlot define a última data em que a versão do horário vale
+func closeTimeSlot(tx *sqlx.Tx, id uuid.UUID, validUntil time.Time) error {
+	result, err := tx.Exec(`
+		UPDATE time_slots
+		SET valid_until = $1, updated_at = $2
+		WHERE id = $3 AND deleted_at IS NULL
+	`, validUntil, time.Now(), id)
+	return expectRow(result, err, "failed to close time slot")
+}
+
+// expectRow converte o resultado de um UPDATE em ErrNotFound quando nenhuma linha foi afetada
+func expectRow(result sql.Result, err error, message string) error {
+	if err != nil {
+		return fmt.Errorf("%s: %w", message, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
@@ -19,6 +19,9 @@ type UserRepository interface {
 	GetByCPF(cpf string) (*domain.User, error)
 	Update(user *domain.User) error
 	Delete(id uuid.UUID) error
+	GetDeletedByAuthID(authID uuid.UUID) (*domain.User, error)
+	Restore(id uuid.UUID) error
+	PurgeDeleted(before time.Time) (int64, error)
 	ExistsByCPF(cpf string) (bool, error)
 	ExistsByAuthID(authID uuid.UUID) (bool, error)
 }
@@ -33,8 +36,8 @@ type userRepository struct {
 
 func (r *userRepository) Create(user *domain.User) error {
 	query := `
-		INSERT INTO users (id, auth_id, full_name, cpf, birth_date, address, contact, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		INSERT INTO users (id, auth_id, full_name, cpf, birth_date, address, contact, timezone, class_reminders, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
 	`
 
 	user.ID = uuid.New()
@@ -61,6 +64,8 @@ func (r *userRepository) Create(user *domain.User) error {
 		user.BirthDate,
 		addressJSON,
 		contactJSON,
+		user.Timezone,
+		user.ClassReminders,
 		user.CreatedAt,
 		user.UpdatedAt,
 	)
@@ -76,9 +81,9 @@ func (r *userRepository) Create(user *domain.User) error {
 
 func (r *userRepository) GetByID(id uuid.UUID) (*domain.User, error) {
 	query := `
-		SELECT id, auth_id, full_name, cpf, birth_date, address, contact, created_at, updated_at
+		SELECT id, auth_id, full_name, cpf, birth_date, address, contact, timezone, class_reminders, created_at, updated_at, deleted_at
 		FROM users
-		WHERE id = $1
+		WHERE id = $1 AND deleted_at IS NULL
 	`
 
 	var user domain.User
@@ -92,8 +97,11 @@ func (r *userRepository) GetByID(id uuid.UUID) (*domain.User, error) {
 		&user.BirthDate,
 		&addressJSON,
 		&contactJSON,
+		&user.Timezone,
+		&user.ClassReminders,
 		&user.CreatedAt,
 		&user.UpdatedAt,
+		&user.DeletedAt,
 	)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -116,9 +124,9 @@ func (r *userRepository) GetByID(id uuid.UUID) (*domain.User, error) {
 
 func (r *userRepository) GetByAuthID(authID uuid.UUID) (*domain.User, error) {
 	query := `
-		SELECT id, auth_id, full_name, cpf, birth_date, address, contact, created_at, updated_at
+		SELECT id, auth_id, full_name, cpf, birth_date, address, contact, timezone, class_reminders, created_at, updated_at, deleted_at
 		FROM users
-		WHERE auth_id = $1
+		WHERE auth_id = $1 AND deleted_at IS NULL
 	`
 
 	var user domain.User
@@ -132,8 +140,11 @@ func (r *userRepository) GetByAuthID(authID uuid.UUID) (*domain.User, error) {
 		&user.BirthDate,
 		&addressJSON,
 		&contactJSON,
+		&user.Timezone,
+		&user.ClassReminders,
 		&user.CreatedAt,
 		&user.UpdatedAt,
+		&user.DeletedAt,
 	)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -155,9 +166,9 @@ func (r *userRepository) GetByAuthID(authID uuid.UUID) (*domain.User, error) {
 
 func (r *userRepository) GetByCPF(cpf string) (*domain.User, error) {
 	query := `
-		SELECT id, auth_id, full_name, cpf, birth_date, address, contact, created_at, updated_at
+		SELECT id, auth_id, full_name, cpf, birth_date, address, contact, timezone, class_reminders, created_at, updated_at, deleted_at
 		FROM users
-		WHERE cpf = $1
+		WHERE cpf = $1 AND deleted_at IS NULL
 	`
 
 	var user domain.User
@@ -171,8 +182,11 @@ func (r *userRepository) GetByCPF(cpf string) (*domain.User, error) {
 		&user.BirthDate,
 		&addressJSON,
 		&contactJSON,
+		&user.Timezone,
+		&user.ClassReminders,
 		&user.CreatedAt,
 		&user.UpdatedAt,
+		&user.DeletedAt,
 	)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -195,8 +209,9 @@ func (r *userRepository) GetByCPF(cpf string) (*domain.User, error) {
 func (r *userRepository) Update(user *domain.User) error {
 	query := `
 		UPDATE users
-		SET full_name = $1, cpf = $2, birth_date = $3, address = $4, contact = $5, updated_at = $6
-		WHERE id = $7
+		SET full_name = $1, cpf = $2, birth_date = $3, address = $4, contact = $5, timezone = $6, class_reminders = $7,
+		    updated_at = $8
+		WHERE id = $9 AND deleted_at IS NULL
 	`
 
 	user.UpdatedAt = time.Now()
@@ -218,6 +233,8 @@ func (r *userRepository) Update(user *domain.User) error {
 		user.BirthDate,
 		addressJSON,
 		contactJSON,
+		user.Timezone,
+		user.ClassReminders,
 		user.UpdatedAt,
 		user.ID,
 	)
@@ -240,26 +257,160 @@ func (r *userRepository) Update(user *domain.User) error {
 	return nil
 }
 
+// Delete faz o soft delete do usuário e desativa o login na mesma transação. O status
+// anterior do login é guardado para o Restore; o registro é removido definitivamente pelo PurgeDeleted
 func (r *userRepository) Delete(id uuid.UUID) error {
-	query := `DELETE FROM users WHERE id = $1`
-
-	result, err := r.db.Exec(query, id)
+	tx, err := r.db.Beginx()
 	if err != nil {
+		return fmt.Errorf("falha ao iniciar a transação: %w", err)
+	}
+
+	defer tx.Rollback()
+
+	now := time.Now()
+	query := `
+		UPDATE users u
+		SET deleted_at = $1, status_before_delete = a.status
+		FROM auth a
+		WHERE a.id = u.auth_id AND u.id = $2 AND u.deleted_at IS NULL
+		RETURNING u.auth_id
+	`
+
+	var authID uuid.UUID
+	if err := tx.Get(&authID, query, now, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrNotFound
+		}
 		return fmt.Errorf("falha ao deletar user: %w", err)
 	}
 
-	rowsAffected, err := result.RowsAffected()
+	if _, err := tx.Exec(`UPDATE auth SET status = $1, updated_at = $2 WHERE id = $3`, domain.StatusInactive, now, authID); err != nil {
+		return fmt.Errorf("falha ao desativar o login: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+func (r *userRepository) GetDeletedByAuthID(authID uuid.UUID) (*domain.User, error) {
+	query := `
+		SELECT id, auth_id, full_name, cpf, birth_date, address, contact, timezone, class_reminders, created_at, updated_at, deleted_at
+		FROM users
+		WHERE auth_id = $1 AND deleted_at IS NOT NULL
+	`
+
+	var user domain.User
+	var addressJSON, contactJSON []byte
+
+	err := r.db.QueryRow(query, authID).Scan(
+		&user.ID,
+		&user.AuthID,
+		&user.FullName,
+		&user.CPF,
+		&user.BirthDate,
+		&addressJSON,
+		&contactJSON,
+		&user.Timezone,
+		&user.ClassReminders,
+		&user.CreatedAt,
+		&user.UpdatedAt,
+		&user.DeletedAt,
+	)
 	if err != nil {
-		return fmt.Errorf("falha ao verificar o números de linhas afetadas: %w", err)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("falha ao buscar o usuário excluído: %w", err)
 	}
 
-	if rowsAffected == 0 {
-		return ErrNotFound
+	if err := json.Unmarshal(addressJSON, &user.Address); err != nil {
+		return nil, fmt.Errorf("falha ao converter os dados de endereço em struct: %w", err)
 	}
 
-	return nil
+	if err := json.Unmarshal(contactJSON, &user.Contact); err != nil {
+		return nil, fmt.Errorf("falha ao converter os dados de contato em struct: %w", err)
+	}
+
+	return &user, nil
+}
+
+// Restore desfaz o soft delete do usuário e devolve ao login o status que ele tinha
+// antes da exclusão (pendente, ativo ou desativado), na mesma transação
+func (r *userRepository) Restore(id uuid.UUID) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return fmt.Errorf("falha ao iniciar a transação: %w", err)
+	}
+
+	defer tx.Rollback()
+
+	var deleted struct {
+		AuthID uuid.UUID         `db:"auth_id"`
+		Status domain.UserStatus `db:"status_before_delete"`
+	}
+	query := `SELECT auth_id, status_before_delete FROM users WHERE id = $1 AND deleted_at IS NOT NULL FOR UPDATE`
+	if err := tx.Get(&deleted, query, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrNotFound
+		}
+		return fmt.Errorf("falha ao buscar o usuário excluído: %w", err)
+	}
+
+	now := time.Now()
+	if _, err := tx.Exec(`UPDATE users SET deleted_at = NULL, status_before_delete = NULL, updated_at = $1 WHERE id = $2`, now, id); err != nil {
+		return fmt.Errorf("falha ao restaurar user: %w", err)
+	}
+
+	if _, err := tx.Exec(`UPDATE auth SET status = $1, updated_at = $2 WHERE id = $3`, deleted.Status, now, deleted.AuthID); err != nil {
+		return fmt.Errorf("falha ao restaurar o status do login: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// PurgeDeleted remove definitivamente os usuários excluídos antes de "before" junto com
+// o login, cuja exclusão remove o usuário em cascata. Logins ainda referenciados por
+// registros sem cascata (ex.: aulas criadas pelo usuário) são mantidos para a próxima purga.
+func (r *userRepository) PurgeDeleted(before time.Time) (int64, error) {
+	var authIDs []uuid.UUID
+	query := `SELECT auth_id FROM users WHERE deleted_at IS NOT NULL AND deleted_at < $1`
+	if err := r.db.Select(&authIDs, query, before); err != nil {
+		return 0, fmt.Errorf("falha ao listar usuários excluídos: %w", err)
+	}
+
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return 0, fmt.Errorf("falha ao iniciar a transação: %w", err)
+	}
+
+	defer tx.Rollback()
+
+	var purged int64
+	for _, authID := range authIDs {
+		if _, err := tx.Exec(`SAVEPOINT purge_user`); err != nil {
+			return 0, fmt.Errorf("falha ao criar savepoint: %w", err)
+		}
+
+		if _, err := tx.Exec(`DELETE FROM auth WHERE id = $1`, authID); err != nil {
+			if _, err := tx.Exec(`ROLLBACK TO SAVEPOINT purge_user`); err != nil {
+				return 0, fmt.Errorf("falha ao desfazer a purga do usuário: %w", err)
+			}
+			continue
+		}
+
+		if _, err := tx.Exec(`RELEASE SAVEPOINT purge_user`); err != nil {
+			return 0, fmt.Errorf("falha ao liberar savepoint: %w", err)
+		}
+		purged++
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("falha ao purgar usuários excluídos: %w", err)
+	}
+
+	return purged, nil
 }
 
+// ExistsByCPF considera também usuários excluídos, pois o CPF continua reservado até a purga
 func (r *userRepository) ExistsByCPF(cpf string) (bool, error) {
 	query := `SELECT EXISTS(SELECT 1 FROM users WHERE cpf = $1)`
 
@@ -0,0 +1,336 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hscHeric/go-potential-api/internal/domain"
+	"github.com/jmoiron/sqlx"
+)
+
+type WaitlistRepository interface {
+	Join(entry *domain.WaitlistEntry) error
+	Leave(classID, studentID uuid.UUID) error
+	GetByClass(classID uuid.UUID) ([]domain.WaitlistEntry, error)
+	GetByClassAndStudent(classID, studentID uuid.UUID) (*domain.WaitlistEntry, error)
+	CountActiveOffers(classID uuid.UUID) (int, error)
+	OfferNext(classID uuid.UUID, capacity int, offerToken string, expiresAt time.Time) (*domain.WaitlistEntry, error)
+	ConfirmOffer(offerToken string, studentID uuid.UUID) (*domain.WaitlistEntry, error)
+	ExpireOffers(now time.Time) ([]uuid.UUID, error)
+	ExpireClassOffers(classID uuid.UUID) error
+}
+
+const waitlistColumns = `id, class_id, student_id, position, status, offer_token, offered_at, offer_expires_at,
+		       created_at, updated_at`
+
+type waitlistRepository struct {
+	db *sqlx.DB
+}
+
+func NewWaitlistRepository(db *sqlx.DB) WaitlistRepository {
+	return &waitlistRepository{db: db}
+}
+
+// Join coloca o aluno no fim da fila. Quem saiu ou perdeu a oferta pode voltar
+// (vai para o fim); quem já está aguardando recebe ErrAlreadyExists.
+func (r *waitlistRepository) Join(entry *domain.WaitlistEntry) error {
+	query := `
+		INSERT INTO class_waitlist (id, class_id, student_id, position, status, created_at, updated_at)
+		VALUES (
+			$1, $2, $3,
+			(SELECT COALESCE(MAX(position), 0) + 1 FROM class_waitlist WHERE class_id = $2),
+			'waiting', $4, $4
+		)
+		ON CONFLICT (class_id, student_id) DO UPDATE
+		SET status = 'waiting',
+		    position = EXCLUDED.position,
+		    offer_token = NULL,
+		    offered_at = NULL,
+		    offer_expires_at = NULL,
+		    updated_at = EXCLUDED.updated_at
+		WHERE class_waitlist.status IN ('left', 'expired')
+		RETURNING id, position, status, created_at, updated_at
+	`
+
+	now := time.Now()
+	err := r.db.QueryRow(query, uuid.New(), entry.ClassID, entry.StudentID, now).Scan(
+		&entry.ID,
+		&entry.Position,
+		&entry.Status,
+		&entry.CreatedAt,
+		&entry.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrAlreadyExists
+		}
+		return fmt.Errorf("failed to join waitlist: %w", err)
+	}
+
+	return nil
+}
+
+func (r *waitlistRepository) Leave(classID, studentID uuid.UUID) error {
+	query := `
+		UPDATE class_waitlist
+		SET status = 'left', offer_token = NULL, updated_at = $1
+		WHERE class_id = $2 AND student_id = $3 AND status IN ('waiting', 'offered')
+	`
+
+	result, err := r.db.Exec(query, time.Now(), classID, studentID)
+	if err != nil {
+		return fmt.Errorf("failed to leave waitlist: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// GetByClass retorna as entradas ativas (aguardando ou com oferta) na ordem da fila
+func (r *waitlistRepository) GetByClass(classID uuid.UUID) ([]domain.WaitlistEntry, error) {
+	query := `
+		SELECT ` + waitlistColumns + `
+		FROM class_waitlist
+		WHERE class_id = $1 AND status IN ('waiting', 'offered')
+		ORDER BY position, created_at
+	`
+
+	var entries []domain.WaitlistEntry
+	err := r.db.Select(&entries, query, classID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get waitlist: %w", err)
+	}
+
+	return entries, nil
+}
+
+func (r *waitlistRepository) GetByClassAndStudent(classID, studentID uuid.UUID) (*domain.WaitlistEntry, error) {
+	query := `
+		SELECT ` + waitlistColumns + `
+		FROM class_waitlist
+		WHERE class_id = $1 AND student_id = $2
+	`
+
+	var entry domain.WaitlistEntry
+	err := r.db.Get(&entry, query, classID, studentID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get waitlist entry: %w", err)
+	}
+
+	return &entry, nil
+}
+
+// CountActiveOffers conta as vagas reservadas para ofertas ainda dentro do prazo
+func (r *waitlistRepository) CountActiveOffers(classID uuid.UUID) (int, error) {
+	query := `
+		SELECT COUNT(*) FROM class_waitlist
+		WHERE class_id = $1 AND status = 'offered' AND offer_expires_at > $2
+	`
+
+	var count int
+	err := r.db.Get(&count, query, classID, time.Now())
+	if err != nil {
+		return 0, fmt.Errorf("failed to count active offers: %w", err)
+	}
+
+	return count, nil
+}
+
+// OfferNext oferece a vaga ao primeiro da fila, se houver vaga livre. A aula é
+// bloqueada com FOR UPDATE para que duas liberações simultâneas não ofereçam
+// a mesma vaga. Retorna ErrNotFound se não houver vaga ou ninguém aguardando.
+func (r *waitlistRepository) OfferNext(classID uuid.UUID, capacity int, offerToken string, expiresAt time.Time) (*domain.WaitlistEntry, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	// Rollback não tem efeito após o Commit
+	defer tx.Rollback()
+
+	var locked uuid.UUID
+	err = tx.Get(&locked, `
+		SELECT id FROM classes
+		WHERE id = $1 AND deleted_at IS NULL AND status = 'scheduled' AND starts_at > $2
+		FOR UPDATE
+	`, classID, time.Now())
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to lock class: %w", err)
+	}
+
+	now := time.Now()
+
+	var taken int
+	err = tx.Get(&taken, `
+		SELECT (SELECT COUNT(*) FROM class_students WHERE class_id = $1 AND cancelled_at IS NULL) +
+		       (SELECT COUNT(*) FROM class_waitlist WHERE class_id = $1 AND status = 'offered' AND offer_expires_at > $2)
+	`, classID, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count seats: %w", err)
+	}
+
+	if taken >= capacity {
+		return nil, ErrNotFound
+	}
+
+	var entry domain.WaitlistEntry
+	err = tx.Get(&entry, `
+		UPDATE class_waitlist
+		SET status = 'offered', offer_token = $1, offered_at = $2, offer_expires_at = $3, updated_at = $2
+		WHERE id = (
+			SELECT id FROM class_waitlist
+			WHERE class_id = $4 AND status = 'waiting'
+			ORDER BY position, created_at
+			LIMIT 1
+		)
+		RETURNING `+waitlistColumns, offerToken, now, expiresAt, classID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to offer seat: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit offer: %w", err)
+	}
+
+	return &entry, nil
+}
+
+// ConfirmOffer matricula o aluno na aula usando o token da oferta. A aula é bloqueada e
+// precisa continuar agendada, não excluída e no futuro. Um crédito do aluno é debitado
+// na mesma transação (ErrNoCredits mantém a oferta em aberto).
+func (r *waitlistRepository) ConfirmOffer(offerToken string, studentID uuid.UUID) (*domain.WaitlistEntry, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	// Rollback não tem efeito após o Commit
+	defer tx.Rollback()
+
+	var entry domain.WaitlistEntry
+	err = tx.Get(&entry, `
+		SELECT `+waitlistColumns+`
+		FROM class_waitlist
+		WHERE offer_token = $1 AND student_id = $2 AND status = 'offered'
+		FOR UPDATE
+	`, offerToken, studentID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get offer: %w", err)
+	}
+
+	now := time.Now()
+	if entry.OfferExpiresAt != nil && entry.OfferExpiresAt.Before(now) {
+		return nil, ErrOfferExpired
+	}
+
+	var locked uuid.UUID
+	err = tx.Get(&locked, `
+		SELECT id FROM classes
+		WHERE id = $1 AND deleted_at IS NULL AND status = 'scheduled' AND starts_at > $2
+		FOR UPDATE
+	`, entry.ClassID, now)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrClassUnavailable
+		}
+		return nil, fmt.Errorf("failed to lock class: %w", err)
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO class_students (id, class_id, student_id, added_by, created_at)
+		VALUES ($1, $2, $3, $3, $4)
+		ON CONFLICT (class_id, student_id) DO UPDATE
+		SET added_by = EXCLUDED.added_by, attended = NULL, created_at = EXCLUDED.created_at,
+		    cancelled_at = NULL, cancelled_by = NULL, cancellation_outcome = NULL
+		WHERE class_students.cancelled_at IS NOT NULL
+	`, uuid.New(), entry.ClassID, entry.StudentID, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add student to class: %w", err)
+	}
+
+	if _, err := debitCredit(tx, entry.StudentID, entry.ClassID, now); err != nil {
+		return nil, err
+	}
+
+	_, err = tx.Exec(`
+		UPDATE class_waitlist
+		SET status = 'confirmed', offer_token = NULL, updated_at = $1
+		WHERE id = $2
+	`, now, entry.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to confirm offer: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit confirmation: %w", err)
+	}
+
+	entry.Status = domain.WaitlistStatusConfirmed
+	entry.OfferToken = nil
+
+	return &entry, nil
+}
+
+// ExpireOffers encerra as ofertas vencidas e retorna as aulas que voltaram a ter vaga
+func (r *waitlistRepository) ExpireOffers(now time.Time) ([]uuid.UUID, error) {
+	query := `
+		UPDATE class_waitlist
+		SET status = 'expired', offer_token = NULL, updated_at = $1
+		WHERE status = 'offered' AND offer_expires_at <= $1
+		RETURNING class_id
+	`
+
+	var classIDs []uuid.UUID
+	err := r.db.Select(&classIDs, query, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to expire offers: %w", err)
+	}
+
+	// Uma aula pode ter mais de uma oferta vencida
+	seen := make(map[uuid.UUID]bool, len(classIDs))
+	unique := classIDs[:0]
+	for _, id := range classIDs {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+
+	return unique, nil
+}
+
+// ExpireClassOffers encerra as ofertas em aberto da aula (ex.: aula cancelada ou excluída)
+func (r *waitlistRepository) ExpireClassOffers(classID uuid.UUID) error {
+	query := `
+		UPDATE class_waitlist
+		SET status = 'expired', offer_token = NULL, updated_at = $1
+		WHERE class_id = $2 AND status = 'offered'
+	`
+
+	if _, err := r.db.Exec(query, time.Now(), classID); err != nil {
+		return fmt.Errorf("failed to expire class offers: %w", err)
+	}
+
+	return nil
+}
@@ -2,24 +2,46 @@
 package router
 
 import (
+	"time"
+
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	playground "github.com/go-playground/validator/v10"
 	"github.com/hscHeric/go-potential-api/internal/domain"
 	"github.com/hscHeric/go-potential-api/internal/handler"
 	"github.com/hscHeric/go-potential-api/internal/middleware"
 	"github.com/hscHeric/go-potential-api/pkg/jwt"
+	"github.com/hscHeric/go-potential-api/pkg/validator"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 )
 
 type RouterConfig struct {
-	AuthHandler     *handler.AuthHandler
-	UserHandler     *handler.UserHandler
-	TimeSlotHandler *handler.TimeSlotHandler
-	ClassHandler    *handler.ClassHandler
-	JWTService      *jwt.Service
+	AuthHandler         *handler.AuthHandler
+	UserHandler         *handler.UserHandler
+	TimeSlotHandler     *handler.TimeSlotHandler
+	ClassHandler        *handler.ClassHandler
+	TeacherHandler      *handler.TeacherHandler
+	StudentHandler      *handler.StudentHandler
+	AddressHandler      *handler.AddressHandler
+	WaitlistHandler     *handler.WaitlistHandler
+	RescheduleHandler   *handler.RescheduleHandler
+	BlackoutHandler     *handler.BlackoutHandler
+	HolidayHandler      *handler.HolidayHandler
+	CalendarFeedHandler *handler.CalendarFeedHandler
+	SubstitutionHandler *handler.SubstitutionHandler
+	RoomHandler         *handler.RoomHandler
+	CreditHandler       *handler.CreditHandler
+	CalendarHandler     *handler.CalendarHandler
+	JWTService          *jwt.Service
 }
 
 func SetupRouter(cfg RouterConfig) *gin.Engine {
+	// As tags "binding" (cpf, phone) são validadas pelo ShouldBindJSON do gin
+	if engine, ok := binding.Validator.Engine().(*playground.Validate); ok {
+		validator.RegisterCustomValidations(engine)
+	}
+
 	router := gin.Default()
 
 	// Middlewares globais
@@ -50,6 +72,20 @@ func SetupRouter(cfg RouterConfig) *gin.Engine {
 			auth.POST("/reset-password", cfg.AuthHandler.ResetPassword)
 		}
 
+		// Address routes (públicas, usadas no cadastro)
+		addresses := v1.Group("/addresses")
+		addresses.Use(middleware.RateLimiterMiddleware(30, time.Minute))
+		{
+			addresses.GET("/cep/:cep", cfg.AddressHandler.LookupCEP)
+		}
+
+		// Feed de calendário (pública, o token da URL é a credencial)
+		feeds := v1.Group("/feeds")
+		feeds.Use(middleware.RateLimiterMiddleware(60, time.Minute))
+		{
+			feeds.GET("/:token", cfg.CalendarFeedHandler.GetFeed)
+		}
+
 		// Invitations (apenas admin)
 		invitations := v1.Group("/invitations")
 		invitations.Use(middleware.AuthMiddleware(cfg.JWTService))
@@ -58,12 +94,44 @@ func SetupRouter(cfg RouterConfig) *gin.Engine {
 			invitations.POST("", cfg.AuthHandler.CreateInvitation)
 		}
 
+		// Admin routes (restauração de registros excluídos, folgas de professores, feriados, salas e créditos)
+		admin := v1.Group("/admin")
+		admin.Use(middleware.AuthMiddleware(cfg.JWTService))
+		admin.Use(middleware.RequireAdmin())
+		{
+			admin.DELETE("/users/:id", cfg.UserHandler.DeleteUser)
+			admin.POST("/users/:id/restore", cfg.UserHandler.RestoreUser)
+			admin.POST("/classes/:id/restore", cfg.ClassHandler.RestoreClass)
+			admin.POST("/time-slots/:id/restore", cfg.TimeSlotHandler.RestoreTimeSlot)
+			admin.POST("/teachers/:id/blackouts", cfg.BlackoutHandler.CreateTeacherBlackout)
+
+			// Calendário de feriados
+			admin.POST("/holidays", cfg.HolidayHandler.CreateHoliday)
+			admin.POST("/holidays/generate", cfg.HolidayHandler.GenerateNationalHolidays)
+			admin.GET("/holidays/:id", cfg.HolidayHandler.GetHoliday)
+			admin.PUT("/holidays/:id", cfg.HolidayHandler.UpdateHoliday)
+			admin.DELETE("/holidays/:id", cfg.HolidayHandler.DeleteHoliday)
+			admin.POST("/holidays/:id/classes", cfg.HolidayHandler.ResolveHolidayClasses)
+
+			// Unidades e salas
+			admin.POST("/branches", cfg.RoomHandler.CreateBranch)
+			admin.PUT("/branches/:id", cfg.RoomHandler.UpdateBranch)
+			admin.DELETE("/branches/:id", cfg.RoomHandler.DeleteBranch)
+			admin.POST("/branches/:id/rooms", cfg.RoomHandler.CreateRoom)
+			admin.PUT("/rooms/:id", cfg.RoomHandler.UpdateRoom)
+			admin.DELETE("/rooms/:id", cfg.RoomHandler.DeleteRoom)
+
+			// Pacotes de créditos de aula
+			admin.POST("/students/:id/credits", cfg.CreditHandler.CreatePackage)
+		}
+
 		// User routes (autenticadas)
 		users := v1.Group("/users")
 		users.Use(middleware.AuthMiddleware(cfg.JWTService))
 		{
 			users.GET("/me", cfg.UserHandler.GetProfile)
 			users.PUT("/me", cfg.UserHandler.UpdateProfile)
+			users.PUT("/me/reminders", cfg.UserHandler.UpdateClassReminders)
 		}
 
 		// TimeSlot routes (Professor)
@@ -73,6 +141,7 @@ func SetupRouter(cfg RouterConfig) *gin.Engine {
 			// Rotas para professores criarem/gerenciarem seus horários
 			timeSlots.POST("", middleware.RequireRole(domain.RoleTeacher), cfg.TimeSlotHandler.CreateTimeSlot)
 			timeSlots.GET("/me", middleware.RequireRole(domain.RoleTeacher), cfg.TimeSlotHandler.GetMyTimeSlots)
+			timeSlots.PUT("/me", middleware.RequireRole(domain.RoleTeacher), cfg.TimeSlotHandler.ReplaceMyTimeSlots)
 			timeSlots.PUT("/:id", middleware.RequireRole(domain.RoleTeacher), cfg.TimeSlotHandler.UpdateTimeSlot)
 			timeSlots.DELETE("/:id", middleware.RequireRole(domain.RoleTeacher), cfg.TimeSlotHandler.DeleteTimeSlot)
 			timeSlots.PATCH("/:id/toggle", middleware.RequireRole(domain.RoleTeacher), cfg.TimeSlotHandler.ToggleTimeSlotAvailability)
@@ -82,6 +151,96 @@ func SetupRouter(cfg RouterConfig) *gin.Engine {
 			timeSlots.GET("/teacher/:teacher_id/available", cfg.TimeSlotHandler.GetAvailableSlots)
 		}
 
+		// Teacher routes (diretório de professores e perfil profissional)
+		teachers := v1.Group("/teachers")
+		teachers.Use(middleware.AuthMiddleware(cfg.JWTService))
+		{
+			teachers.GET("", cfg.TeacherHandler.ListTeachers)
+			teachers.GET("/me/profile", middleware.RequireRole(domain.RoleTeacher), cfg.TeacherHandler.GetMyProfile)
+			teachers.PUT("/me/profile", middleware.RequireRole(domain.RoleTeacher), cfg.TeacherHandler.UpdateMyProfile)
+			teachers.GET("/:id", cfg.TeacherHandler.GetTeacherProfile)
+			teachers.GET("/:id/openings", cfg.TimeSlotHandler.GetTeacherOpenings)
+
+			// Férias e folgas (Professor gerencia as próprias)
+			teachers.POST("/me/blackouts", middleware.RequireRole(domain.RoleTeacher), cfg.BlackoutHandler.CreateMyBlackout)
+			teachers.GET("/me/blackouts/:blackout_id", middleware.RequireRole(domain.RoleTeacher), cfg.BlackoutHandler.GetMyBlackout)
+			teachers.DELETE("/me/blackouts/:blackout_id", middleware.RequireRole(domain.RoleTeacher), cfg.BlackoutHandler.DeleteMyBlackout)
+			teachers.GET("/:id/blackouts", cfg.BlackoutHandler.GetTeacherBlackouts)
+		}
+
+		// Student routes (níveis CEFR e créditos de aula)
+		students := v1.Group("/students")
+		students.Use(middleware.AuthMiddleware(cfg.JWTService))
+		{
+			students.GET("/me/levels", middleware.RequireRole(domain.RoleStudent), cfg.StudentHandler.GetMyLevelHistory)
+			students.GET("/:id/levels", middleware.RequireRole(domain.RoleTeacher, domain.RoleAdmin), cfg.StudentHandler.GetLevelHistory)
+			students.POST("/:id/levels", middleware.RequireRole(domain.RoleTeacher, domain.RoleAdmin), cfg.StudentHandler.RecordLevel)
+			students.GET("/me/credits", middleware.RequireRole(domain.RoleStudent), cfg.CreditHandler.GetMyCredits)
+			students.GET("/me/credits/ledger", middleware.RequireRole(domain.RoleStudent), cfg.CreditHandler.GetMyLedger)
+			students.GET("/:id/credits", middleware.RequireAdmin(), cfg.CreditHandler.GetStudentCredits)
+			students.GET("/:id/credits/ledger", middleware.RequireAdmin(), cfg.CreditHandler.GetStudentLedger)
+		}
+
+		// Holiday routes (calendário da escola)
+		holidays := v1.Group("/holidays")
+		holidays.Use(middleware.AuthMiddleware(cfg.JWTService))
+		{
+			holidays.GET("", cfg.HolidayHandler.ListHolidays)
+		}
+
+		// Branch routes (unidades, salas e ocupação)
+		branches := v1.Group("/branches")
+		branches.Use(middleware.AuthMiddleware(cfg.JWTService))
+		{
+			branches.GET("", cfg.RoomHandler.ListBranches)
+			branches.GET("/:id/rooms", cfg.RoomHandler.ListRooms)
+			branches.GET("/:id/occupancy", middleware.RequireRole(domain.RoleTeacher, domain.RoleAdmin), cfg.RoomHandler.GetBranchOccupancy)
+		}
+
+		// Room routes (sugestão de salas livres para o agendamento)
+		rooms := v1.Group("/rooms")
+		rooms.Use(middleware.AuthMiddleware(cfg.JWTService))
+		{
+			rooms.GET("/available", middleware.RequireRole(domain.RoleTeacher, domain.RoleAdmin), cfg.RoomHandler.SuggestRooms)
+			rooms.GET("/:id", cfg.RoomHandler.GetRoom)
+		}
+
+		// Calendar routes (agenda da semana/mês e assinatura .ics do usuário)
+		calendar := v1.Group("/calendar")
+		calendar.Use(middleware.AuthMiddleware(cfg.JWTService))
+		{
+			calendar.GET("", cfg.CalendarHandler.GetCalendar)
+
+			calendar.GET("/feed", middleware.RequireRole(domain.RoleTeacher, domain.RoleStudent), cfg.CalendarFeedHandler.GetMyFeed)
+			calendar.POST("/feed", middleware.RequireRole(domain.RoleTeacher, domain.RoleStudent), cfg.CalendarFeedHandler.RotateMyFeed)
+			calendar.DELETE("/feed", middleware.RequireRole(domain.RoleTeacher, domain.RoleStudent), cfg.CalendarFeedHandler.RevokeMyFeed)
+		}
+
+		// Booking routes (aluno reserva uma ocorrência de horário)
+		bookings := v1.Group("/bookings")
+		bookings.Use(middleware.AuthMiddleware(cfg.JWTService))
+		bookings.Use(middleware.RequireRole(domain.RoleStudent))
+		{
+			bookings.POST("", cfg.ClassHandler.CreateBooking)
+		}
+
+		// Class series routes (aulas recorrentes)
+		classSeries := v1.Group("/class-series")
+		classSeries.Use(middleware.AuthMiddleware(cfg.JWTService))
+		classSeries.Use(middleware.RequireRole(domain.RoleTeacher, domain.RoleAdmin))
+		{
+			classSeries.POST("", cfg.ClassHandler.CreateClassSeries)
+			classSeries.GET("/:id", cfg.ClassHandler.GetClassSeries)
+		}
+
+		// Waitlist routes (confirmação da vaga oferecida por email)
+		waitlist := v1.Group("/waitlist")
+		waitlist.Use(middleware.AuthMiddleware(cfg.JWTService))
+		waitlist.Use(middleware.RequireRole(domain.RoleStudent))
+		{
+			waitlist.POST("/confirm", cfg.WaitlistHandler.ConfirmOffer)
+		}
+
 		// Class routes
 		classes := v1.Group("/classes")
 		classes.Use(middleware.AuthMiddleware(cfg.JWTService))
@@ -92,19 +251,49 @@ func SetupRouter(cfg RouterConfig) *gin.Engine {
 			// Ver minhas aulas (Professor vê as que ensina, Aluno vê as que está matriculado)
 			classes.GET("/me", cfg.ClassHandler.GetMyClasses)
 
+			// Agenda geral de todos os professores (lista paginada e grade por professor e dia)
+			classes.GET("", middleware.RequireAdmin(), cfg.ClassHandler.ListClasses)
+			classes.GET("/grid", middleware.RequireAdmin(), cfg.ClassHandler.GetScheduleGrid)
+
 			// Ver detalhes de uma aula
 			classes.GET("/:id", cfg.ClassHandler.GetClass)
 
 			// Atualizar aula (Professor ou Admin)
 			classes.PUT("/:id", middleware.RequireRole(domain.RoleTeacher, domain.RoleAdmin), cfg.ClassHandler.UpdateClass)
 
+			// Excluir aula (apenas Admin, soft delete)
+			classes.DELETE("/:id", middleware.RequireAdmin(), cfg.ClassHandler.DeleteClass)
+
 			// Cancelar aula (Professor ou Admin)
 			classes.PATCH("/:id/cancel", middleware.RequireRole(domain.RoleTeacher, domain.RoleAdmin), cfg.ClassHandler.CancelClass)
 
+			// Mudar status da aula (Professor da aula ou Admin)
+			classes.PATCH("/:id/status", middleware.RequireRole(domain.RoleTeacher, domain.RoleAdmin), cfg.ClassHandler.UpdateClassStatus)
+
+			// Aluno cancela a própria participação (aplica a política de cancelamento)
+			classes.PATCH("/:id/participation/cancel", middleware.RequireRole(domain.RoleStudent), cfg.ClassHandler.CancelParticipation)
+
 			// Gerenciar alunos na aula
 			classes.POST("/:id/students", middleware.RequireRole(domain.RoleTeacher, domain.RoleAdmin, domain.RoleStudent), cfg.ClassHandler.AddStudentToClass)
 			classes.DELETE("/:id/students/:student_id", middleware.RequireRole(domain.RoleTeacher, domain.RoleAdmin), cfg.ClassHandler.RemoveStudentFromClass)
 
+			// Lista de espera (Aluno entra/sai, Professor ou Admin consulta)
+			classes.POST("/:id/waitlist", middleware.RequireRole(domain.RoleStudent), cfg.WaitlistHandler.JoinWaitlist)
+			classes.DELETE("/:id/waitlist", middleware.RequireRole(domain.RoleStudent), cfg.WaitlistHandler.LeaveWaitlist)
+			classes.GET("/:id/waitlist", middleware.RequireRole(domain.RoleTeacher, domain.RoleAdmin), cfg.WaitlistHandler.GetWaitlist)
+
+			// Remarcação (Professor ou Admin propõe, Alunos confirmam)
+			classes.POST("/:id/reschedule", middleware.RequireRole(domain.RoleTeacher, domain.RoleAdmin), cfg.RescheduleHandler.ProposeReschedule)
+			classes.POST("/:id/reschedule/respond", middleware.RequireRole(domain.RoleStudent), cfg.RescheduleHandler.RespondReschedule)
+			classes.GET("/:id/reschedules", cfg.RescheduleHandler.GetRescheduleHistory)
+
+			// Professor substituto (Professor da aula ou Admin)
+			classes.GET("/:id/substitute", middleware.RequireRole(domain.RoleTeacher, domain.RoleAdmin), cfg.SubstitutionHandler.SuggestSubstitutes)
+			classes.POST("/:id/substitute", middleware.RequireRole(domain.RoleTeacher, domain.RoleAdmin), cfg.SubstitutionHandler.AssignSubstitute)
+
+			// Sala da aula presencial (Professor da aula ou Admin)
+			classes.PUT("/:id/room", middleware.RequireRole(domain.RoleTeacher, domain.RoleAdmin), cfg.RoomHandler.AssignClassRoom)
+
 			// Marcar presença (apenas Professor)
 			classes.PATCH("/:id/students/:student_id/attendance", middleware.RequireRole(domain.RoleTeacher), cfg.ClassHandler.MarkAttendance)
 		}
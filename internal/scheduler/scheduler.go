@@ -0,0 +1,83 @@
+// Package scheduler executa tarefas periódicas em segundo plano
+package scheduler
+
+import (
+	"log"
+	"sync"
+	"time"
+)
+
+// Job é uma tarefa executada a cada Interval
+type Job struct {
+	Name     string
+	Interval time.Duration
+	Run      func() error
+}
+
+type Scheduler struct {
+	jobs []Job
+	stop chan struct{}
+	wg   sync.WaitGroup
+}
+
+func New() *Scheduler {
+	return &Scheduler{
+		stop: make(chan struct{}),
+	}
+}
+
+// Add registra um job; deve ser chamado antes de Start
+func (s *Scheduler) Add(job Job) {
+	s.jobs = append(s.jobs, job)
+}
+
+// Start inicia cada job em sua própria goroutine. A primeira execução
+// acontece imediatamente e as seguintes a cada Interval.
+func (s *Scheduler) Start() {
+	for _, job := range s.jobs {
+		if job.Interval <= 0 {
+			log.Printf("Scheduler: job %s ignorado (intervalo inválido)", job.Name)
+			continue
+		}
+
+		s.wg.Add(1)
+		go s.loop(job)
+	}
+}
+
+// Stop sinaliza o encerramento e aguarda as execuções em andamento
+func (s *Scheduler) Stop() {
+	close(s.stop)
+	s.wg.Wait()
+}
+
+func (s *Scheduler) loop(job Job) {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(job.Interval)
+	defer ticker.Stop()
+
+	s.runJob(job)
+
+	for {
+		select {
+		case <-ticker.C:
+			s.runJob(job)
+		case <-s.stop:
+			return
+		}
+	}
+}
+
+func (s *Scheduler) runJob(job Job) {
+	// Um panic em um job não deve derrubar o servidor
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("Scheduler: job %s entrou em panic: %v", job.Name, r)
+		}
+	}()
+
+	if err := job.Run(); err != nil {
+		log.Printf("Scheduler: job %s falhou: %v", job.Name, err)
+	}
+}
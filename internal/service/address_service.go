@@ -0,0 +1,112 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hscHeric/go-potential-api/internal/domain"
+	"github.com/hscHeric/go-potential-api/pkg/cep"
+)
+
+// ErrInvalidAddress indica que o endereço informado é inconsistente com o CEP
+var ErrInvalidAddress = errors.New("endereço inválido")
+
+type AddressService interface {
+	LookupCEP(code string) (*cep.Address, error)
+	ValidateAddress(address *domain.Address) error
+}
+
+type addressService struct {
+	cepProvider cep.Provider
+}
+
+func NewAddressService(cepProvider cep.Provider) AddressService {
+	return &addressService{
+		cepProvider: cepProvider,
+	}
+}
+
+func (s *addressService) LookupCEP(code string) (*cep.Address, error) {
+	return s.cepProvider.Lookup(code)
+}
+
+// ValidateAddress verifica se CEP, UF e cidade são consistentes entre si.
+// A UF é conferida pela faixa de CEP (sem consulta externa); a cidade é
+// conferida pelo provider. Se o provider estiver indisponível, ou se o CEP
+// não constar da base local (que pode estar incompleta), apenas a checagem
+// de faixa é aplicada para não bloquear o cadastro.
+// O CEP é normalizado para apenas dígitos.
+func (s *addressService) ValidateAddress(address *domain.Address) error {
+	if !isBrazilianAddress(address.Country) {
+		return nil
+	}
+
+	digits, err := cep.Normalize(address.ZipCode)
+	if err != nil {
+		return fmt.Errorf("%w: CEP deve conter 8 dígitos", ErrInvalidAddress)
+	}
+	address.ZipCode = digits
+	address.State = strings.ToUpper(strings.TrimSpace(address.State))
+
+	expectedState, ok := cep.StateForCEP(digits)
+	if !ok {
+		return fmt.Errorf("%w: CEP fora das faixas conhecidas", ErrInvalidAddress)
+	}
+
+	if expectedState != address.State {
+		return fmt.Errorf("%w: o CEP %s pertence a %s, não a %s", ErrInvalidAddress, digits, expectedState, address.State)
+	}
+
+	found, err := s.cepProvider.Lookup(digits)
+	if err != nil {
+		if errors.Is(err, cep.ErrNotInDataset) {
+			fmt.Printf("Aviso: CEP %s ausente da base local, validando apenas a UF\n", digits)
+			return nil
+		}
+		if errors.Is(err, cep.ErrNotFound) {
+			return fmt.Errorf("%w: CEP não encontrado", ErrInvalidAddress)
+		}
+		fmt.Printf("Aviso: falha ao consultar o CEP %s, validando apenas a UF: %v\n", digits, err)
+		return nil
+	}
+
+	if found.State != "" && found.State != address.State {
+		return fmt.Errorf("%w: o CEP %s pertence a %s, não a %s", ErrInvalidAddress, digits, found.State, address.State)
+	}
+
+	if found.City != "" && !sameName(found.City, address.City) {
+		return fmt.Errorf("%w: o CEP %s pertence à cidade %s", ErrInvalidAddress, digits, found.City)
+	}
+
+	return nil
+}
+
+// isBrazilianAddress verifica se o país informado é o Brasil
+func isBrazilianAddress(country string) bool {
+	switch foldName(country) {
+	case "br", "bra", "brasil", "brazil":
+		return true
+	}
+	return false
+}
+
+// sameName compara nomes ignorando caixa e acentos ("São Paulo" == "sao paulo")
+func sameName(a, b string) bool {
+	return foldName(a) == foldName(b)
+}
+
+// accentReplacer remove os acentos usados em nomes de cidades brasileiras
+var accentReplacer = strings.NewReplacer(
+	"á", "a", "à", "a", "â", "a", "ã", "a", "ä", "a",
+	"é", "e", "è", "e", "ê", "e", "ë", "e",
+	"í", "i", "ì", "i", "î", "i", "ï", "i",
+	"ó", "o", "ò", "o", "ô", "o", "õ", "o", "ö", "o",
+	"ú", "u", "ù", "u", "û", "u", "ü", "u",
+	"ç", "c", "ñ", "n",
+)
+
+func foldName(s string) string {
+	folded := accentReplacer.Replace(strings.ToLower(s))
+	return strings.Join(strings.Fields(folded), " ")
+}
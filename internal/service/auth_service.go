@@ -11,7 +11,9 @@ import (
 	"github.com/hscHeric/go-potential-api/pkg/email"
 	"github.com/hscHeric/go-potential-api/pkg/hash"
 	"github.com/hscHeric/go-potential-api/pkg/jwt"
+	"github.com/hscHeric/go-potential-api/pkg/timezone"
 	"github.com/hscHeric/go-potential-api/pkg/token"
+	"github.com/hscHeric/go-potential-api/pkg/validator"
 )
 
 var (
@@ -20,6 +22,7 @@ var (
 	ErrTokenExpired         = errors.New("o token expirou")
 	ErrTokenAlreadyUsed     = errors.New("o token já foi utilizado")
 	ErrUserAlreadyCompleted = errors.New("o registro do usuário já foi concluído")
+	ErrInvalidCPF           = errors.New("CPF inválido")
 )
 
 type AuthService interface {
@@ -45,11 +48,12 @@ type AuthService interface {
 
 type CompleteRegistrationInput struct {
 	FullName  string         `json:"full_name" binding:"required,min=3"`
-	CPF       string         `json:"cpf" binding:"required,len=11"`
+	CPF       string         `json:"cpf" binding:"required,cpf"`
 	BirthDate time.Time      `json:"birth_date" binding:"required"`
 	Address   domain.Address `json:"address" binding:"required"`
 	Contact   domain.Contact `json:"contact" binding:"required"`
 	Password  string         `json:"password" binding:"required,min=8"`
+	Timezone  string         `json:"timezone"` // Nome IANA; padrão America/Sao_Paulo
 }
 
 type LoginResponse struct {
@@ -62,6 +66,7 @@ type authService struct {
 	userRepo                repository.UserRepository
 	activationTokenRepo     repository.ActivationTokenRepository
 	passwordResetRepo       repository.PasswordResetTokenRepository
+	addressService          AddressService
 	jwtService              *jwt.Service
 	emailService            *email.Service
 	activationExpiration    time.Duration
@@ -73,6 +78,7 @@ func NewAuthService(
 	userRepo repository.UserRepository,
 	activationTokenRepo repository.ActivationTokenRepository,
 	passwordResetRepo repository.PasswordResetTokenRepository,
+	addressService AddressService,
 	jwtService *jwt.Service,
 	emailService *email.Service,
 	activationExpiration time.Duration,
@@ -83,6 +89,7 @@ func NewAuthService(
 		userRepo:                userRepo,
 		activationTokenRepo:     activationTokenRepo,
 		passwordResetRepo:       passwordResetRepo,
+		addressService:          addressService,
 		jwtService:              jwtService,
 		emailService:            emailService,
 		activationExpiration:    activationExpiration,
@@ -171,6 +178,30 @@ func (s *authService) CompleteRegistration(activationToken string, userData *Com
 		return ErrUserAlreadyCompleted
 	}
 
+	// CPF é armazenado apenas com dígitos
+	userData.CPF = validator.NormalizeCPF(userData.CPF)
+	if !validator.IsValidCPF(userData.CPF) {
+		return ErrInvalidCPF
+	}
+
+	// Telefones são armazenados em E.164
+	if err := normalizeContact(&userData.Contact); err != nil {
+		return err
+	}
+
+	// Validar consistência entre CEP, UF e cidade
+	if err := s.addressService.ValidateAddress(&userData.Address); err != nil {
+		return err
+	}
+
+	// Fuso usado para exibir horários de aulas e nos e-mails
+	if userData.Timezone == "" {
+		userData.Timezone = timezone.Default
+	}
+	if _, err := timezone.Load(userData.Timezone); err != nil {
+		return err
+	}
+
 	// Verificar se CPF já existe
 	cpfExists, err := s.userRepo.ExistsByCPF(userData.CPF)
 	if err != nil {
@@ -193,12 +224,14 @@ func (s *authService) CompleteRegistration(activationToken string, userData *Com
 
 	// Criar user com informações pessoais
 	user := &domain.User{
-		AuthID:    auth.ID,
-		FullName:  userData.FullName,
-		CPF:       userData.CPF,
-		BirthDate: userData.BirthDate,
-		Address:   userData.Address,
-		Contact:   userData.Contact,
+		AuthID:         auth.ID,
+		FullName:       userData.FullName,
+		CPF:            userData.CPF,
+		BirthDate:      userData.BirthDate,
+		Address:        userData.Address,
+		Contact:        userData.Contact,
+		Timezone:       userData.Timezone,
+		ClassReminders: true,
 	}
 
 	if err := s.userRepo.Create(user); err != nil {
@@ -0,0 +1,141 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hscHeric/go-potential-api/internal/domain"
+	"github.com/hscHeric/go-potential-api/internal/repository"
+)
+
+type BlackoutService interface {
+	CreateBlackout(teacherID, createdBy uuid.UUID, input *CreateBlackoutInput) (*domain.BlackoutReport, error)
+	GetBlackout(teacherID, id uuid.UUID) (*domain.BlackoutReport, error)
+	GetTeacherBlackouts(teacherID uuid.UUID, from, to time.Time) ([]domain.TeacherBlackout, error)
+	DeleteBlackout(teacherID, id uuid.UUID) error
+}
+
+type CreateBlackoutInput struct {
+	StartDate string  `json:"start_date" binding:"required"` // Format: "2006-01-02"
+	EndDate   string  `json:"end_date" binding:"required"`   // Format: "2006-01-02"
+	Reason    *string `json:"reason"`
+}
+
+type blackoutService struct {
+	blackoutRepo repository.BlackoutRepository
+	classRepo    repository.ClassRepository
+	authRepo     repository.AuthRepository
+}
+
+func NewBlackoutService(
+	blackoutRepo repository.BlackoutRepository,
+	classRepo repository.ClassRepository,
+	authRepo repository.AuthRepository,
+) BlackoutService {
+	return &blackoutService{
+		blackoutRepo: blackoutRepo,
+		classRepo:    classRepo,
+		authRepo:     authRepo,
+	}
+}
+
+// CreateBlackout registra o período de indisponibilidade e lista as aulas já
+// agendadas nele, que precisam ser remarcadas, passadas a outro professor ou canceladas
+func (s *blackoutService) CreateBlackout(teacherID, createdBy uuid.UUID, input *CreateBlackoutInput) (*domain.BlackoutReport, error) {
+	auth, err := s.authRepo.GetByID(teacherID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get auth: %w", err)
+	}
+
+	if auth.Role != domain.RoleTeacher {
+		return nil, errors.New("blackouts can only be created for teachers")
+	}
+
+	startDate, err := time.Parse(dateLayout, input.StartDate)
+	if err != nil {
+		return nil, errors.New("invalid start_date (use YYYY-MM-DD)")
+	}
+
+	endDate, err := time.Parse(dateLayout, input.EndDate)
+	if err != nil {
+		return nil, errors.New("invalid end_date (use YYYY-MM-DD)")
+	}
+
+	if endDate.Before(startDate) {
+		return nil, fmt.Errorf("%w: end_date must not be before start_date", ErrInvalidDateRange)
+	}
+
+	if input.EndDate < time.Now().Format(dateLayout) {
+		return nil, errors.New("cannot create a blackout in the past")
+	}
+
+	blackout := &domain.TeacherBlackout{
+		TeacherID: teacherID,
+		StartDate: startDate,
+		EndDate:   endDate,
+		Reason:    input.Reason,
+		CreatedBy: createdBy,
+	}
+
+	if err := s.blackoutRepo.Create(blackout); err != nil {
+		return nil, err
+	}
+
+	return s.buildReport(blackout)
+}
+
+func (s *blackoutService) GetBlackout(teacherID, id uuid.UUID) (*domain.BlackoutReport, error) {
+	blackout, err := s.blackoutRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if blackout.TeacherID != teacherID {
+		return nil, repository.ErrNotFound
+	}
+
+	return s.buildReport(blackout)
+}
+
+func (s *blackoutService) GetTeacherBlackouts(teacherID uuid.UUID, from, to time.Time) ([]domain.TeacherBlackout, error) {
+	if to.Before(from) {
+		return nil, fmt.Errorf("%w: 'to' must not be before 'from'", ErrInvalidDateRange)
+	}
+
+	return s.blackoutRepo.GetByTeacher(teacherID, from, to)
+}
+
+func (s *blackoutService) DeleteBlackout(teacherID, id uuid.UUID) error {
+	blackout, err := s.blackoutRepo.GetByID(id)
+	if err != nil {
+		return err
+	}
+
+	if blackout.TeacherID != teacherID {
+		return repository.ErrNotFound
+	}
+
+	return s.blackoutRepo.Delete(id)
+}
+
+// buildReport busca as aulas agendadas do professor que caem no período
+func (s *blackoutService) buildReport(blackout *domain.TeacherBlackout) (*domain.BlackoutReport, error) {
+	classes, err := s.classRepo.GetByTeacher(blackout.TeacherID, blackout.StartDate, blackout.EndDate, domain.ClassFilter{})
+	if err != nil {
+		return nil, err
+	}
+
+	affected := []domain.Class{}
+	for _, class := range classes {
+		if class.Status == domain.ClassStatusScheduled {
+			affected = append(affected, class)
+		}
+	}
+
+	return &domain.BlackoutReport{
+		Blackout:        *blackout,
+		AffectedClasses: affected,
+	}, nil
+}
@@ -0,0 +1,204 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hscHeric/go-potential-api/internal/domain"
+	"github.com/hscHeric/go-potential-api/internal/repository"
+	"github.com/hscHeric/go-potential-api/pkg/ical"
+	"github.com/hscHeric/go-potential-api/pkg/token"
+)
+
+// ErrFeedNotAvailable indica um papel sem agenda de aulas (ex.: admin)
+var ErrFeedNotAvailable = errors.New("calendar feeds are only available to teachers and students")
+
+// Janela de aulas incluídas no feed, a partir de hoje
+const (
+	feedPastDays   = 60
+	feedFutureDays = 365
+)
+
+const icalProdID = "-//Potential Idiomas//Aulas//PT"
+
+type CalendarFeedService interface {
+	GetFeed(authID uuid.UUID) (*domain.CalendarFeed, error)
+	RotateFeed(authID uuid.UUID) (*domain.CalendarFeed, error)
+	RevokeFeed(authID uuid.UUID) error
+	RenderFeed(feedToken string) ([]byte, error)
+}
+
+type calendarFeedService struct {
+	feedRepo  repository.CalendarFeedRepository
+	classRepo repository.ClassRepository
+	authRepo  repository.AuthRepository
+	timezones TimezoneService
+	baseURL   string
+}
+
+func NewCalendarFeedService(
+	feedRepo repository.CalendarFeedRepository,
+	classRepo repository.ClassRepository,
+	authRepo repository.AuthRepository,
+	timezones TimezoneService,
+	baseURL string,
+) CalendarFeedService {
+	return &calendarFeedService{
+		feedRepo:  feedRepo,
+		classRepo: classRepo,
+		authRepo:  authRepo,
+		timezones: timezones,
+		baseURL:   strings.TrimRight(baseURL, "/"),
+	}
+}
+
+func (s *calendarFeedService) GetFeed(authID uuid.UUID) (*domain.CalendarFeed, error) {
+	feed, err := s.feedRepo.GetByAuthID(authID)
+	if err != nil {
+		return nil, err
+	}
+
+	feed.URL = s.feedURL(feed.Token)
+	return feed, nil
+}
+
+// RotateFeed cria o feed do usuário ou troca o token, invalidando a URL anterior
+func (s *calendarFeedService) RotateFeed(authID uuid.UUID) (*domain.CalendarFeed, error) {
+	auth, err := s.authRepo.GetByID(authID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get auth: %w", err)
+	}
+
+	if auth.Role != domain.RoleTeacher && auth.Role != domain.RoleStudent {
+		return nil, ErrFeedNotAvailable
+	}
+
+	// 30 bytes geram 40 caracteres base64 sem padding
+	feedToken, err := token.Generate(30)
+	if err != nil {
+		return nil, err
+	}
+
+	feed := &domain.CalendarFeed{
+		AuthID: authID,
+		Token:  feedToken,
+	}
+
+	if err := s.feedRepo.Save(feed); err != nil {
+		return nil, err
+	}
+
+	feed.URL = s.feedURL(feed.Token)
+	return feed, nil
+}
+
+func (s *calendarFeedService) RevokeFeed(authID uuid.UUID) error {
+	return s.feedRepo.Delete(authID)
+}
+
+// RenderFeed gera o calendário do dono do token com as aulas da janela do feed.
+// Aulas canceladas ou excluídas e matrículas canceladas continuam no feed com
+// STATUS:CANCELLED para sumirem dos clientes.
+func (s *calendarFeedService) RenderFeed(feedToken string) ([]byte, error) {
+	feed, err := s.feedRepo.GetByToken(feedToken)
+	if err != nil {
+		return nil, err
+	}
+
+	auth, err := s.authRepo.GetByID(feed.AuthID)
+	if err != nil {
+		return nil, err
+	}
+
+	if auth.Status != domain.StatusActive {
+		return nil, repository.ErrNotFound
+	}
+
+	today := s.timezones.Today()
+	from := today.AddDate(0, 0, -feedPastDays)
+	to := today.AddDate(0, 0, feedFutureDays)
+
+	var classes []domain.Class
+	switch auth.Role {
+	case domain.RoleTeacher:
+		classes, err = s.classRepo.GetFeedByTeacher(auth.ID, from, to)
+	case domain.RoleStudent:
+		classes, err = s.classRepo.GetFeedByStudent(auth.ID, from, to)
+	default:
+		return nil, ErrFeedNotAvailable
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	calendar := ical.Calendar{
+		ProdID: icalProdID,
+		Name:   "Potential Idiomas - Aulas",
+		Method: "PUBLISH",
+	}
+	for i := range classes {
+		calendar.Events = append(calendar.Events, classEvent(&classes[i]))
+	}
+
+	if err := s.feedRepo.MarkAccessed(feed.ID); err != nil {
+		fmt.Printf("Warning: failed to mark calendar feed %s as accessed: %v\n", feed.ID, err)
+	}
+
+	return calendar.Encode(time.Now()), nil
+}
+
+func (s *calendarFeedService) feedURL(feedToken string) string {
+	return fmt.Sprintf("%s/api/v1/feeds/%s.ics", s.baseURL, feedToken)
+}
+
+// classEvent converte a aula em um VEVENT. O UID é estável para que os clientes
+// atualizem o mesmo evento quando a aula muda.
+func classEvent(class *domain.Class) ical.Event {
+	summary := "Aula - Potential Idiomas"
+	if class.Title != nil && *class.Title != "" {
+		summary = *class.Title
+	}
+
+	var description []string
+	if class.Description != nil && *class.Description != "" {
+		description = append(description, *class.Description)
+	}
+
+	event := ical.Event{
+		UID:          class.ID.String() + "@potential-idiomas",
+		Start:        class.StartsAt,
+		End:          class.EndsAt,
+		Summary:      summary,
+		Status:       ical.StatusConfirmed,
+		Sequence:     int(class.UpdatedAt.Sub(class.CreatedAt) / time.Second), // Cresce a cada alteração
+		Created:      class.CreatedAt,
+		LastModified: class.UpdatedAt,
+	}
+
+	if class.ClassLink != nil && *class.ClassLink != "" {
+		event.URL = *class.ClassLink
+		event.Location = *class.ClassLink
+		description = append(description, "Link da aula: "+*class.ClassLink)
+	}
+
+	event.Description = strings.Join(description, "\n\n")
+
+	if class.Status == domain.ClassStatusCancelled {
+		event.Status = ical.StatusCancelled
+	}
+
+	return event
+}
+
+// classCalendar gera o .ics de uma única aula, anexado aos emails de notificação
+func classCalendar(class *domain.Class) []byte {
+	calendar := ical.Calendar{
+		ProdID: icalProdID,
+		Method: "PUBLISH",
+		Events: []ical.Event{classEvent(class)},
+	}
+	return calendar.Encode(time.Now())
+}
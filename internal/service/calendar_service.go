@@ -0,0 +1,277 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hscHeric/go-potential-api/internal/domain"
+	"github.com/hscHeric/go-potential-api/internal/repository"
+)
+
+var (
+	// ErrCalendarTeacherRequired indica uma consulta de admin sem o professor a exibir
+	ErrCalendarTeacherRequired = errors.New("teacher_id is required to view a teacher calendar")
+	// ErrCalendarForbidden indica um professor consultando a agenda de outro professor
+	ErrCalendarForbidden = errors.New("teachers can only view their own calendar")
+)
+
+// CalendarService monta a agenda de um período a partir das aulas, dos horários livres,
+// das folgas do professor e dos feriados
+type CalendarService interface {
+	GetCalendar(viewerID uuid.UUID, input *CalendarInput) (*domain.Calendar, error)
+}
+
+type CalendarInput struct {
+	View      domain.CalendarView
+	Date      time.Time      // Qualquer data do período (meia-noite UTC, como as colunas DATE)
+	TeacherID *uuid.UUID     // Admins: professor exibido; alunos: professor cujos horários livres aparecem
+	Location  *time.Location // Fuso de quem consulta
+}
+
+type calendarService struct {
+	classRepo       repository.ClassRepository
+	blackoutRepo    repository.BlackoutRepository
+	authRepo        repository.AuthRepository
+	timeSlotService TimeSlotService
+	holidays        HolidayChecker
+}
+
+func NewCalendarService(
+	classRepo repository.ClassRepository,
+	blackoutRepo repository.BlackoutRepository,
+	authRepo repository.AuthRepository,
+	timeSlotService TimeSlotService,
+	holidays HolidayChecker,
+) CalendarService {
+	return &calendarService{
+		classRepo:       classRepo,
+		blackoutRepo:    blackoutRepo,
+		authRepo:        authRepo,
+		timeSlotService: timeSlotService,
+		holidays:        holidays,
+	}
+}
+
+// GetCalendar retorna os eventos do período ordenados por data. Professores veem as
+// próprias aulas, horários livres e folgas; admins veem os de qualquer professor. Alunos
+// veem as próprias aulas e, informando um professor, os horários livres dele.
+// Feriados aparecem para todos.
+func (s *calendarService) GetCalendar(viewerID uuid.UUID, input *CalendarInput) (*domain.Calendar, error) {
+	viewer, err := s.authRepo.GetByID(viewerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get auth: %w", err)
+	}
+
+	from, to := calendarPeriod(input.View, input.Date)
+
+	teacherID, err := s.calendarTeacher(viewer, input.TeacherID)
+	if err != nil {
+		return nil, err
+	}
+
+	calendar := &domain.Calendar{
+		View:      input.View,
+		From:      from.Format(dateLayout),
+		To:        to.Format(dateLayout),
+		Timezone:  input.Location.String(),
+		TeacherID: teacherID,
+		Events:    []domain.CalendarEvent{},
+	}
+
+	var classes []domain.Class
+	if viewer.Role == domain.RoleStudent {
+		classes, err = s.classRepo.GetByStudent(viewerID, from, to, domain.ClassFilter{})
+	} else {
+		classes, err = s.classRepo.GetByTeacher(*teacherID, from, to, domain.ClassFilter{})
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	listed := make(map[uuid.UUID]bool, len(classes))
+	for _, class := range classes {
+		listed[class.ID] = true
+		calendar.Events = append(calendar.Events, calendarClassEvent(class, input.Location))
+	}
+
+	if teacherID != nil {
+		openings, err := s.timeSlotService.GetOpenings(*teacherID, from, to)
+		if err != nil {
+			return nil, err
+		}
+
+		// A turma já exibida como aula não se repete como horário livre
+		for _, opening := range openings {
+			if opening.ClassID != nil && listed[*opening.ClassID] {
+				continue
+			}
+			calendar.Events = append(calendar.Events, calendarOpeningEvent(opening, input.Location))
+		}
+	}
+
+	// Folgas são exibidas apenas na agenda do próprio professor (ou para admins)
+	if teacherID != nil && viewer.Role != domain.RoleStudent {
+		blackouts, err := s.blackoutRepo.GetByTeacher(*teacherID, from, to)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, blackout := range blackouts {
+			calendar.Events = append(calendar.Events, calendarBlackoutEvent(blackout))
+		}
+	}
+
+	holidays, err := s.holidays.InRange(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, holiday := range holidays {
+		calendar.Events = append(calendar.Events, calendarHolidayEvent(holiday))
+	}
+
+	sortCalendarEvents(calendar.Events)
+
+	return calendar, nil
+}
+
+// calendarTeacher define o professor cuja agenda é exibida (nil para alunos sem professor)
+func (s *calendarService) calendarTeacher(viewer *domain.Auth, requested *uuid.UUID) (*uuid.UUID, error) {
+	switch viewer.Role {
+	case domain.RoleTeacher:
+		if requested != nil && *requested != viewer.ID {
+			return nil, ErrCalendarForbidden
+		}
+		return &viewer.ID, nil
+	case domain.RoleAdmin:
+		if requested == nil {
+			return nil, ErrCalendarTeacherRequired
+		}
+	default:
+		if requested == nil {
+			return nil, nil
+		}
+	}
+
+	teacher, err := s.authRepo.GetByID(*requested)
+	if err != nil {
+		return nil, err
+	}
+
+	if teacher.Role != domain.RoleTeacher {
+		return nil, repository.ErrNotFound
+	}
+
+	return requested, nil
+}
+
+// calendarPeriod retorna o primeiro e o último dia da semana (segunda a domingo) ou do mês da data
+func calendarPeriod(view domain.CalendarView, date time.Time) (time.Time, time.Time) {
+	if view == domain.CalendarViewMonth {
+		from := time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, time.UTC)
+		return from, from.AddDate(0, 1, -1)
+	}
+
+	offset := (int(date.Weekday()) + 6) % 7
+	from := date.AddDate(0, 0, -offset)
+	return from, from.AddDate(0, 0, 6)
+}
+
+func calendarClassEvent(class domain.Class, loc *time.Location) domain.CalendarEvent {
+	class.Localize(loc)
+
+	title := "Aula"
+	if class.Title != nil && *class.Title != "" {
+		title = *class.Title
+	}
+
+	status := class.Status
+	return domain.CalendarEvent{
+		Type:       domain.CalendarEventClass,
+		ColorKey:   "class_" + string(class.Status),
+		Title:      title,
+		StartDate:  class.StartsAt.Format(dateLayout),
+		EndDate:    class.EndsAt.Format(dateLayout),
+		StartsAt:   &class.StartsAt,
+		EndsAt:     &class.EndsAt,
+		TeacherID:  &class.TeacherID,
+		ClassID:    &class.ID,
+		TimeSlotID: class.TimeSlotID,
+		Status:     &status,
+	}
+}
+
+func calendarOpeningEvent(opening domain.Opening, loc *time.Location) domain.CalendarEvent {
+	opening.Localize(loc)
+
+	// Ocorrências com turma iniciada têm cor própria, para indicar que já há alunos
+	colorKey := "opening"
+	if opening.BookedStudents > 0 {
+		colorKey = "opening_partial"
+	}
+
+	remaining := opening.RemainingCapacity
+	return domain.CalendarEvent{
+		Type:              domain.CalendarEventOpening,
+		ColorKey:          colorKey,
+		Title:             "Horário livre",
+		StartDate:         opening.StartsAt.Format(dateLayout),
+		EndDate:           opening.EndsAt.Format(dateLayout),
+		StartsAt:          &opening.StartsAt,
+		EndsAt:            &opening.EndsAt,
+		TeacherID:         &opening.TeacherID,
+		ClassID:           opening.ClassID,
+		TimeSlotID:        &opening.TimeSlotID,
+		RemainingCapacity: &remaining,
+	}
+}
+
+func calendarBlackoutEvent(blackout domain.TeacherBlackout) domain.CalendarEvent {
+	title := "Folga"
+	if blackout.Reason != nil && *blackout.Reason != "" {
+		title = *blackout.Reason
+	}
+
+	return domain.CalendarEvent{
+		Type:       domain.CalendarEventBlackout,
+		ColorKey:   "blackout",
+		Title:      title,
+		AllDay:     true,
+		StartDate:  blackout.StartDate.Format(dateLayout),
+		EndDate:    blackout.EndDate.Format(dateLayout),
+		TeacherID:  &blackout.TeacherID,
+		BlackoutID: &blackout.ID,
+	}
+}
+
+func calendarHolidayEvent(holiday domain.Holiday) domain.CalendarEvent {
+	return domain.CalendarEvent{
+		Type:      domain.CalendarEventHoliday,
+		ColorKey:  "holiday",
+		Title:     holiday.Name,
+		AllDay:    true,
+		StartDate: holiday.Date.Format(dateLayout),
+		EndDate:   holiday.Date.Format(dateLayout),
+		HolidayID: &holiday.ID,
+	}
+}
+
+// sortCalendarEvents ordena por data, com os eventos de dia inteiro antes dos com horário
+func sortCalendarEvents(events []domain.CalendarEvent) {
+	sort.SliceStable(events, func(i, j int) bool {
+		a, b := events[i], events[j]
+		if a.StartDate != b.StartDate {
+			return a.StartDate < b.StartDate
+		}
+		if a.AllDay != b.AllDay {
+			return a.AllDay
+		}
+		if a.AllDay {
+			return a.Title < b.Title
+		}
+		return a.StartsAt.Before(*b.StartsAt)
+	})
+}
@@ -0,0 +1,171 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hscHeric/go-potential-api/internal/domain"
+	"github.com/hscHeric/go-potential-api/internal/repository"
+	"github.com/hscHeric/go-potential-api/pkg/email"
+)
+
+// ClassLifecycleService encerra as aulas que já terminaram e cobra dos professores
+// a chamada pendente
+type ClassLifecycleService interface {
+	Run() (*ClassLifecycleResult, error)
+}
+
+type ClassLifecycleResult struct {
+	Completed int `json:"completed"`
+	NoShow    int `json:"no_show"`
+	Reminded  int `json:"reminded"`
+}
+
+type classLifecycleService struct {
+	classRepo     repository.ClassRepository
+	userRepo      repository.UserRepository
+	authRepo      repository.AuthRepository
+	credits       CreditService
+	emailService  *email.Service
+	timezones     TimezoneService
+	reminderAfter time.Duration
+	closeAfter    time.Duration
+}
+
+func NewClassLifecycleService(
+	classRepo repository.ClassRepository,
+	userRepo repository.UserRepository,
+	authRepo repository.AuthRepository,
+	credits CreditService,
+	emailService *email.Service,
+	timezones TimezoneService,
+	reminderAfter time.Duration,
+	closeAfter time.Duration,
+) ClassLifecycleService {
+	return &classLifecycleService{
+		classRepo:     classRepo,
+		userRepo:      userRepo,
+		authRepo:      authRepo,
+		credits:       credits,
+		emailService:  emailService,
+		timezones:     timezones,
+		reminderAfter: reminderAfter,
+		closeAfter:    closeAfter,
+	}
+}
+
+// Run percorre as aulas agendadas que já terminaram. A aula é encerrada quando a chamada
+// está completa ou closeAfter depois do fim: completed se algum aluno esteve presente,
+// no_show caso contrário. Enquanto isso, reminderAfter depois do fim o professor recebe
+// um único lembrete da chamada pendente.
+func (s *classLifecycleService) Run() (*ClassLifecycleResult, error) {
+	now := time.Now()
+
+	summaries, err := s.classRepo.GetEndedAttendance(now)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ClassLifecycleResult{}
+	for _, summary := range summaries {
+		ended := now.Sub(summary.EndsAt)
+
+		if summary.Pending == 0 || ended >= s.closeAfter {
+			status := domain.ClassStatusNoShow
+			if summary.Attended > 0 {
+				status = domain.ClassStatusCompleted
+			}
+
+			if err := s.classRepo.TransitionStatus(summary.ClassID, domain.ClassStatusScheduled, status); err != nil {
+				// A aula mudou de status desde a consulta (ex.: foi cancelada)
+				if errors.Is(err, repository.ErrNotFound) {
+					continue
+				}
+				return result, err
+			}
+
+			// Aula encerrada, com ou sem presença, consome o crédito dos matriculados
+			s.credits.ChargeEnrolled(summary.ClassID)
+
+			if status == domain.ClassStatusCompleted {
+				result.Completed++
+			} else {
+				result.NoShow++
+			}
+			continue
+		}
+
+		if summary.AttendanceRemindedAt == nil && ended >= s.reminderAfter {
+			if err := s.remindAttendance(summary); err != nil {
+				fmt.Printf("Warning: failed to remind attendance of class %s: %v\n", summary.ClassID, err)
+				continue
+			}
+			result.Reminded++
+		}
+	}
+
+	return result, nil
+}
+
+// remindAttendance envia o lembrete ao professor e marca a aula para não lembrar de novo
+func (s *classLifecycleService) remindAttendance(summary domain.ClassAttendanceSummary) error {
+	class, err := s.classRepo.GetByID(summary.ClassID)
+	if err != nil {
+		return err
+	}
+
+	auth, err := s.authRepo.GetByID(summary.TeacherID)
+	if err != nil {
+		return fmt.Errorf("failed to get auth: %w", err)
+	}
+
+	name := auth.Email
+	if user, err := s.userRepo.GetByAuthID(summary.TeacherID); err == nil {
+		name = user.FullName
+	}
+
+	schedule := localSchedule(class, s.timezones.ForUser(summary.TeacherID))
+
+	title := ""
+	if class.Title != nil {
+		title = fmt.Sprintf("<p style=\"margin: 5px 0;\"><strong>Tema:</strong> %s</p>", *class.Title)
+	}
+
+	body := fmt.Sprintf(`
+<!DOCTYPE html>
+<html>
+<head>
+    <meta charset="UTF-8">
+    <title>Chamada pendente</title>
+</head>
+<body style="font-family: Arial, sans-serif; line-height: 1.6; color: #333;">
+    <div style="max-width: 600px; margin: 0 auto; padding: 20px;">
+        <h2 style="color: #3498db;">Chamada pendente</h2>
+        <p>Olá, %s!</p>
+        <p>A aula abaixo já terminou e %d aluno(s) ainda estão sem presença registrada. Registre a chamada para que a aula seja encerrada corretamente.</p>
+        <div style="background-color: #f8f9fa; padding: 20px; border-radius: 5px; margin: 20px 0;">
+            <p style="margin: 5px 0;"><strong>Data:</strong> %s</p>
+            <p style="margin: 5px 0;"><strong>Horário:</strong> %s - %s (%s)</p>
+            %s
+        </div>
+        <p>Sem a chamada, a aula será encerrada automaticamente e, se nenhum aluno tiver presença, ficará como falta (no_show).</p>
+    </div>
+</body>
+</html>
+	`,
+		name,
+		summary.Pending,
+		schedule.Date,
+		schedule.StartTime,
+		schedule.EndTime,
+		schedule.Timezone,
+		title,
+	)
+
+	if err := s.emailService.SendCustomEmail(auth.Email, "Chamada pendente - Potential Idiomas", body); err != nil {
+		return err
+	}
+
+	return s.classRepo.MarkAttendanceReminded(summary.ClassID)
+}
@@ -0,0 +1,188 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hscHeric/go-potential-api/internal/domain"
+	"github.com/hscHeric/go-potential-api/internal/repository"
+	"github.com/hscHeric/go-potential-api/pkg/email"
+)
+
+// ClassReminderService envia lembretes por e-mail antes do início das aulas
+type ClassReminderService interface {
+	SendDue() (int, error)
+}
+
+type classReminderService struct {
+	reminderRepo repository.ClassReminderRepository
+	classRepo    repository.ClassRepository
+	fileRepo     repository.FileRepository
+	userRepo     repository.UserRepository
+	authRepo     repository.AuthRepository
+	emailService *email.Service
+	timezones    TimezoneService
+	offsets      []time.Duration
+}
+
+func NewClassReminderService(
+	reminderRepo repository.ClassReminderRepository,
+	classRepo repository.ClassRepository,
+	fileRepo repository.FileRepository,
+	userRepo repository.UserRepository,
+	authRepo repository.AuthRepository,
+	emailService *email.Service,
+	timezones TimezoneService,
+	offsets []time.Duration,
+) ClassReminderService {
+	// Da maior para a menor antecedência
+	sorted := append([]time.Duration(nil), offsets...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] > sorted[j] })
+
+	return &classReminderService{
+		reminderRepo: reminderRepo,
+		classRepo:    classRepo,
+		fileRepo:     fileRepo,
+		userRepo:     userRepo,
+		authRepo:     authRepo,
+		emailService: emailService,
+		timezones:    timezones,
+		offsets:      sorted,
+	}
+}
+
+// SendDue envia os lembretes pendentes e retorna quantos foram enviados. Cada
+// antecedência cobre as aulas que começam até ela e depois da antecedência menor
+// seguinte, então uma aula marcada em cima da hora recebe só o lembrete mais próximo.
+// O envio é registrado antes do e-mail, de modo que reinícios não geram duplicados.
+func (s *classReminderService) SendDue() (int, error) {
+	now := time.Now()
+	classes := make(map[uuid.UUID]*domain.Class)
+	sent := 0
+
+	for i, offset := range s.offsets {
+		var lower time.Duration
+		if i+1 < len(s.offsets) {
+			lower = s.offsets[i+1]
+		}
+
+		minutes := int(offset.Minutes())
+		targets, err := s.reminderRepo.GetDue(minutes, now.Add(lower), now.Add(offset))
+		if err != nil {
+			return sent, err
+		}
+
+		for _, target := range targets {
+			claimed, err := s.reminderRepo.Claim(target.ClassID, target.AuthID, minutes)
+			if err != nil {
+				return sent, err
+			}
+			if !claimed {
+				continue
+			}
+
+			class, ok := classes[target.ClassID]
+			if !ok {
+				if class, err = s.classRepo.GetByID(target.ClassID); err != nil {
+					fmt.Printf("Warning: failed to get class %s for reminder: %v\n", target.ClassID, err)
+					s.release(target, minutes)
+					continue
+				}
+				classes[target.ClassID] = class
+			}
+
+			if err := s.sendReminder(target.AuthID, class); err != nil {
+				fmt.Printf("Warning: failed to send reminder of class %s to %s: %v\n", target.ClassID, target.AuthID, err)
+				s.release(target, minutes)
+				continue
+			}
+			sent++
+		}
+	}
+
+	return sent, nil
+}
+
+// release libera o registro de um lembrete não enviado para a próxima execução
+func (s *classReminderService) release(target domain.ClassReminderTarget, minutes int) {
+	if err := s.reminderRepo.Release(target.ClassID, target.AuthID, minutes); err != nil {
+		fmt.Printf("Warning: failed to release reminder of class %s: %v\n", target.ClassID, err)
+	}
+}
+
+func (s *classReminderService) sendReminder(authID uuid.UUID, class *domain.Class) error {
+	auth, err := s.authRepo.GetByID(authID)
+	if err != nil {
+		return fmt.Errorf("failed to get auth: %w", err)
+	}
+
+	name := auth.Email
+	if user, err := s.userRepo.GetByAuthID(authID); err == nil {
+		name = user.FullName
+	}
+
+	schedule := localSchedule(class, s.timezones.ForUser(authID))
+
+	details := ""
+	if class.Title != nil {
+		details += fmt.Sprintf("<p style=\"margin: 5px 0;\"><strong>Tema:</strong> %s</p>", *class.Title)
+	}
+	if class.ClassLink != nil && *class.ClassLink != "" {
+		details += fmt.Sprintf("<p style=\"margin: 5px 0;\"><strong>Link da aula:</strong> <a href=\"%s\">%s</a></p>", *class.ClassLink, *class.ClassLink)
+	}
+	if class.MaterialID != nil {
+		if material, err := s.fileRepo.GetByID(*class.MaterialID); err == nil {
+			details += fmt.Sprintf("<p style=\"margin: 5px 0;\"><strong>Material:</strong> <a href=\"%s\">%s</a></p>", material.FileURL, material.OriginalFilename)
+		}
+	}
+
+	body := fmt.Sprintf(`
+<!DOCTYPE html>
+<html>
+<head>
+    <meta charset="UTF-8">
+    <title>Lembrete de aula</title>
+</head>
+<body style="font-family: Arial, sans-serif; line-height: 1.6; color: #333;">
+    <div style="max-width: 600px; margin: 0 auto; padding: 20px;">
+        <h2 style="color: #3498db;">Lembrete de aula</h2>
+        <p>Olá, %s!</p>
+        <p>Sua aula começa em %s.</p>
+        <div style="background-color: #f8f9fa; padding: 20px; border-radius: 5px; margin: 20px 0;">
+            <p style="margin: 5px 0;"><strong>Data:</strong> %s</p>
+            <p style="margin: 5px 0;"><strong>Horário:</strong> %s - %s (%s)</p>
+            %s
+        </div>
+        <p style="font-size: 12px; color: #777;">Para não receber mais lembretes, desative-os no seu perfil.</p>
+    </div>
+</body>
+</html>
+	`,
+		name,
+		reminderLead(time.Until(class.StartsAt)),
+		schedule.Date,
+		schedule.StartTime,
+		schedule.EndTime,
+		schedule.Timezone,
+		details,
+	)
+
+	return s.emailService.SendCustomEmail(auth.Email, "Lembrete de aula - Potential Idiomas", body, email.CalendarAttachment(classCalendar(class))...)
+}
+
+// reminderLead descreve o tempo até o início da aula ("24 horas", "45 minutos")
+func reminderLead(until time.Duration) string {
+	minutes := int(until.Round(time.Minute).Minutes())
+	if minutes >= 120 {
+		return fmt.Sprintf("%d horas", (minutes+30)/60)
+	}
+	if minutes >= 60 {
+		return "1 hora"
+	}
+	if minutes <= 1 {
+		return "1 minuto"
+	}
+	return fmt.Sprintf("%d minutos", minutes)
+}
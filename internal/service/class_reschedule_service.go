@@ -0,0 +1,434 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hscHeric/go-potential-api/internal/domain"
+	"github.com/hscHeric/go-potential-api/internal/repository"
+	"github.com/hscHeric/go-potential-api/pkg/email"
+)
+
+type ClassRescheduleService interface {
+	ProposeReschedule(classID, proposedBy uuid.UUID, input *RescheduleClassInput) (*domain.ClassReschedule, error)
+	RespondReschedule(classID, studentID uuid.UUID, accepted bool) (*domain.ClassReschedule, error)
+	GetRescheduleHistory(classID uuid.UUID) ([]domain.ClassReschedule, error)
+}
+
+type RescheduleClassInput struct {
+	ScheduledDate string  `json:"scheduled_date" binding:"required"` // Format: "2006-01-02"
+	StartTime     string  `json:"start_time" binding:"required"`
+	EndTime       string  `json:"end_time" binding:"required"`
+	Timezone      *string `json:"timezone"` // Fuso IANA de data/horários informados; padrão é o da escola
+	Reason        *string `json:"reason"`
+}
+
+// ScheduleConflictError indica que o professor ou a sala não estão livres no horário pedido.
+// Conflitos de alunos são retornados como StudentConflictError.
+type ScheduleConflictError struct {
+	TeacherUnavailable bool
+	RoomUnavailable    bool
+}
+
+func (e *ScheduleConflictError) Error() string {
+	parts := []string{}
+	if e.TeacherUnavailable {
+		parts = append(parts, "teacher not available at this time")
+	}
+	if e.RoomUnavailable {
+		parts = append(parts, "room not available at this time")
+	}
+	return "schedule conflict: " + strings.Join(parts, "; ")
+}
+
+type classRescheduleService struct {
+	rescheduleRepo   repository.ClassRescheduleRepository
+	classRepo        repository.ClassRepository
+	classStudentRepo repository.ClassStudentRepository
+	roomRepo         repository.RoomRepository
+	userRepo         repository.UserRepository
+	authRepo         repository.AuthRepository
+	emailService     *email.Service
+	holidays         HolidayChecker
+	timezones        TimezoneService
+}
+
+func NewClassRescheduleService(
+	rescheduleRepo repository.ClassRescheduleRepository,
+	classRepo repository.ClassRepository,
+	classStudentRepo repository.ClassStudentRepository,
+	roomRepo repository.RoomRepository,
+	userRepo repository.UserRepository,
+	authRepo repository.AuthRepository,
+	emailService *email.Service,
+	holidays HolidayChecker,
+	timezones TimezoneService,
+) ClassRescheduleService {
+	return &classRescheduleService{
+		rescheduleRepo:   rescheduleRepo,
+		classRepo:        classRepo,
+		classStudentRepo: classStudentRepo,
+		roomRepo:         roomRepo,
+		userRepo:         userRepo,
+		authRepo:         authRepo,
+		emailService:     emailService,
+		holidays:         holidays,
+		timezones:        timezones,
+	}
+}
+
+// ProposeReschedule propõe um novo horário para a aula. Professor, sala e alunos precisam
+// estar livres no novo horário. Sem alunos matriculados a aula é remarcada na hora;
+// caso contrário a proposta fica pendente até todos os alunos confirmarem.
+func (s *classRescheduleService) ProposeReschedule(classID, proposedBy uuid.UUID, input *RescheduleClassInput) (*domain.ClassReschedule, error) {
+	class, err := s.classRepo.GetByID(classID)
+	if err != nil {
+		return nil, err
+	}
+
+	if class.Status != domain.ClassStatusScheduled {
+		return nil, errors.New("only scheduled classes can be rescheduled")
+	}
+
+	date, err := time.Parse(dateLayout, input.ScheduledDate)
+	if err != nil {
+		return nil, errors.New("invalid scheduled_date (use YYYY-MM-DD)")
+	}
+
+	// Horários podem vir no fuso de quem remarca; a aula é gravada no fuso da escola
+	scheduled, err := s.timezones.FromInput(date, input.StartTime, input.EndTime, input.Timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	newDate, startTime, endTime := scheduled.Date, scheduled.StartTime, scheduled.EndTime
+
+	if !scheduled.StartsAt.After(time.Now()) {
+		return nil, errors.New("cannot reschedule to the past")
+	}
+
+	if newDate.Format(dateLayout) == class.ScheduledDate.Format(dateLayout) &&
+		startTime == clockTime(class.StartTime) && endTime == clockTime(class.EndTime) {
+		return nil, errors.New("new time is the same as the current time")
+	}
+
+	if _, err := s.holidays.Check(newDate); err != nil {
+		return nil, err
+	}
+
+	studentIDs, err := s.classStudentRepo.GetStudentsByClass(classID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get students: %w", err)
+	}
+
+	if err := s.checkConflicts(class, studentIDs, newDate, startTime, endTime); err != nil {
+		return nil, err
+	}
+
+	reschedule := &domain.ClassReschedule{
+		ClassID:           classID,
+		ProposedBy:        proposedBy,
+		PreviousDate:      class.ScheduledDate,
+		PreviousStartTime: clockTime(class.StartTime),
+		PreviousEndTime:   clockTime(class.EndTime),
+		NewDate:           newDate,
+		NewStartTime:      startTime,
+		NewEndTime:        endTime,
+		Reason:            input.Reason,
+		Status:            domain.RescheduleStatusPending,
+	}
+
+	if err := s.rescheduleRepo.Create(reschedule); err != nil {
+		return nil, err
+	}
+
+	if len(studentIDs) == 0 {
+		if err := s.apply(class, reschedule, studentIDs); err != nil {
+			return nil, err
+		}
+		return reschedule, nil
+	}
+
+	// Um único email por participante com a proposta
+	for _, studentID := range studentIDs {
+		go s.sendRescheduleEmail(studentID, class, reschedule,
+			"Proposta de Remarcação de Aula",
+			"Seu professor propôs um novo horário para a aula abaixo. Confirme ou recuse a proposta na plataforma.")
+	}
+	go s.sendRescheduleEmail(class.TeacherID, class, reschedule,
+		"Proposta de Remarcação Enviada",
+		"A proposta de novo horário foi enviada aos alunos. A aula será remarcada quando todos confirmarem.")
+
+	return reschedule, nil
+}
+
+// RespondReschedule grava a resposta do aluno. Uma recusa encerra a proposta;
+// quando todos os alunos aceitam, a aula é remarcada.
+func (s *classRescheduleService) RespondReschedule(classID, studentID uuid.UUID, accepted bool) (*domain.ClassReschedule, error) {
+	reschedule, err := s.rescheduleRepo.GetPendingByClass(classID)
+	if err != nil {
+		return nil, err
+	}
+
+	enrolled, err := s.classStudentRepo.IsStudentInClass(classID, studentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check enrollment: %w", err)
+	}
+
+	if !enrolled {
+		return nil, errors.New("student is not enrolled in this class")
+	}
+
+	if err := s.rescheduleRepo.SaveResponse(&domain.RescheduleResponse{
+		RescheduleID: reschedule.ID,
+		StudentID:    studentID,
+		Accepted:     accepted,
+	}); err != nil {
+		return nil, err
+	}
+
+	class, err := s.classRepo.GetByID(classID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !accepted {
+		if err := s.rescheduleRepo.UpdateStatus(reschedule.ID, domain.RescheduleStatusRejected); err != nil {
+			return nil, err
+		}
+		reschedule.Status = domain.RescheduleStatusRejected
+
+		go s.sendRescheduleEmail(class.TeacherID, class, reschedule,
+			"Remarcação Recusada",
+			"Um aluno recusou a proposta de novo horário. A aula continua no horário original.")
+
+		return s.withResponses(reschedule)
+	}
+
+	studentIDs, err := s.classStudentRepo.GetStudentsByClass(classID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get students: %w", err)
+	}
+
+	responses, err := s.rescheduleRepo.GetResponses(reschedule.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	acceptedBy := make(map[uuid.UUID]bool, len(responses))
+	for _, response := range responses {
+		if response.Accepted {
+			acceptedBy[response.StudentID] = true
+		}
+	}
+
+	for _, id := range studentIDs {
+		if !acceptedBy[id] {
+			return s.withResponses(reschedule)
+		}
+	}
+
+	// Todos aceitaram: apply confere a agenda de novo, pois ela pode ter mudado desde a proposta
+	if err := s.apply(class, reschedule, studentIDs); err != nil {
+		return nil, err
+	}
+
+	return s.withResponses(reschedule)
+}
+
+func (s *classRescheduleService) GetRescheduleHistory(classID uuid.UUID) ([]domain.ClassReschedule, error) {
+	reschedules, err := s.rescheduleRepo.GetByClass(classID)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range reschedules {
+		responses, err := s.rescheduleRepo.GetResponses(reschedules[i].ID)
+		if err != nil {
+			return nil, err
+		}
+		reschedules[i].Responses = responses
+	}
+
+	return reschedules, nil
+}
+
+// checkConflicts verifica professor, sala e alunos no novo horário, ignorando a própria aula
+func (s *classRescheduleService) checkConflicts(class *domain.Class, studentIDs []uuid.UUID, date time.Time, startTime, endTime string) error {
+	conflict := &ScheduleConflictError{}
+
+	available, err := s.classRepo.CheckTeacherAvailability(class.TeacherID, date, startTime, endTime, &class.ID)
+	if err != nil {
+		return fmt.Errorf("failed to check availability: %w", err)
+	}
+	conflict.TeacherUnavailable = !available
+
+	if class.RoomID != nil {
+		available, err := s.roomRepo.CheckAvailability(*class.RoomID, date, startTime, endTime, &class.ID)
+		if err != nil {
+			return err
+		}
+		conflict.RoomUnavailable = !available
+	}
+
+	if conflict.TeacherUnavailable || conflict.RoomUnavailable {
+		return conflict
+	}
+
+	return findStudentConflicts(s.classRepo, studentIDs, date, startTime, endTime, &class.ID)
+}
+
+// apply move a aula para o novo horário e avisa todos os participantes. Professor, sala e
+// alunos são conferidos na mesma transação da gravação; em caso de conflito a proposta é
+// cancelada e o conflito é retornado.
+func (s *classRescheduleService) apply(class *domain.Class, reschedule *domain.ClassReschedule, studentIDs []uuid.UUID) error {
+	startsAt, endsAt, err := s.timezones.ClassTimes(reschedule.NewDate, reschedule.NewStartTime, reschedule.NewEndTime)
+	if err != nil {
+		return err
+	}
+
+	if err := s.rescheduleRepo.Apply(reschedule, startsAt, endsAt); err != nil {
+		conflict := rescheduleConflict(err)
+		if conflict == nil {
+			return err
+		}
+
+		if updateErr := s.rescheduleRepo.UpdateStatus(reschedule.ID, domain.RescheduleStatusCancelled); updateErr != nil {
+			return updateErr
+		}
+		reschedule.Status = domain.RescheduleStatusCancelled
+
+		// Detalha quais alunos e aulas colidem com o novo horário
+		if errors.Is(conflict, repository.ErrStudentUnavailable) {
+			if detailed := findStudentConflicts(s.classRepo, studentIDs, reschedule.NewDate, reschedule.NewStartTime, reschedule.NewEndTime, &class.ID); detailed != nil {
+				return detailed
+			}
+		}
+		return conflict
+	}
+
+	// Os emails levam o .ics com o novo horário
+	if updated, err := s.classRepo.GetByID(class.ID); err == nil {
+		class = updated
+	}
+
+	for _, studentID := range studentIDs {
+		go s.sendRescheduleEmail(studentID, class, reschedule,
+			"Aula Remarcada",
+			"A aula abaixo foi remarcada para o novo horário.")
+	}
+	go s.sendRescheduleEmail(class.TeacherID, class, reschedule,
+		"Aula Remarcada",
+		"A aula abaixo foi remarcada para o novo horário.")
+
+	return nil
+}
+
+// rescheduleConflict traduz os conflitos detectados na gravação da remarcação; retorna nil
+// para os demais erros
+func rescheduleConflict(err error) error {
+	switch {
+	case errors.Is(err, repository.ErrTeacherUnavailable):
+		return &ScheduleConflictError{TeacherUnavailable: true}
+	case errors.Is(err, repository.ErrRoomUnavailable):
+		return &ScheduleConflictError{RoomUnavailable: true}
+	case errors.Is(err, repository.ErrStudentUnavailable):
+		return err
+	}
+
+	return nil
+}
+
+func (s *classRescheduleService) withResponses(reschedule *domain.ClassReschedule) (*domain.ClassReschedule, error) {
+	responses, err := s.rescheduleRepo.GetResponses(reschedule.ID)
+	if err != nil {
+		return nil, err
+	}
+	reschedule.Responses = responses
+	return reschedule, nil
+}
+
+func (s *classRescheduleService) sendRescheduleEmail(authID uuid.UUID, class *domain.Class, reschedule *domain.ClassReschedule, subject, message string) {
+	auth, err := s.authRepo.GetByID(authID)
+	if err != nil {
+		fmt.Printf("Failed to get auth: %v\n", err)
+		return
+	}
+
+	name := auth.Email
+	if user, err := s.userRepo.GetByAuthID(authID); err == nil {
+		name = user.FullName
+	}
+
+	loc := s.timezones.ForUser(authID)
+
+	title := ""
+	if class.Title != nil {
+		title = fmt.Sprintf("<p style=\"margin: 5px 0;\"><strong>Tema:</strong> %s</p>", *class.Title)
+	}
+
+	reason := ""
+	if reschedule.Reason != nil && *reschedule.Reason != "" {
+		reason = fmt.Sprintf("<p><strong>Motivo:</strong> %s</p>", *reschedule.Reason)
+	}
+
+	body := fmt.Sprintf(`
+<!DOCTYPE html>
+<html>
+<head>
+    <meta charset="UTF-8">
+    <title>%s</title>
+</head>
+<body style="font-family: Arial, sans-serif; line-height: 1.6; color: #333;">
+    <div style="max-width: 600px; margin: 0 auto; padding: 20px;">
+        <h2 style="color: #f39c12;">%s</h2>
+        <p>Olá, %s!</p>
+        <p>%s</p>
+        <div style="background-color: #f8f9fa; padding: 20px; border-radius: 5px; margin: 20px 0;">
+            %s
+            <p style="margin: 5px 0; color: #7f8c8d; text-decoration: line-through;">
+                <strong>Antes:</strong> %s
+            </p>
+            <p style="margin: 5px 0;">
+                <strong>Novo horário:</strong> %s
+            </p>
+        </div>
+        %s
+    </div>
+</body>
+</html>
+	`,
+		subject,
+		subject,
+		name,
+		message,
+		title,
+		s.formatSchedule(reschedule.PreviousDate, reschedule.PreviousStartTime, reschedule.PreviousEndTime, loc),
+		s.formatSchedule(reschedule.NewDate, reschedule.NewStartTime, reschedule.NewEndTime, loc),
+		reason,
+	)
+
+	// O .ics só acompanha a remarcação aplicada; propostas ainda podem ser recusadas
+	var attachments []email.Attachment
+	if reschedule.Status == domain.RescheduleStatusApplied {
+		attachments = email.CalendarAttachment(classCalendar(class))
+	}
+
+	if err := s.emailService.SendCustomEmail(auth.Email, subject+" - Potential Idiomas", body, attachments...); err != nil {
+		fmt.Printf("Failed to send reschedule email: %v\n", err)
+	}
+}
+
+// formatSchedule exibe um horário de parede da escola no fuso loc do destinatário
+func (s *classRescheduleService) formatSchedule(date time.Time, startTime, endTime string, loc *time.Location) string {
+	startsAt, endsAt, err := s.timezones.ClassTimes(date, startTime, endTime)
+	if err != nil {
+		return fmt.Sprintf("%s, %s - %s", date.Format("02/01/2006"), clockTime(startTime)[:5], clockTime(endTime)[:5])
+	}
+
+	startsAt, endsAt = startsAt.In(loc), endsAt.In(loc)
+	return fmt.Sprintf("%s, %s - %s (%s)", startsAt.Format("02/01/2006"), startsAt.Format("15:04"), endsAt.Format("15:04"), loc)
+}
@@ -0,0 +1,351 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hscHeric/go-potential-api/internal/domain"
+	"github.com/hscHeric/go-potential-api/internal/repository"
+	"github.com/hscHeric/go-potential-api/pkg/recurrence"
+)
+
+type ClassSeriesService interface {
+	CreateSeries(createdBy uuid.UUID, input *CreateClassSeriesInput) (*domain.ClassSeriesReport, error)
+	GetSeries(id uuid.UUID) (*domain.ClassSeriesWithClasses, error)
+	UpdateOccurrences(classID uuid.UUID, scope domain.SeriesScope, input *UpdateClassInput) (int, error)
+	CancelOccurrences(classID uuid.UUID, scope domain.SeriesScope, cancelledBy uuid.UUID) (int, error)
+}
+
+type CreateClassSeriesInput struct {
+	TeacherID    uuid.UUID             `json:"teacher_id" binding:"required"`
+	DaysOfWeek   []domain.DayOfWeek    `json:"days_of_week" binding:"required,min=1,dive,min=0,max=6"`
+	StartDate    string                `json:"start_date" binding:"required"` // Format: "2006-01-02"
+	UntilDate    *string               `json:"until_date"`                    // Format: "2006-01-02"
+	Occurrences  *int                  `json:"occurrences" binding:"omitempty,min=1"`
+	StartTime    string                `json:"start_time" binding:"required"`
+	EndTime      string                `json:"end_time" binding:"required"`
+	Title        *string               `json:"title"`
+	Description  *string               `json:"description"`
+	ClassLink    *string               `json:"class_link"`
+	Language     *string               `json:"language"`
+	Level        *domain.LanguageLevel `json:"level" binding:"omitempty,oneof=A1 A2 B1 B2 C1 C2"`
+	ExcludeDates []string              `json:"exclude_dates"` // Feriados e recessos, Format: "2006-01-02"
+	StudentIDs   []uuid.UUID           `json:"student_ids"`
+}
+
+// Motivos pelos quais uma ocorrência da série não gera aula
+const (
+	skipReasonPast        = "date is in the past"
+	skipReasonExcluded    = "date excluded from series"
+	skipReasonUnavailable = "teacher not available at this time"
+	skipReasonHoliday     = "date is a holiday"
+	skipReasonNonexistent = "time does not exist on this date (daylight saving change)"
+)
+
+type classSeriesService struct {
+	seriesRepo       repository.ClassSeriesRepository
+	classRepo        repository.ClassRepository
+	classStudentRepo repository.ClassStudentRepository
+	classService     ClassService
+	holidays         HolidayChecker
+	timezones        TimezoneService
+}
+
+func NewClassSeriesService(
+	seriesRepo repository.ClassSeriesRepository,
+	classRepo repository.ClassRepository,
+	classStudentRepo repository.ClassStudentRepository,
+	classService ClassService,
+	holidays HolidayChecker,
+	timezones TimezoneService,
+) ClassSeriesService {
+	return &classSeriesService{
+		seriesRepo:       seriesRepo,
+		classRepo:        classRepo,
+		classStudentRepo: classStudentRepo,
+		classService:     classService,
+		holidays:         holidays,
+		timezones:        timezones,
+	}
+}
+
+// CreateSeries grava a série e gera uma aula para cada data do padrão.
+// Datas passadas, excluídas, em feriados ou em conflito com outra aula do professor
+// são puladas e listadas no relatório em vez de interromper a geração.
+func (s *classSeriesService) CreateSeries(createdBy uuid.UUID, input *CreateClassSeriesInput) (*domain.ClassSeriesReport, error) {
+	startTime, endTime, err := clockRange(input.StartTime, input.EndTime)
+	if err != nil {
+		return nil, err
+	}
+
+	startDate, err := time.Parse(dateLayout, input.StartDate)
+	if err != nil {
+		return nil, errors.New("invalid start_date (use YYYY-MM-DD)")
+	}
+
+	rule := recurrence.Rule{Start: startDate}
+	for _, day := range input.DaysOfWeek {
+		rule.Weekdays = append(rule.Weekdays, time.Weekday(day))
+	}
+
+	var untilDate *time.Time
+	if input.UntilDate != nil {
+		parsed, err := time.Parse(dateLayout, *input.UntilDate)
+		if err != nil {
+			return nil, errors.New("invalid until_date (use YYYY-MM-DD)")
+		}
+		untilDate = &parsed
+		rule.Until = untilDate
+	}
+
+	if input.Occurrences != nil {
+		rule.Count = *input.Occurrences
+	}
+
+	dates, err := rule.Dates()
+	if err != nil {
+		return nil, fmt.Errorf("invalid recurrence: %w", err)
+	}
+
+	excluded := make(map[string]bool, len(input.ExcludeDates))
+	excludeDates := make([]string, 0, len(input.ExcludeDates))
+	for _, value := range input.ExcludeDates {
+		date, err := time.Parse(dateLayout, value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid exclude date %q (use YYYY-MM-DD)", value)
+		}
+		excluded[date.Format(dateLayout)] = true
+		excludeDates = append(excludeDates, date.Format(dateLayout))
+	}
+
+	series := &domain.ClassSeries{
+		TeacherID:    input.TeacherID,
+		DaysOfWeek:   input.DaysOfWeek,
+		StartDate:    startDate,
+		UntilDate:    untilDate,
+		Occurrences:  input.Occurrences,
+		StartTime:    startTime,
+		EndTime:      endTime,
+		Title:        input.Title,
+		Description:  input.Description,
+		ClassLink:    input.ClassLink,
+		Language:     normalizeOptionalLanguage(input.Language),
+		Level:        input.Level,
+		ExcludeDates: excludeDates,
+		CreatedBy:    createdBy,
+	}
+
+	if err := s.seriesRepo.Create(series); err != nil {
+		return nil, fmt.Errorf("failed to create class series: %w", err)
+	}
+
+	report := &domain.ClassSeriesReport{
+		Series:  *series,
+		Created: []domain.Class{},
+		Skipped: []domain.SkippedOccurrence{},
+	}
+
+	today := time.Now().In(s.timezones.School()).Format(dateLayout)
+
+	holidays := map[string]domain.Holiday{}
+	if len(dates) > 0 {
+		holidays, err = s.holidays.InRange(dates[0], dates[len(dates)-1])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, date := range dates {
+		formatted := date.Format(dateLayout)
+
+		if formatted < today {
+			report.Skipped = append(report.Skipped, domain.SkippedOccurrence{Date: formatted, Reason: skipReasonPast})
+			continue
+		}
+
+		if excluded[formatted] {
+			report.Skipped = append(report.Skipped, domain.SkippedOccurrence{Date: formatted, Reason: skipReasonExcluded})
+			continue
+		}
+
+		holiday, isHoliday := holidays[formatted]
+		if isHoliday && s.holidays.Blocks() {
+			report.Skipped = append(report.Skipped, domain.SkippedOccurrence{Date: formatted, Reason: skipReasonHoliday})
+			continue
+		}
+
+		// Na mudança de horário de verão o horário pode não existir na data
+		startsAt, endsAt, err := s.timezones.ClassTimes(date, series.StartTime, series.EndTime)
+		if err != nil {
+			report.Skipped = append(report.Skipped, domain.SkippedOccurrence{Date: formatted, Reason: skipReasonNonexistent})
+			continue
+		}
+
+		available, err := s.classRepo.CheckTeacherAvailability(series.TeacherID, date, series.StartTime, series.EndTime, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check availability: %w", err)
+		}
+
+		if !available {
+			report.Skipped = append(report.Skipped, domain.SkippedOccurrence{Date: formatted, Reason: skipReasonUnavailable})
+			continue
+		}
+
+		class := &domain.Class{
+			TeacherID:     series.TeacherID,
+			SeriesID:      &series.ID,
+			ScheduledDate: date,
+			StartTime:     series.StartTime,
+			EndTime:       series.EndTime,
+			StartsAt:      startsAt,
+			EndsAt:        endsAt,
+			Status:        domain.ClassStatusScheduled,
+			Title:         series.Title,
+			Description:   series.Description,
+			ClassLink:     series.ClassLink,
+			Language:      series.Language,
+			Level:         series.Level,
+			CreatedBy:     createdBy,
+		}
+
+		if err := s.classRepo.Create(class); err != nil {
+			return nil, fmt.Errorf("failed to create class for %s: %w", formatted, err)
+		}
+
+		// Sem e-mail por ocorrência, para não enviar dezenas de avisos de uma vez
+		for _, studentID := range input.StudentIDs {
+			classStudent := &domain.ClassStudent{
+				ClassID:   class.ID,
+				StudentID: studentID,
+				AddedBy:   createdBy,
+			}
+
+			if err := s.classStudentRepo.AddStudent(classStudent); err != nil {
+				fmt.Printf("Warning: failed to add student %s to class %s: %v\n", studentID, class.ID, err)
+			}
+		}
+
+		if isHoliday {
+			report.Warnings = append(report.Warnings, domain.SkippedOccurrence{Date: formatted, Reason: holidayWarning(&holiday)})
+		}
+
+		report.Created = append(report.Created, *class)
+	}
+
+	return report, nil
+}
+
+func (s *classSeriesService) GetSeries(id uuid.UUID) (*domain.ClassSeriesWithClasses, error) {
+	series, err := s.seriesRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	classes, err := s.classRepo.GetBySeries(id, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if classes == nil {
+		classes = []domain.Class{}
+	}
+
+	return &domain.ClassSeriesWithClasses{
+		ClassSeries: *series,
+		Classes:     classes,
+	}, nil
+}
+
+// UpdateOccurrences aplica a alteração às ocorrências do escopo e retorna quantas aulas mudaram.
+// No escopo "all" os dados da série também são atualizados.
+func (s *classSeriesService) UpdateOccurrences(classID uuid.UUID, scope domain.SeriesScope, input *UpdateClassInput) (int, error) {
+	class, targets, err := s.resolveScope(classID, scope)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, target := range targets {
+		if err := s.classService.UpdateClass(target.ID, input); err != nil {
+			return 0, fmt.Errorf("failed to update class %s: %w", target.ID, err)
+		}
+	}
+
+	if scope == domain.SeriesScopeAll {
+		series, err := s.seriesRepo.GetByID(*class.SeriesID)
+		if err != nil {
+			return 0, fmt.Errorf("failed to get class series: %w", err)
+		}
+
+		if input.Title != nil {
+			series.Title = input.Title
+		}
+		if input.Description != nil {
+			series.Description = input.Description
+		}
+		if input.ClassLink != nil {
+			series.ClassLink = input.ClassLink
+		}
+		if input.Language != nil {
+			series.Language = normalizeOptionalLanguage(input.Language)
+		}
+		if input.Level != nil {
+			series.Level = input.Level
+		}
+
+		if err := s.seriesRepo.Update(series); err != nil {
+			return 0, fmt.Errorf("failed to update class series: %w", err)
+		}
+	}
+
+	return len(targets), nil
+}
+
+// CancelOccurrences cancela as ocorrências do escopo e retorna quantas aulas foram canceladas
+func (s *classSeriesService) CancelOccurrences(classID uuid.UUID, scope domain.SeriesScope, cancelledBy uuid.UUID) (int, error) {
+	_, targets, err := s.resolveScope(classID, scope)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, target := range targets {
+		if err := s.classService.CancelClass(target.ID, cancelledBy); err != nil {
+			return 0, fmt.Errorf("failed to cancel class %s: %w", target.ID, err)
+		}
+	}
+
+	return len(targets), nil
+}
+
+// resolveScope retorna a aula de referência e as aulas atingidas pelo escopo
+func (s *classSeriesService) resolveScope(classID uuid.UUID, scope domain.SeriesScope) (*domain.Class, []domain.Class, error) {
+	if !scope.IsValid() {
+		return nil, nil, errors.New("invalid scope (must be this, following or all)")
+	}
+
+	class, err := s.classRepo.GetByID(classID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if scope == domain.SeriesScopeThis {
+		return class, []domain.Class{*class}, nil
+	}
+
+	if class.SeriesID == nil {
+		return nil, nil, errors.New("class does not belong to a series")
+	}
+
+	var fromDate *time.Time
+	if scope == domain.SeriesScopeFollowing {
+		fromDate = &class.ScheduledDate
+	}
+
+	targets, err := s.classRepo.GetBySeries(*class.SeriesID, fromDate)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return class, targets, nil
+}
@@ -8,79 +8,157 @@ import (
 	"github.com/google/uuid"
 	"github.com/hscHeric/go-potential-api/internal/domain"
 	"github.com/hscHeric/go-potential-api/internal/repository"
+	"github.com/hscHeric/go-potential-api/pkg/cancellation"
 	"github.com/hscHeric/go-potential-api/pkg/email"
 )
 
+// Paginação da agenda geral de aulas e período máximo da grade por professor
+const (
+	defaultClassPageSize = 50
+	maxClassPageSize     = 200
+	maxScheduleGridDays  = 14
+)
+
+// ErrClassFull indica que a aula não tem vagas (o aluno pode entrar na lista de espera)
+var ErrClassFull = errors.New("class is full")
+
+// ErrInvalidStatusTransition indica uma mudança de status não permitida (ex.: aula cancelada para completed)
+var ErrInvalidStatusTransition = errors.New("invalid class status transition")
+
+// ErrOverrideNotAllowed indica um pedido para ignorar conflitos feito por quem não é admin
+var ErrOverrideNotAllowed = errors.New("only admins can override student schedule conflicts")
+
+// StudentConflictError indica alunos que já têm outra aula no horário pedido
+type StudentConflictError struct {
+	Conflicts []domain.StudentConflict
+}
+
+func (e *StudentConflictError) Error() string {
+	return fmt.Sprintf("schedule conflict: %d student(s) have another class at this time", len(e.Conflicts))
+}
+
 type ClassService interface {
 	CreateClass(createdBy uuid.UUID, input *CreateClassInput) (*domain.Class, error)
-	AddStudentToClass(classID, studentID, addedBy uuid.UUID) error
+	AddStudentToClass(classID, studentID, addedBy uuid.UUID, overrideConflicts bool) error
 	RemoveStudentFromClass(classID, studentID uuid.UUID) error
 	GetClass(id uuid.UUID) (*domain.ClassWithDetails, error)
-	GetTeacherClasses(teacherID uuid.UUID, startDate, endDate time.Time) ([]domain.Class, error)
-	GetStudentClasses(studentID uuid.UUID, startDate, endDate time.Time) ([]domain.Class, error)
+	GetTeacherClasses(teacherID uuid.UUID, startDate, endDate time.Time, filter domain.ClassFilter) ([]domain.Class, error)
+	GetStudentClasses(studentID uuid.UUID, startDate, endDate time.Time, filter domain.ClassFilter) ([]domain.Class, error)
+	ListClasses(filter domain.ClassListFilter) (*domain.ClassPage, error)
+	GetScheduleGrid(filter domain.ClassListFilter) (*domain.ScheduleGrid, error)
 	UpdateClass(id uuid.UUID, input *UpdateClassInput) error
-	UpdateClassStatus(id uuid.UUID, status domain.ClassStatus) error
+	UpdateClassStatus(id, requestedBy uuid.UUID, status domain.ClassStatus) (*domain.Class, error)
 	CancelClass(id uuid.UUID, cancelledBy uuid.UUID) error
+	CancelParticipation(classID, studentID uuid.UUID) (*domain.ClassStudent, error)
 	MarkAttendance(classID, studentID uuid.UUID, attended bool) error
+	BookOccurrence(studentID uuid.UUID, input *BookOccurrenceInput) (*domain.Class, error)
+	DeleteClass(id uuid.UUID) error
+	RestoreClass(id uuid.UUID) error
 }
 
 type CreateClassInput struct {
-	TeacherID     uuid.UUID   `json:"teacher_id" binding:"required"`
-	TimeSlotID    *uuid.UUID  `json:"time_slot_id"`
-	ScheduledDate time.Time   `json:"scheduled_date" binding:"required"`
-	StartTime     string      `json:"start_time" binding:"required"`
-	EndTime       string      `json:"end_time" binding:"required"`
-	Title         *string     `json:"title"`
-	Description   *string     `json:"description"`
-	StudentIDs    []uuid.UUID `json:"student_ids"`
+	TeacherID         uuid.UUID             `json:"teacher_id" binding:"required"`
+	TimeSlotID        *uuid.UUID            `json:"time_slot_id"`
+	ScheduledDate     time.Time             `json:"scheduled_date" binding:"required"`
+	StartTime         string                `json:"start_time" binding:"required"`
+	EndTime           string                `json:"end_time" binding:"required"`
+	Timezone          *string               `json:"timezone"` // Fuso IANA de data/horários informados; padrão é o da escola
+	Title             *string               `json:"title"`
+	Description       *string               `json:"description"`
+	Language          *string               `json:"language"`
+	Level             *domain.LanguageLevel `json:"level" binding:"omitempty,oneof=A1 A2 B1 B2 C1 C2"`
+	StudentIDs        []uuid.UUID           `json:"student_ids"`
+	RoomID            *uuid.UUID            `json:"room_id"`            // Sala da aula presencial
+	BranchID          *uuid.UUID            `json:"branch_id"`          // Sem room_id, reserva a menor sala livre da unidade
+	OverrideConflicts bool                  `json:"override_conflicts"` // Apenas admins: agenda mesmo com alunos em outra aula no horário
 }
 
 type UpdateClassInput struct {
-	Title       *string    `json:"title"`
-	Description *string    `json:"description"`
-	ClassLink   *string    `json:"class_link"`
-	MaterialID  *uuid.UUID `json:"material_id"`
+	Title       *string               `json:"title"`
+	Description *string               `json:"description"`
+	ClassLink   *string               `json:"class_link"`
+	MaterialID  *uuid.UUID            `json:"material_id"`
+	Language    *string               `json:"language"`
+	Level       *domain.LanguageLevel `json:"level" binding:"omitempty,oneof=A1 A2 B1 B2 C1 C2"`
+}
+
+// BookOccurrenceInput identifica uma ocorrência (horário recorrente + data) escolhida pelo aluno
+type BookOccurrenceInput struct {
+	TimeSlotID uuid.UUID `json:"time_slot_id" binding:"required"`
+	Date       string    `json:"date" binding:"required"` // Format: "2006-01-02"
 }
 
 type classService struct {
 	classRepo        repository.ClassRepository
 	classStudentRepo repository.ClassStudentRepository
 	timeSlotRepo     repository.TimeSlotRepository
+	bookingRepo      repository.BookingRepository
+	waitlistRepo     repository.WaitlistRepository
+	roomRepo         repository.RoomRepository
 	userRepo         repository.UserRepository
 	authRepo         repository.AuthRepository
+	waitlistService  WaitlistService
+	credits          CreditService
 	emailService     *email.Service
+	policy           cancellation.Policy
+	holidays         HolidayChecker
+	timezones        TimezoneService
 }
 
 func NewClassService(
 	classRepo repository.ClassRepository,
 	classStudentRepo repository.ClassStudentRepository,
 	timeSlotRepo repository.TimeSlotRepository,
+	bookingRepo repository.BookingRepository,
+	waitlistRepo repository.WaitlistRepository,
+	roomRepo repository.RoomRepository,
 	userRepo repository.UserRepository,
 	authRepo repository.AuthRepository,
+	waitlistService WaitlistService,
+	credits CreditService,
 	emailService *email.Service,
+	policy cancellation.Policy,
+	holidays HolidayChecker,
+	timezones TimezoneService,
 ) ClassService {
 	return &classService{
 		classRepo:        classRepo,
 		classStudentRepo: classStudentRepo,
 		timeSlotRepo:     timeSlotRepo,
+		bookingRepo:      bookingRepo,
+		waitlistRepo:     waitlistRepo,
+		roomRepo:         roomRepo,
 		userRepo:         userRepo,
 		authRepo:         authRepo,
+		waitlistService:  waitlistService,
+		credits:          credits,
 		emailService:     emailService,
+		policy:           policy,
+		holidays:         holidays,
+		timezones:        timezones,
 	}
 }
 
 func (s *classService) CreateClass(createdBy uuid.UUID, input *CreateClassInput) (*domain.Class, error) {
-	// Validar horários
-	if input.StartTime >= input.EndTime {
-		return nil, errors.New("start time must be before end time")
+	// Horários podem vir no fuso de quem agenda; a aula é gravada no fuso da escola
+	scheduled, err := s.timezones.FromInput(input.ScheduledDate, input.StartTime, input.EndTime, input.Timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	// Feriados bloqueiam ou geram aviso, conforme a política
+	holiday, err := s.holidays.Check(scheduled.Date)
+	if err != nil {
+		return nil, err
 	}
 
 	// Verificar disponibilidade do professor
 	available, err := s.classRepo.CheckTeacherAvailability(
 		input.TeacherID,
-		input.ScheduledDate,
-		input.StartTime,
-		input.EndTime,
+		scheduled.Date,
+		scheduled.StartTime,
+		scheduled.EndTime,
+		nil,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to check availability: %w", err)
@@ -90,6 +168,11 @@ func (s *classService) CreateClass(createdBy uuid.UUID, input *CreateClassInput)
 		return nil, errors.New("teacher not available at this time")
 	}
 
+	// Alunos não podem estar em duas aulas ao mesmo tempo
+	if err := s.checkStudentConflicts(createdBy, input.OverrideConflicts, input.StudentIDs, scheduled.Date, scheduled.StartTime, scheduled.EndTime, nil); err != nil {
+		return nil, err
+	}
+
 	// Se tiver time slot, validar capacidade
 	if input.TimeSlotID != nil {
 		slot, err := s.timeSlotRepo.GetByID(*input.TimeSlotID)
@@ -97,40 +180,58 @@ func (s *classService) CreateClass(createdBy uuid.UUID, input *CreateClassInput)
 			return nil, fmt.Errorf("failed to get time slot: %w", err)
 		}
 
+		if !slot.Covers(scheduled.Date) {
+			return nil, errors.New("time slot is not valid on this date")
+		}
+
 		if len(input.StudentIDs) > slot.MaxStudents {
 			return nil, fmt.Errorf("cannot add %d students, max is %d", len(input.StudentIDs), slot.MaxStudents)
 		}
 	}
 
+	// Aulas presenciais reservam uma sala livre no horário
+	roomID, err := reserveRoom(s.roomRepo, input.RoomID, input.BranchID, len(input.StudentIDs), scheduled.Date, scheduled.StartTime, scheduled.EndTime, nil)
+	if err != nil {
+		return nil, err
+	}
+
 	// Criar aula
 	class := &domain.Class{
 		TeacherID:     input.TeacherID,
 		TimeSlotID:    input.TimeSlotID,
-		ScheduledDate: input.ScheduledDate,
-		StartTime:     input.StartTime,
-		EndTime:       input.EndTime,
+		ScheduledDate: scheduled.Date,
+		StartTime:     scheduled.StartTime,
+		EndTime:       scheduled.EndTime,
+		StartsAt:      scheduled.StartsAt,
+		EndsAt:        scheduled.EndsAt,
 		Status:        domain.ClassStatusScheduled,
 		Title:         input.Title,
 		Description:   input.Description,
+		Language:      normalizeOptionalLanguage(input.Language),
+		Level:         input.Level,
+		RoomID:        roomID,
 		CreatedBy:     createdBy,
 	}
 
-	if err := s.classRepo.Create(class); err != nil {
+	// Aula e alunos são gravados juntos, com o horário bloqueado contra reservas simultâneas
+	if err := s.bookingRepo.CreateClass(class, input.StudentIDs); err != nil {
+		switch {
+		case errors.Is(err, repository.ErrTeacherUnavailable):
+			return nil, errors.New("teacher not available at this time")
+		case errors.Is(err, repository.ErrSlotFull):
+			return nil, ErrClassFull
+		case errors.Is(err, repository.ErrRoomUnavailable):
+			return nil, ErrRoomUnavailable
+		}
 		return nil, fmt.Errorf("failed to create class: %w", err)
 	}
 
-	// Adicionar alunos
-	for _, studentID := range input.StudentIDs {
-		classStudent := &domain.ClassStudent{
-			ClassID:   class.ID,
-			StudentID: studentID,
-			AddedBy:   createdBy,
-		}
+	if holiday != nil {
+		class.Warnings = append(class.Warnings, holidayWarning(holiday))
+	}
 
-		if err := s.classStudentRepo.AddStudent(classStudent); err != nil {
-			fmt.Printf("Warning: failed to add student %s: %v\n", studentID, err)
-			continue
-		}
+	for _, studentID := range input.StudentIDs {
+		s.chargeEnrollment(studentID, class.ID)
 
 		// Enviar email para o aluno
 		go s.sendClassNotificationToStudent(studentID, class)
@@ -142,7 +243,7 @@ func (s *classService) CreateClass(createdBy uuid.UUID, input *CreateClassInput)
 	return class, nil
 }
 
-func (s *classService) AddStudentToClass(classID, studentID, addedBy uuid.UUID) error {
+func (s *classService) AddStudentToClass(classID, studentID, addedBy uuid.UUID, overrideConflicts bool) error {
 	// Buscar aula
 	class, err := s.classRepo.GetByID(classID)
 	if err != nil {
@@ -150,36 +251,28 @@ func (s *classService) AddStudentToClass(classID, studentID, addedBy uuid.UUID)
 	}
 
 	// Verificar se aula já passou
-	if class.ScheduledDate.Before(time.Now()) {
+	if !class.StartsAt.After(time.Now()) {
 		return errors.New("cannot add student to past class")
 	}
 
-	// Verificar capacidade se tiver time slot
-	if class.TimeSlotID != nil {
-		slot, err := s.timeSlotRepo.GetByID(*class.TimeSlotID)
-		if err != nil {
-			return fmt.Errorf("failed to get time slot: %w", err)
-		}
-
-		currentCount, err := s.classStudentRepo.CountStudentsInClass(classID)
-		if err != nil {
-			return fmt.Errorf("failed to count students: %w", err)
-		}
-
-		if currentCount >= slot.MaxStudents {
-			return errors.New("class is full")
-		}
+	if err := s.checkStudentConflicts(addedBy, overrideConflicts, []uuid.UUID{studentID}, class.ScheduledDate, clockTime(class.StartTime), clockTime(class.EndTime), &class.ID); err != nil {
+		return err
 	}
 
-	// Adicionar aluno
-	classStudent := &domain.ClassStudent{
-		ClassID:   classID,
-		StudentID: studentID,
-		AddedBy:   addedBy,
+	// A capacidade do horário é conferida com o horário bloqueado. O aluno que entra por
+	// conta própria tem o crédito debitado na mesma transação.
+	selfBooking := addedBy == studentID
+	if err := s.bookingRepo.AddStudent(classID, studentID, addedBy, selfBooking); err != nil {
+		if errors.Is(err, repository.ErrSlotFull) {
+			return ErrClassFull
+		}
+		return fmt.Errorf("failed to add student: %w", err)
 	}
 
-	if err := s.classStudentRepo.AddStudent(classStudent); err != nil {
-		return fmt.Errorf("failed to add student: %w", err)
+	if selfBooking {
+		s.credits.NotifyLowBalance(studentID)
+	} else {
+		s.chargeEnrollment(studentID, classID)
 	}
 
 	// Enviar email de notificação
@@ -189,7 +282,17 @@ func (s *classService) AddStudentToClass(classID, studentID, addedBy uuid.UUID)
 }
 
 func (s *classService) RemoveStudentFromClass(classID, studentID uuid.UUID) error {
-	return s.classStudentRepo.RemoveStudent(classID, studentID)
+	if err := s.classStudentRepo.RemoveStudent(classID, studentID); err != nil {
+		return err
+	}
+	s.credits.RefundClass(studentID, classID)
+
+	// A vaga liberada é oferecida ao próximo da lista de espera
+	if err := s.waitlistService.PromoteNext(classID); err != nil {
+		fmt.Printf("Warning: failed to promote waitlist of class %s: %v\n", classID, err)
+	}
+
+	return nil
 }
 
 func (s *classService) GetClass(id uuid.UUID) (*domain.ClassWithDetails, error) {
@@ -223,12 +326,106 @@ func (s *classService) GetClass(id uuid.UUID) (*domain.ClassWithDetails, error)
 	}, nil
 }
 
-func (s *classService) GetTeacherClasses(teacherID uuid.UUID, startDate, endDate time.Time) ([]domain.Class, error) {
-	return s.classRepo.GetByTeacher(teacherID, startDate, endDate)
+func (s *classService) GetTeacherClasses(teacherID uuid.UUID, startDate, endDate time.Time, filter domain.ClassFilter) ([]domain.Class, error) {
+	filter.Language = NormalizeLanguage(filter.Language)
+	return s.classRepo.GetByTeacher(teacherID, startDate, endDate, filter)
+}
+
+func (s *classService) GetStudentClasses(studentID uuid.UUID, startDate, endDate time.Time, filter domain.ClassFilter) ([]domain.Class, error) {
+	filter.Language = NormalizeLanguage(filter.Language)
+	return s.classRepo.GetByStudent(studentID, startDate, endDate, filter)
+}
+
+// ListClasses retorna uma página da agenda geral de aulas de todos os professores
+func (s *classService) ListClasses(filter domain.ClassListFilter) (*domain.ClassPage, error) {
+	if filter.StartDate != nil && filter.EndDate != nil && filter.EndDate.Before(*filter.StartDate) {
+		return nil, fmt.Errorf("%w: 'end_date' must not be before 'start_date'", ErrInvalidDateRange)
+	}
+
+	if filter.Page < 1 {
+		filter.Page = 1
+	}
+	if filter.PageSize < 1 {
+		filter.PageSize = defaultClassPageSize
+	}
+	if filter.PageSize > maxClassPageSize {
+		filter.PageSize = maxClassPageSize
+	}
+	filter.Language = NormalizeLanguage(filter.Language)
+
+	classes, total, err := s.classRepo.List(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	if classes == nil {
+		classes = []domain.Class{}
+	}
+
+	return &domain.ClassPage{
+		Classes:  classes,
+		Page:     filter.Page,
+		PageSize: filter.PageSize,
+		Total:    total,
+	}, nil
 }
 
-func (s *classService) GetStudentClasses(studentID uuid.UUID, startDate, endDate time.Time) ([]domain.Class, error) {
-	return s.classRepo.GetByStudent(studentID, startDate, endDate)
+// GetScheduleGrid monta a grade de aulas por professor e dia entre StartDate e EndDate
+// (obrigatórios, inclusive). Só aparecem os professores com aulas no período.
+func (s *classService) GetScheduleGrid(filter domain.ClassListFilter) (*domain.ScheduleGrid, error) {
+	if filter.StartDate == nil || filter.EndDate == nil {
+		return nil, fmt.Errorf("%w: start and end dates are required", ErrInvalidDateRange)
+	}
+
+	from, to := *filter.StartDate, *filter.EndDate
+	if to.Before(from) {
+		return nil, fmt.Errorf("%w: 'to' must not be before 'from'", ErrInvalidDateRange)
+	}
+
+	if to.Sub(from) >= maxScheduleGridDays*24*time.Hour {
+		return nil, fmt.Errorf("%w: range cannot exceed %d days", ErrInvalidDateRange, maxScheduleGridDays)
+	}
+	filter.Language = NormalizeLanguage(filter.Language)
+
+	entries, err := s.classRepo.GetSchedule(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	grid := &domain.ScheduleGrid{
+		From:     from.Format(dateLayout),
+		To:       to.Format(dateLayout),
+		Days:     []string{},
+		Teachers: []domain.ScheduleGridRow{},
+	}
+
+	dayIndex := make(map[string]int)
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		dayIndex[day.Format(dateLayout)] = len(grid.Days)
+		grid.Days = append(grid.Days, day.Format(dateLayout))
+	}
+
+	// As aulas vêm ordenadas por professor: cada troca de professor abre uma linha
+	for _, entry := range entries {
+		last := len(grid.Teachers) - 1
+		if last < 0 || grid.Teachers[last].TeacherID != entry.TeacherID {
+			row := domain.ScheduleGridRow{
+				TeacherID:   entry.TeacherID,
+				TeacherName: entry.TeacherName,
+				Days:        make([]domain.ScheduleGridDay, len(grid.Days)),
+			}
+			for i, date := range grid.Days {
+				row.Days[i] = domain.ScheduleGridDay{Date: date, Classes: []domain.ClassOccupancy{}}
+			}
+			grid.Teachers = append(grid.Teachers, row)
+			last++
+		}
+
+		i := dayIndex[entry.ScheduledDate.Format(dateLayout)]
+		grid.Teachers[last].Days[i].Classes = append(grid.Teachers[last].Days[i].Classes, entry.ClassOccupancy)
+	}
+
+	return grid, nil
 }
 
 func (s *classService) UpdateClass(id uuid.UUID, input *UpdateClassInput) error {
@@ -250,12 +447,57 @@ func (s *classService) UpdateClass(id uuid.UUID, input *UpdateClassInput) error
 	if input.MaterialID != nil {
 		class.MaterialID = input.MaterialID
 	}
+	if input.Language != nil {
+		class.Language = normalizeOptionalLanguage(input.Language)
+	}
+	if input.Level != nil {
+		class.Level = input.Level
+	}
 
 	return s.classRepo.Update(class)
 }
 
-func (s *classService) UpdateClassStatus(id uuid.UUID, status domain.ClassStatus) error {
-	return s.classRepo.UpdateStatus(id, status)
+// UpdateClassStatus muda o status da aula seguindo as transições permitidas. Professores
+// só alteram as próprias aulas; o cancelamento segue a política de CancelClass.
+func (s *classService) UpdateClassStatus(id, requestedBy uuid.UUID, status domain.ClassStatus) (*domain.Class, error) {
+	class, err := s.classRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	auth, err := s.authRepo.GetByID(requestedBy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get auth: %w", err)
+	}
+
+	if auth.Role != domain.RoleAdmin && class.TeacherID != requestedBy {
+		return nil, ErrNotClassTeacher
+	}
+
+	if !class.Status.CanTransitionTo(status) {
+		return nil, fmt.Errorf("%w: %s to %s", ErrInvalidStatusTransition, class.Status, status)
+	}
+
+	if status == domain.ClassStatusCancelled {
+		if err := s.CancelClass(id, requestedBy); err != nil {
+			return nil, err
+		}
+		return s.classRepo.GetByID(id)
+	}
+
+	// completed e no_show descrevem o que aconteceu na aula
+	if class.StartsAt.After(time.Now()) {
+		return nil, errors.New("class has not started yet")
+	}
+
+	if err := s.classRepo.TransitionStatus(id, class.Status, status); err != nil {
+		return nil, err
+	}
+
+	// completed e no_show consomem o crédito dos matriculados (ver ChargeEnrolled)
+	s.credits.ChargeEnrolled(id)
+
+	return s.classRepo.GetByID(id)
 }
 
 func (s *classService) CancelClass(id uuid.UUID, cancelledBy uuid.UUID) error {
@@ -264,14 +506,37 @@ func (s *classService) CancelClass(id uuid.UUID, cancelledBy uuid.UUID) error {
 		return err
 	}
 
+	if class.Status != domain.ClassStatusScheduled {
+		return errors.New("only scheduled classes can be cancelled")
+	}
+
+	auth, err := s.authRepo.GetByID(cancelledBy)
+	if err != nil {
+		return fmt.Errorf("failed to get auth: %w", err)
+	}
+
+	// Professores só cancelam se a política permitir e antes do início da aula
+	if _, err := s.policy.Evaluate(cancellation.Actor(auth.Role), class.StartsAt, time.Now()); err != nil {
+		return err
+	}
+
 	if err := s.classRepo.UpdateStatus(id, domain.ClassStatusCancelled); err != nil {
 		return err
 	}
+	class.Status = domain.ClassStatusCancelled
+	class.UpdatedAt = time.Now()
+	s.expireOffers(id)
 
-	// Notificar alunos
+	// Notificar alunos. Quando o professor cancela, o crédito debitado vira uma
+	// reposição com validade; cancelamentos da escola estornam o crédito.
 	studentIDs, err := s.classStudentRepo.GetStudentsByClass(id)
 	if err == nil {
 		for _, studentID := range studentIDs {
+			if auth.Role == domain.RoleTeacher {
+				s.credits.GrantMakeup(studentID, class)
+			} else {
+				s.credits.RefundClass(studentID, class.ID)
+			}
 			go s.sendClassCancellationToStudent(studentID, class)
 		}
 	}
@@ -282,8 +547,229 @@ func (s *classService) CancelClass(id uuid.UUID, cancelledBy uuid.UUID) error {
 	return nil
 }
 
+// CancelParticipation cancela a participação do próprio aluno aplicando a política de
+// cancelamento. A decisão fica registrada em class_students e a vaga vai para a lista de espera.
+func (s *classService) CancelParticipation(classID, studentID uuid.UUID) (*domain.ClassStudent, error) {
+	class, err := s.classRepo.GetByID(classID)
+	if err != nil {
+		return nil, err
+	}
+
+	if class.Status != domain.ClassStatusScheduled {
+		return nil, errors.New("only scheduled classes can be cancelled")
+	}
+
+	decision, err := s.policy.Evaluate(cancellation.ActorStudent, class.StartsAt, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	outcome := domain.CancellationOnTime
+	switch decision.Penalty {
+	case cancellation.PenaltyUsed:
+		outcome = domain.CancellationLateUsed
+	case cancellation.PenaltyNoShow:
+		outcome = domain.CancellationNoShow
+	}
+
+	classStudent, err := s.classStudentRepo.CancelParticipation(classID, studentID, studentID, outcome)
+	if err != nil {
+		return nil, err
+	}
+
+	// Cancelamento dentro do prazo devolve o crédito; fora dele a aula conta como usada
+	if outcome == domain.CancellationOnTime {
+		s.credits.RefundClass(studentID, classID)
+	}
+
+	if err := s.waitlistService.PromoteNext(classID); err != nil {
+		fmt.Printf("Warning: failed to promote waitlist of class %s: %v\n", classID, err)
+	}
+
+	return classStudent, nil
+}
+
 func (s *classService) MarkAttendance(classID, studentID uuid.UUID, attended bool) error {
-	return s.classStudentRepo.MarkAttendance(classID, studentID, attended)
+	if err := s.classStudentRepo.MarkAttendance(classID, studentID, attended); err != nil {
+		return err
+	}
+
+	// Presença registrada depois do encerramento automático corrige uma falta (no_show)
+	if attended {
+		err := s.classRepo.TransitionStatus(classID, domain.ClassStatusNoShow, domain.ClassStatusCompleted)
+		if err != nil && !errors.Is(err, repository.ErrNotFound) {
+			return err
+		}
+		if err == nil {
+			s.credits.ChargeEnrolled(classID)
+		}
+	}
+
+	return nil
+}
+
+// BookOccurrence reserva uma vaga para o aluno na ocorrência escolhida. A aula é
+// criada na primeira reserva; as seguintes entram na mesma turma até a lotação.
+func (s *classService) BookOccurrence(studentID uuid.UUID, input *BookOccurrenceInput) (*domain.Class, error) {
+	date, err := time.Parse(dateLayout, input.Date)
+	if err != nil {
+		return nil, errors.New("invalid date (use YYYY-MM-DD)")
+	}
+
+	slot, err := s.timeSlotRepo.GetByID(input.TimeSlotID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get time slot: %w", err)
+	}
+
+	if !slot.IsAvailable {
+		return nil, errors.New("time slot is not available")
+	}
+
+	if slot.DayOfWeek != domain.DayOfWeek(date.Weekday()) {
+		return nil, errors.New("date does not match the time slot day of week")
+	}
+
+	// Cada data é reservada na versão do horário que vale nela
+	if !slot.Covers(date) {
+		return nil, errors.New("time slot is not valid on this date")
+	}
+
+	startsAt, endsAt, err := s.timezones.ClassTimes(date, slot.StartTime, slot.EndTime)
+	if err != nil {
+		return nil, err
+	}
+
+	// Não é possível reservar ocorrências passadas ou já iniciadas
+	if !startsAt.After(time.Now()) {
+		return nil, errors.New("cannot book a past time slot occurrence")
+	}
+
+	holiday, err := s.holidays.Check(date)
+	if err != nil {
+		return nil, err
+	}
+
+	// A turma da própria ocorrência fica de fora: reservar de novo é tratado pelo repositório
+	overlaps, err := s.classRepo.GetStudentOverlaps(studentID, date, clockTime(slot.StartTime), clockTime(slot.EndTime), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	conflict := domain.StudentConflict{StudentID: studentID}
+	for _, overlap := range overlaps {
+		if overlap.TimeSlotID == nil || *overlap.TimeSlotID != slot.ID {
+			conflict.Classes = append(conflict.Classes, overlap)
+		}
+	}
+	if len(conflict.Classes) > 0 {
+		return nil, &StudentConflictError{Conflicts: []domain.StudentConflict{conflict}}
+	}
+
+	class, created, err := s.bookingRepo.BookOccurrence(slot.ID, date, studentID, startsAt, endsAt)
+	if err != nil {
+		return nil, err
+	}
+	s.credits.NotifyLowBalance(studentID)
+
+	if holiday != nil {
+		class.Warnings = append(class.Warnings, holidayWarning(holiday))
+	}
+
+	go s.sendClassNotificationToStudent(studentID, class)
+
+	if created {
+		go s.sendClassNotificationToTeacher(class.TeacherID, class, 1)
+	}
+
+	return class, nil
+}
+
+// chargeEnrollment debita a matrícula feita pela escola. Sem saldo, a aula é debitada
+// quando for realizada, se o aluno tiver créditos até lá.
+func (s *classService) chargeEnrollment(studentID, classID uuid.UUID) {
+	if err := s.credits.ChargeClass(studentID, classID); err != nil && !errors.Is(err, repository.ErrNoCredits) {
+		fmt.Printf("Warning: failed to debit credit of student %s: %v\n", studentID, err)
+	}
+}
+
+// checkStudentConflicts recusa o horário se algum aluno já tiver outra aula nele.
+// Com override, apenas admins podem agendar mesmo assim.
+func (s *classService) checkStudentConflicts(actorID uuid.UUID, override bool, studentIDs []uuid.UUID, date time.Time, startTime, endTime string, excludeClassID *uuid.UUID) error {
+	if override {
+		auth, err := s.authRepo.GetByID(actorID)
+		if err != nil {
+			return fmt.Errorf("failed to get auth: %w", err)
+		}
+
+		if auth.Role != domain.RoleAdmin {
+			return ErrOverrideNotAllowed
+		}
+		return nil
+	}
+
+	return findStudentConflicts(s.classRepo, studentIDs, date, startTime, endTime, excludeClassID)
+}
+
+// findStudentConflicts retorna um StudentConflictError com as aulas de cada aluno que
+// colidem com o intervalo, ou nil se todos estiverem livres
+func findStudentConflicts(classRepo repository.ClassRepository, studentIDs []uuid.UUID, date time.Time, startTime, endTime string, excludeClassID *uuid.UUID) error {
+	conflict := &StudentConflictError{}
+	for _, studentID := range studentIDs {
+		classes, err := classRepo.GetStudentOverlaps(studentID, date, startTime, endTime, excludeClassID)
+		if err != nil {
+			return err
+		}
+
+		if len(classes) > 0 {
+			conflict.Conflicts = append(conflict.Conflicts, domain.StudentConflict{
+				StudentID: studentID,
+				Classes:   classes,
+			})
+		}
+	}
+
+	if len(conflict.Conflicts) > 0 {
+		return conflict
+	}
+
+	return nil
+}
+
+func (s *classService) DeleteClass(id uuid.UUID) error {
+	if err := s.classRepo.Delete(id); err != nil {
+		return err
+	}
+	s.expireOffers(id)
+
+	return nil
+}
+
+// expireOffers encerra as vagas oferecidas à lista de espera de uma aula que deixou de acontecer
+func (s *classService) expireOffers(classID uuid.UUID) {
+	if err := s.waitlistRepo.ExpireClassOffers(classID); err != nil {
+		fmt.Printf("Warning: failed to expire waitlist offers of class %s: %v\n", classID, err)
+	}
+}
+
+func (s *classService) RestoreClass(id uuid.UUID) error {
+	class, err := s.classRepo.GetDeletedByID(id)
+	if err != nil {
+		return fmt.Errorf("failed to get deleted class: %w", err)
+	}
+
+	// Uma aula agendada só volta se o professor ainda estiver livre no horário
+	if class.Status == domain.ClassStatusScheduled {
+		available, err := s.classRepo.CheckTeacherAvailability(class.TeacherID, class.ScheduledDate, clockTime(class.StartTime), clockTime(class.EndTime), nil)
+		if err != nil {
+			return fmt.Errorf("failed to check availability: %w", err)
+		}
+
+		if !available {
+			return errors.New("teacher not available at this time")
+		}
+	}
+
+	return s.classRepo.Restore(id)
 }
 
 // Funções de envio de email
@@ -300,79 +786,37 @@ func (s *classService) sendClassNotificationToStudent(studentID uuid.UUID, class
 		return
 	}
 
-	teacher, _ := s.userRepo.GetByAuthID(class.TeacherID)
+	data := buildClassEmailData(s.userRepo, student.FullName, class, s.timezones.ForUser(studentID))
+	data.Calendar = classCalendar(class)
+
+	if err := s.emailService.SendClassScheduledEmail(studentAuth.Email, data); err != nil {
+		fmt.Printf("Failed to send email to student: %v\n", err)
+	}
+}
+
+// buildClassEmailData monta os dados da aula usados nos templates de email, com
+// data e horários no fuso do destinatário
+func buildClassEmailData(userRepo repository.UserRepository, studentName string, class *domain.Class, loc *time.Location) email.ClassEmailData {
+	teacher, _ := userRepo.GetByAuthID(class.TeacherID)
 
 	teacherName := "Seu Professor"
 	if teacher != nil {
 		teacherName = teacher.FullName
 	}
 
-	title := ""
+	data := localSchedule(class, loc)
+	data.StudentName = studentName
+	data.TeacherName = teacherName
+
 	if class.Title != nil {
-		title = *class.Title
+		data.Title = *class.Title
 	}
 
-	classLink := ""
 	if class.ClassLink != nil {
-		classLink = *class.ClassLink
+		data.ClassLink = *class.ClassLink
 	}
 
-	subject := "Nova Aula Agendada - Potential Idiomas"
-	body := fmt.Sprintf(`
-<!DOCTYPE html>
-<html>
-<head>
-    <meta charset="UTF-8">
-    <title>Aula Agendada</title>
-</head>
-<body style="font-family: Arial, sans-serif; line-height: 1.6; color: #333;">
-    <div style="max-width: 600px; margin: 0 auto; padding: 20px;">
-        <h2 style="color: #3498db;">Nova Aula Agendada!</h2>
-        <p>Olá, %s!</p>
-        <p>Uma nova aula foi agendada para você.</p>
-        <div style="background-color: #f8f9fa; padding: 20px; border-radius: 5px; margin: 20px 0;">
-            <p style="margin: 5px 0;"><strong>Data:</strong> %s</p>
-            <p style="margin: 5px 0;"><strong>Horário:</strong> %s - %s</p>
-            <p style="margin: 5px 0;"><strong>Professor:</strong> %s</p>
-            %s
-            %s
-        </div>
-        <p style="color: #7f8c8d; font-size: 14px;">
-            Não esqueça de comparecer no horário marcado!
-        </p>
-    </div>
-</body>
-</html>
-	`,
-		student.FullName,
-		class.ScheduledDate.Format("02/01/2006"),
-		class.StartTime[:5], // "14:00:00" -> "14:00"
-		class.EndTime[:5],
-		teacherName,
-		func() string {
-			if title != "" {
-				return fmt.Sprintf("<p style=\"margin: 5px 0;\"><strong>Tema:</strong> %s</p>", title)
-			}
-			return ""
-		}(),
-		func() string {
-			if classLink != "" {
-				return fmt.Sprintf(`
-            <div style="text-align: center; margin-top: 20px;">
-                <a href="%s" 
-                   style="background-color: #27ae60; color: white; padding: 12px 30px; 
-                          text-decoration: none; border-radius: 5px; display: inline-block;">
-                    Entrar na Aula
-                </a>
-            </div>`, classLink)
-			}
-			return ""
-		}(),
-	)
-
-	if err := s.emailService.SendCustomEmail(studentAuth.Email, subject, body); err != nil {
-		fmt.Printf("Failed to send email to student: %v\n", err)
-	}
+	return data
 }
 
 func (s *classService) sendClassNotificationToTeacher(teacherID uuid.UUID, class *domain.Class, studentCount int) {
@@ -393,6 +837,8 @@ func (s *classService) sendClassNotificationToTeacher(teacherID uuid.UUID, class
 		title = *class.Title
 	}
 
+	schedule := localSchedule(class, s.timezones.ForUser(teacherID))
+
 	subject := "Nova Aula Agendada - Potential Idiomas"
 	body := fmt.Sprintf(`
 <!DOCTYPE html>
@@ -408,7 +854,7 @@ func (s *classService) sendClassNotificationToTeacher(teacherID uuid.UUID, class
         <p>Uma nova aula foi agendada no seu calendário.</p>
         <div style="background-color: #f8f9fa; padding: 20px; border-radius: 5px; margin: 20px 0;">
             <p style="margin: 5px 0;"><strong>Data:</strong> %s</p>
-            <p style="margin: 5px 0;"><strong>Horário:</strong> %s - %s</p>
+            <p style="margin: 5px 0;"><strong>Horário:</strong> %s - %s (%s)</p>
             <p style="margin: 5px 0;"><strong>Alunos matriculados:</strong> %d</p>
             %s
         </div>
@@ -420,9 +866,10 @@ func (s *classService) sendClassNotificationToTeacher(teacherID uuid.UUID, class
 </html>
 	`,
 		teacher.FullName,
-		class.ScheduledDate.Format("02/01/2006"),
-		class.StartTime[:5],
-		class.EndTime[:5],
+		schedule.Date,
+		schedule.StartTime,
+		schedule.EndTime,
+		schedule.Timezone,
 		studentCount,
 		func() string {
 			if title != "" {
@@ -432,7 +879,7 @@ func (s *classService) sendClassNotificationToTeacher(teacherID uuid.UUID, class
 		}(),
 	)
 
-	if err := s.emailService.SendCustomEmail(teacherAuth.Email, subject, body); err != nil {
+	if err := s.emailService.SendCustomEmail(teacherAuth.Email, subject, body, email.CalendarAttachment(classCalendar(class))...); err != nil {
 		fmt.Printf("Failed to send email to teacher: %v\n", err)
 	}
 }
@@ -459,6 +906,8 @@ func (s *classService) sendClassCancellationToStudent(studentID uuid.UUID, class
 		title = *class.Title
 	}
 
+	schedule := localSchedule(class, s.timezones.ForUser(studentID))
+
 	subject := "Aula Cancelada - Potential Idiomas"
 	body := fmt.Sprintf(`
 <!DOCTYPE html>
@@ -474,7 +923,7 @@ func (s *classService) sendClassCancellationToStudent(studentID uuid.UUID, class
         <p>Infelizmente, a aula abaixo foi cancelada:</p>
         <div style="background-color: #fee; padding: 20px; border-radius: 5px; margin: 20px 0; border-left: 4px solid #e74c3c;">
             <p style="margin: 5px 0;"><strong>Data:</strong> %s</p>
-            <p style="margin: 5px 0;"><strong>Horário:</strong> %s - %s</p>
+            <p style="margin: 5px 0;"><strong>Horário:</strong> %s - %s (%s)</p>
             <p style="margin: 5px 0;"><strong>Professor:</strong> %s</p>
             %s
         </div>
@@ -487,9 +936,10 @@ func (s *classService) sendClassCancellationToStudent(studentID uuid.UUID, class
 </html>
 	`,
 		student.FullName,
-		class.ScheduledDate.Format("02/01/2006"),
-		class.StartTime[:5],
-		class.EndTime[:5],
+		schedule.Date,
+		schedule.StartTime,
+		schedule.EndTime,
+		schedule.Timezone,
 		teacherName,
 		func() string {
 			if title != "" {
@@ -499,7 +949,7 @@ func (s *classService) sendClassCancellationToStudent(studentID uuid.UUID, class
 		}(),
 	)
 
-	if err := s.emailService.SendCustomEmail(studentAuth.Email, subject, body); err != nil {
+	if err := s.emailService.SendCustomEmail(studentAuth.Email, subject, body, email.CalendarAttachment(classCalendar(class))...); err != nil {
 		fmt.Printf("Failed to send cancellation email to student: %v\n", err)
 	}
 }
@@ -520,6 +970,8 @@ func (s *classService) sendClassCancellationToTeacher(teacherID uuid.UUID, class
 		title = *class.Title
 	}
 
+	schedule := localSchedule(class, s.timezones.ForUser(teacherID))
+
 	subject := "Aula Cancelada - Potential Idiomas"
 	body := fmt.Sprintf(`
 <!DOCTYPE html>
@@ -535,7 +987,7 @@ func (s *classService) sendClassCancellationToTeacher(teacherID uuid.UUID, class
         <p>A aula abaixo foi cancelada:</p>
         <div style="background-color: #fee; padding: 20px; border-radius: 5px; margin: 20px 0; border-left: 4px solid #e74c3c;">
             <p style="margin: 5px 0;"><strong>Data:</strong> %s</p>
-            <p style="margin: 5px 0;"><strong>Horário:</strong> %s - %s</p>
+            <p style="margin: 5px 0;"><strong>Horário:</strong> %s - %s (%s)</p>
             %s
         </div>
     </div>
@@ -543,9 +995,10 @@ func (s *classService) sendClassCancellationToTeacher(teacherID uuid.UUID, class
 </html>
 	`,
 		teacher.FullName,
-		class.ScheduledDate.Format("02/01/2006"),
-		class.StartTime[:5],
-		class.EndTime[:5],
+		schedule.Date,
+		schedule.StartTime,
+		schedule.EndTime,
+		schedule.Timezone,
 		func() string {
 			if title != "" {
 				return fmt.Sprintf("<p style=\"margin: 5px 0;\"><strong>Tema:</strong> %s</p>", title)
@@ -554,7 +1007,7 @@ func (s *classService) sendClassCancellationToTeacher(teacherID uuid.UUID, class
 		}(),
 	)
 
-	if err := s.emailService.SendCustomEmail(teacherAuth.Email, subject, body); err != nil {
+	if err := s.emailService.SendCustomEmail(teacherAuth.Email, subject, body, email.CalendarAttachment(classCalendar(class))...); err != nil {
 		fmt.Printf("Failed to send cancellation email to teacher: %v\n", err)
 	}
 }
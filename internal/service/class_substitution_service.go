@@ -0,0 +1,295 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hscHeric/go-potential-api/internal/domain"
+	"github.com/hscHeric/go-potential-api/internal/repository"
+	"github.com/hscHeric/go-potential-api/pkg/email"
+)
+
+var (
+	// ErrNotClassTeacher indica um professor tentando alterar a aula de outro professor
+	ErrNotClassTeacher = errors.New("only the class teacher or an admin can do this")
+	// ErrSubstituteUnavailable indica um substituto com outra aula ou de folga no horário
+	ErrSubstituteUnavailable = errors.New("substitute teacher is not available at this time")
+	// ErrSubstituteLanguage indica um substituto que não ensina o idioma da aula
+	ErrSubstituteLanguage = errors.New("substitute teacher does not teach the class language")
+)
+
+// ClassSubstitutionService passa aulas de um professor impedido para um substituto
+type ClassSubstitutionService interface {
+	SuggestSubstitutes(classID, requestedBy uuid.UUID) ([]domain.SubstituteCandidate, error)
+	AssignSubstitute(classID, requestedBy uuid.UUID, input *AssignSubstituteInput) (*domain.Class, error)
+}
+
+type AssignSubstituteInput struct {
+	TeacherID uuid.UUID `json:"teacher_id" binding:"required"`
+	Reason    *string   `json:"reason"`
+}
+
+type classSubstitutionService struct {
+	classRepo          repository.ClassRepository
+	classStudentRepo   repository.ClassStudentRepository
+	teacherProfileRepo repository.TeacherProfileRepository
+	userRepo           repository.UserRepository
+	authRepo           repository.AuthRepository
+	emailService       *email.Service
+	timezones          TimezoneService
+}
+
+func NewClassSubstitutionService(
+	classRepo repository.ClassRepository,
+	classStudentRepo repository.ClassStudentRepository,
+	teacherProfileRepo repository.TeacherProfileRepository,
+	userRepo repository.UserRepository,
+	authRepo repository.AuthRepository,
+	emailService *email.Service,
+	timezones TimezoneService,
+) ClassSubstitutionService {
+	return &classSubstitutionService{
+		classRepo:          classRepo,
+		classStudentRepo:   classStudentRepo,
+		teacherProfileRepo: teacherProfileRepo,
+		userRepo:           userRepo,
+		authRepo:           authRepo,
+		emailService:       emailService,
+		timezones:          timezones,
+	}
+}
+
+// SuggestSubstitutes lista os professores ativos que ensinam o idioma da aula e estão
+// livres no horário dela (sem outra aula e fora de folgas)
+func (s *classSubstitutionService) SuggestSubstitutes(classID, requestedBy uuid.UUID) ([]domain.SubstituteCandidate, error) {
+	class, err := s.substitutableClass(classID, requestedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := domain.TeacherDirectoryFilter{}
+	if class.Language != nil {
+		filter.Language = *class.Language
+	}
+
+	teachers, err := s.teacherProfileRepo.List(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := []domain.SubstituteCandidate{}
+	for _, teacher := range teachers {
+		if teacher.TeacherID == class.TeacherID {
+			continue
+		}
+
+		available, err := s.classRepo.CheckTeacherAvailability(teacher.TeacherID, class.ScheduledDate, clockTime(class.StartTime), clockTime(class.EndTime), nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check availability: %w", err)
+		}
+		if !available {
+			continue
+		}
+
+		candidates = append(candidates, domain.SubstituteCandidate{
+			TeacherID: teacher.TeacherID,
+			FullName:  teacher.FullName,
+			Languages: teacher.Profile.Languages,
+			Levels:    teacher.Profile.Levels,
+		})
+	}
+
+	return candidates, nil
+}
+
+// AssignSubstitute passa a aula para o substituto, mantendo o titular em
+// OriginalTeacherID, e avisa os alunos e os dois professores
+func (s *classSubstitutionService) AssignSubstitute(classID, requestedBy uuid.UUID, input *AssignSubstituteInput) (*domain.Class, error) {
+	class, err := s.substitutableClass(classID, requestedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.TeacherID == class.TeacherID {
+		return nil, errors.New("substitute teacher is already the class teacher")
+	}
+
+	substitute, err := s.authRepo.GetByID(input.TeacherID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get substitute teacher: %w", err)
+	}
+
+	if substitute.Role != domain.RoleTeacher || substitute.Status != domain.StatusActive {
+		return nil, errors.New("substitute must be an active teacher")
+	}
+
+	if class.Language != nil {
+		profile, err := s.teacherProfileRepo.GetByTeacherID(input.TeacherID)
+		if err != nil && !errors.Is(err, repository.ErrNotFound) {
+			return nil, err
+		}
+
+		if profile == nil || !teachesLanguage(profile, *class.Language) {
+			return nil, ErrSubstituteLanguage
+		}
+	}
+
+	available, err := s.classRepo.CheckTeacherAvailability(input.TeacherID, class.ScheduledDate, clockTime(class.StartTime), clockTime(class.EndTime), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check availability: %w", err)
+	}
+
+	if !available {
+		return nil, ErrSubstituteUnavailable
+	}
+
+	previousTeacherID := class.TeacherID
+
+	if err := s.classRepo.AssignTeacher(classID, input.TeacherID, input.Reason); err != nil {
+		return nil, err
+	}
+
+	updated, err := s.classRepo.GetByID(classID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.notifySubstitution(updated, previousTeacherID)
+
+	return updated, nil
+}
+
+// substitutableClass busca a aula e verifica se ela ainda pode trocar de professor.
+// Professores só podem passar adiante as próprias aulas.
+func (s *classSubstitutionService) substitutableClass(classID, requestedBy uuid.UUID) (*domain.Class, error) {
+	class, err := s.classRepo.GetByID(classID)
+	if err != nil {
+		return nil, err
+	}
+
+	auth, err := s.authRepo.GetByID(requestedBy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get auth: %w", err)
+	}
+
+	if auth.Role != domain.RoleAdmin && class.TeacherID != requestedBy {
+		return nil, ErrNotClassTeacher
+	}
+
+	if class.Status != domain.ClassStatusScheduled {
+		return nil, errors.New("only scheduled classes can be substituted")
+	}
+
+	if !class.StartsAt.After(time.Now()) {
+		return nil, errors.New("cannot substitute the teacher of a class that already started")
+	}
+
+	return class, nil
+}
+
+func teachesLanguage(profile *domain.TeacherProfile, language string) bool {
+	language = NormalizeLanguage(language)
+	for _, taught := range profile.Languages {
+		if NormalizeLanguage(taught) == language {
+			return true
+		}
+	}
+	return false
+}
+
+// notifySubstitution avisa alunos, substituto e o professor que deixou a aula. O professor
+// anterior recebe o evento cancelado para que a aula saia do calendário dele.
+func (s *classSubstitutionService) notifySubstitution(class *domain.Class, previousTeacherID uuid.UUID) {
+	teacherName := "um novo professor"
+	if teacher, err := s.userRepo.GetByAuthID(class.TeacherID); err == nil {
+		teacherName = teacher.FullName
+	}
+
+	removed := *class
+	removed.Status = domain.ClassStatusCancelled
+
+	go s.sendSubstitutionEmail(previousTeacherID, &removed,
+		"Aula Repassada",
+		fmt.Sprintf("A aula abaixo foi repassada para %s e saiu da sua agenda.", teacherName))
+
+	go s.sendSubstitutionEmail(class.TeacherID, class,
+		"Nova Aula Como Substituto",
+		"Você foi designado para dar a aula abaixo como professor substituto.")
+
+	studentIDs, err := s.classStudentRepo.GetStudentsByClass(class.ID)
+	if err != nil {
+		fmt.Printf("Warning: failed to get students of class %s: %v\n", class.ID, err)
+		return
+	}
+
+	for _, studentID := range studentIDs {
+		go s.sendSubstitutionEmail(studentID, class,
+			"Professor Substituto",
+			fmt.Sprintf("A aula abaixo continua no mesmo horário, mas será dada por %s.", teacherName))
+	}
+}
+
+func (s *classSubstitutionService) sendSubstitutionEmail(authID uuid.UUID, class *domain.Class, subject, message string) {
+	auth, err := s.authRepo.GetByID(authID)
+	if err != nil {
+		fmt.Printf("Failed to get auth: %v\n", err)
+		return
+	}
+
+	name := auth.Email
+	if user, err := s.userRepo.GetByAuthID(authID); err == nil {
+		name = user.FullName
+	}
+
+	schedule := localSchedule(class, s.timezones.ForUser(authID))
+
+	title := ""
+	if class.Title != nil {
+		title = fmt.Sprintf("<p style=\"margin: 5px 0;\"><strong>Tema:</strong> %s</p>", *class.Title)
+	}
+
+	reason := ""
+	if class.SubstitutionReason != nil && *class.SubstitutionReason != "" {
+		reason = fmt.Sprintf("<p><strong>Motivo:</strong> %s</p>", *class.SubstitutionReason)
+	}
+
+	body := fmt.Sprintf(`
+<!DOCTYPE html>
+<html>
+<head>
+    <meta charset="UTF-8">
+    <title>%s</title>
+</head>
+<body style="font-family: Arial, sans-serif; line-height: 1.6; color: #333;">
+    <div style="max-width: 600px; margin: 0 auto; padding: 20px;">
+        <h2 style="color: #3498db;">%s</h2>
+        <p>Olá, %s!</p>
+        <p>%s</p>
+        <div style="background-color: #f8f9fa; padding: 20px; border-radius: 5px; margin: 20px 0;">
+            <p style="margin: 5px 0;"><strong>Data:</strong> %s</p>
+            <p style="margin: 5px 0;"><strong>Horário:</strong> %s - %s (%s)</p>
+            %s
+        </div>
+        %s
+    </div>
+</body>
+</html>
+	`,
+		subject,
+		subject,
+		name,
+		message,
+		schedule.Date,
+		schedule.StartTime,
+		schedule.EndTime,
+		schedule.Timezone,
+		title,
+		reason,
+	)
+
+	if err := s.emailService.SendCustomEmail(auth.Email, subject+" - Potential Idiomas", body, email.CalendarAttachment(classCalendar(class))...); err != nil {
+		fmt.Printf("Failed to send substitution email: %v\n", err)
+	}
+}
@@ -0,0 +1,230 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hscHeric/go-potential-api/internal/domain"
+	"github.com/hscHeric/go-potential-api/internal/repository"
+	"github.com/hscHeric/go-potential-api/pkg/email"
+)
+
+// CreditService controla os créditos de aula dos alunos: pacotes comprados, débitos por
+// aula, estornos e reposições
+type CreditService interface {
+	CreatePackage(studentID, createdBy uuid.UUID, input *CreatePackageInput) (*domain.CreditPackage, error)
+	GetBalance(studentID uuid.UUID) (*domain.CreditBalance, error)
+	GetLedger(studentID uuid.UUID) ([]domain.CreditEntry, error)
+	ChargeClass(studentID, classID uuid.UUID) error
+	NotifyLowBalance(studentID uuid.UUID)
+	ChargeEnrolled(classID uuid.UUID)
+	RefundClass(studentID, classID uuid.UUID)
+	GrantMakeup(studentID uuid.UUID, class *domain.Class)
+}
+
+type CreatePackageInput struct {
+	Name      string `json:"name" binding:"required,max=150"`
+	Credits   int    `json:"credits" binding:"required,min=1"`
+	ValidDays *int   `json:"valid_days" binding:"omitempty,min=1"` // Ausente: os créditos não expiram
+}
+
+type creditService struct {
+	creditRepo       repository.CreditRepository
+	classStudentRepo repository.ClassStudentRepository
+	userRepo         repository.UserRepository
+	authRepo         repository.AuthRepository
+	emailService     *email.Service
+	timezones        TimezoneService
+	lowBalance       int
+	makeupValidity   time.Duration
+}
+
+func NewCreditService(
+	creditRepo repository.CreditRepository,
+	classStudentRepo repository.ClassStudentRepository,
+	userRepo repository.UserRepository,
+	authRepo repository.AuthRepository,
+	emailService *email.Service,
+	timezones TimezoneService,
+	lowBalance int,
+	makeupValidity time.Duration,
+) CreditService {
+	return &creditService{
+		creditRepo:       creditRepo,
+		classStudentRepo: classStudentRepo,
+		userRepo:         userRepo,
+		authRepo:         authRepo,
+		emailService:     emailService,
+		timezones:        timezones,
+		lowBalance:       lowBalance,
+		makeupValidity:   makeupValidity,
+	}
+}
+
+func (s *creditService) CreatePackage(studentID, createdBy uuid.UUID, input *CreatePackageInput) (*domain.CreditPackage, error) {
+	auth, err := s.authRepo.GetByID(studentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if auth.Role != domain.RoleStudent {
+		return nil, errors.New("credit packages can only be assigned to students")
+	}
+
+	pkg := &domain.CreditPackage{
+		StudentID: studentID,
+		Kind:      domain.CreditPackageKindPackage,
+		Name:      strings.TrimSpace(input.Name),
+		Credits:   input.Credits,
+		CreatedBy: &createdBy,
+	}
+
+	if input.ValidDays != nil {
+		expiresAt := time.Now().Add(time.Duration(*input.ValidDays) * 24 * time.Hour)
+		pkg.ExpiresAt = &expiresAt
+	}
+
+	if err := s.creditRepo.CreatePackage(pkg); err != nil {
+		return nil, err
+	}
+
+	return pkg, nil
+}
+
+// GetBalance soma os créditos dos lotes ainda válidos e sinaliza o saldo baixo
+func (s *creditService) GetBalance(studentID uuid.UUID) (*domain.CreditBalance, error) {
+	packages, err := s.creditRepo.GetPackagesByStudent(studentID)
+	if err != nil {
+		return nil, err
+	}
+
+	balance := &domain.CreditBalance{
+		StudentID: studentID,
+		Packages:  []domain.CreditPackage{},
+	}
+
+	now := time.Now()
+	for _, pkg := range packages {
+		if !pkg.IsExpired(now) && pkg.Remaining > 0 {
+			balance.Available += pkg.Remaining
+		}
+		balance.Packages = append(balance.Packages, pkg)
+	}
+	balance.LowBalance = balance.Available <= s.lowBalance
+
+	return balance, nil
+}
+
+func (s *creditService) GetLedger(studentID uuid.UUID) ([]domain.CreditEntry, error) {
+	entries, err := s.creditRepo.GetLedger(studentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if entries == nil {
+		entries = []domain.CreditEntry{}
+	}
+
+	return entries, nil
+}
+
+// ChargeClass debita um crédito do aluno pela aula (uma única vez por aula) e avisa o
+// aluno quando o saldo chega ao limite de alerta
+func (s *creditService) ChargeClass(studentID, classID uuid.UUID) error {
+	entry, err := s.creditRepo.Debit(studentID, classID, time.Now())
+	if err != nil || entry == nil {
+		return err
+	}
+
+	s.NotifyLowBalance(studentID)
+
+	return nil
+}
+
+// NotifyLowBalance avisa o aluno quando o saldo, após um débito, chega ao limite de alerta.
+// Usado também pelas reservas que debitam o crédito na própria transação.
+func (s *creditService) NotifyLowBalance(studentID uuid.UUID) {
+	balance, err := s.GetBalance(studentID)
+	if err != nil {
+		fmt.Printf("Warning: failed to get credit balance of %s: %v\n", studentID, err)
+		return
+	}
+
+	// Alerta ao atingir o limite e ao zerar, sem repetir a cada aula
+	if balance.Available == s.lowBalance || (balance.Available == 0 && s.lowBalance > 0) {
+		go s.sendLowBalanceAlert(studentID, balance.Available)
+	}
+}
+
+// ChargeEnrolled debita a aula encerrada dos alunos matriculados que ainda não foram
+// debitados (ex.: adicionados pela escola sem saldo no momento). Vale para completed e
+// no_show: a vaga ficou reservada e o professor disponível, então a falta consome o
+// crédito como já acontece com quem reservou e pagou na reserva. O débito é único por
+// aula, então corrigir no_show para completed não cobra de novo.
+func (s *creditService) ChargeEnrolled(classID uuid.UUID) {
+	studentIDs, err := s.classStudentRepo.GetStudentsByClass(classID)
+	if err != nil {
+		fmt.Printf("Warning: failed to get students of class %s for credit debit: %v\n", classID, err)
+		return
+	}
+
+	for _, studentID := range studentIDs {
+		if err := s.ChargeClass(studentID, classID); err != nil {
+			fmt.Printf("Warning: failed to debit credit of class %s from %s: %v\n", classID, studentID, err)
+		}
+	}
+}
+
+// RefundClass devolve o crédito debitado pela aula, se houver
+func (s *creditService) RefundClass(studentID, classID uuid.UUID) {
+	if _, err := s.creditRepo.Refund(studentID, classID); err != nil {
+		fmt.Printf("Warning: failed to refund credit of class %s to %s: %v\n", classID, studentID, err)
+	}
+}
+
+// GrantMakeup concede ao aluno debitado pela aula um crédito de reposição com validade
+func (s *creditService) GrantMakeup(studentID uuid.UUID, class *domain.Class) {
+	schedule := localSchedule(class, s.timezones.ForUser(studentID))
+	name := fmt.Sprintf("Reposição da aula de %s", schedule.Date)
+
+	if _, err := s.creditRepo.GrantMakeup(studentID, class.ID, name, time.Now().Add(s.makeupValidity)); err != nil {
+		fmt.Printf("Warning: failed to grant makeup credit of class %s to %s: %v\n", class.ID, studentID, err)
+	}
+}
+
+func (s *creditService) sendLowBalanceAlert(studentID uuid.UUID, available int) {
+	auth, err := s.authRepo.GetByID(studentID)
+	if err != nil {
+		return
+	}
+
+	name := auth.Email
+	if user, err := s.userRepo.GetByAuthID(studentID); err == nil {
+		name = user.FullName
+	}
+
+	body := fmt.Sprintf(`
+<!DOCTYPE html>
+<html>
+<head>
+    <meta charset="UTF-8">
+    <title>Saldo de aulas baixo</title>
+</head>
+<body style="font-family: Arial, sans-serif; line-height: 1.6; color: #333;">
+    <div style="max-width: 600px; margin: 0 auto; padding: 20px;">
+        <h2 style="color: #e67e22;">Saldo de aulas baixo</h2>
+        <p>Olá, %s!</p>
+        <p>Você tem <strong>%d crédito(s)</strong> de aula disponível(is).</p>
+        <p>Fale com a escola para renovar o seu pacote e continuar reservando aulas.</p>
+    </div>
+</body>
+</html>
+	`, name, available)
+
+	if err := s.emailService.SendCustomEmail(auth.Email, "Saldo de aulas baixo - Potential Idiomas", body); err != nil {
+		fmt.Printf("Warning: failed to send low balance alert to %s: %v\n", studentID, err)
+	}
+}
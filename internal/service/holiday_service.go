@@ -0,0 +1,312 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hscHeric/go-potential-api/internal/domain"
+	"github.com/hscHeric/go-potential-api/internal/repository"
+	"github.com/hscHeric/go-potential-api/pkg/holidays"
+)
+
+// HolidayPolicy define o que acontece ao agendar aulas em feriados
+type HolidayPolicy string
+
+const (
+	HolidayPolicyBlock HolidayPolicy = "block" // Agendamento recusado
+	HolidayPolicyWarn  HolidayPolicy = "warn"  // Agendamento aceito com aviso
+)
+
+// IsValid verifica se a política é conhecida
+func (p HolidayPolicy) IsValid() bool {
+	return p == HolidayPolicyBlock || p == HolidayPolicyWarn
+}
+
+// ErrHoliday indica que a data é feriado e a política bloqueia o agendamento
+var ErrHoliday = errors.New("date is a holiday")
+
+// HolidayChecker consulta o calendário de feriados aplicando a política configurada
+type HolidayChecker interface {
+	// Check retorna o feriado da data (nil se não houver). Com a política "block"
+	// também retorna um erro que envolve ErrHoliday.
+	Check(date time.Time) (*domain.Holiday, error)
+	// InRange retorna os feriados do intervalo indexados por data ("2006-01-02")
+	InRange(from, to time.Time) (map[string]domain.Holiday, error)
+	Blocks() bool
+}
+
+type holidayChecker struct {
+	holidayRepo repository.HolidayRepository
+	policy      HolidayPolicy
+}
+
+func NewHolidayChecker(holidayRepo repository.HolidayRepository, policy HolidayPolicy) HolidayChecker {
+	return &holidayChecker{
+		holidayRepo: holidayRepo,
+		policy:      policy,
+	}
+}
+
+func (c *holidayChecker) Check(date time.Time) (*domain.Holiday, error) {
+	holiday, err := c.holidayRepo.GetByDate(date)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	if c.Blocks() {
+		return holiday, fmt.Errorf("%w: %s", ErrHoliday, holiday.Name)
+	}
+
+	return holiday, nil
+}
+
+func (c *holidayChecker) InRange(from, to time.Time) (map[string]domain.Holiday, error) {
+	list, err := c.holidayRepo.GetInRange(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	byDate := make(map[string]domain.Holiday, len(list))
+	for _, holiday := range list {
+		date := holiday.Date.Format(dateLayout)
+		if _, ok := byDate[date]; !ok {
+			byDate[date] = holiday
+		}
+	}
+
+	return byDate, nil
+}
+
+func (c *holidayChecker) Blocks() bool {
+	return c.policy == HolidayPolicyBlock
+}
+
+// holidayWarning é o aviso anexado a aulas marcadas em feriado com a política "warn"
+func holidayWarning(holiday *domain.Holiday) string {
+	return fmt.Sprintf("date is a holiday: %s", holiday.Name)
+}
+
+type HolidayService interface {
+	CreateHoliday(createdBy uuid.UUID, input *HolidayInput) (*domain.HolidayReport, error)
+	GetHoliday(id uuid.UUID) (*domain.HolidayReport, error)
+	GetHolidays(from, to time.Time) ([]domain.Holiday, error)
+	UpdateHoliday(id uuid.UUID, input *HolidayInput) (*domain.Holiday, error)
+	DeleteHoliday(id uuid.UUID) error
+	GenerateNational(year int) ([]domain.Holiday, error)
+	ResolveClasses(id, adminID uuid.UUID, input *ResolveHolidayClassesInput) ([]domain.HolidayClassResult, error)
+}
+
+type HolidayInput struct {
+	Date  string              `json:"date" binding:"required"` // Format: "2006-01-02"
+	Name  string              `json:"name" binding:"required,max=150"`
+	Scope domain.HolidayScope `json:"scope" binding:"omitempty,oneof=national state municipal school"`
+}
+
+type GenerateHolidaysInput struct {
+	Year int `json:"year" binding:"required,min=2000,max=2100"`
+}
+
+type ResolveHolidayClassesInput struct {
+	Action  domain.HolidayClassAction `json:"action" binding:"required,oneof=cancel reschedule"`
+	NewDate *string                   `json:"new_date"` // Format: "2006-01-02" (default: mesma data na semana seguinte)
+}
+
+type holidayService struct {
+	holidayRepo       repository.HolidayRepository
+	classRepo         repository.ClassRepository
+	classService      ClassService
+	rescheduleService ClassRescheduleService
+}
+
+func NewHolidayService(
+	holidayRepo repository.HolidayRepository,
+	classRepo repository.ClassRepository,
+	classService ClassService,
+	rescheduleService ClassRescheduleService,
+) HolidayService {
+	return &holidayService{
+		holidayRepo:       holidayRepo,
+		classRepo:         classRepo,
+		classService:      classService,
+		rescheduleService: rescheduleService,
+	}
+}
+
+// CreateHoliday cadastra o feriado e lista as aulas já agendadas na data
+func (s *holidayService) CreateHoliday(createdBy uuid.UUID, input *HolidayInput) (*domain.HolidayReport, error) {
+	date, err := time.Parse(dateLayout, input.Date)
+	if err != nil {
+		return nil, errors.New("invalid date (use YYYY-MM-DD)")
+	}
+
+	holiday := &domain.Holiday{
+		Date:      date,
+		Name:      input.Name,
+		Scope:     input.Scope,
+		CreatedBy: &createdBy,
+	}
+
+	if holiday.Scope == "" {
+		holiday.Scope = domain.HolidayScopeSchool
+	}
+
+	if err := s.holidayRepo.Create(holiday); err != nil {
+		return nil, err
+	}
+
+	return s.buildReport(holiday)
+}
+
+func (s *holidayService) GetHoliday(id uuid.UUID) (*domain.HolidayReport, error) {
+	holiday, err := s.holidayRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.buildReport(holiday)
+}
+
+func (s *holidayService) GetHolidays(from, to time.Time) ([]domain.Holiday, error) {
+	if to.Before(from) {
+		return nil, fmt.Errorf("%w: 'to' must not be before 'from'", ErrInvalidDateRange)
+	}
+
+	return s.holidayRepo.GetInRange(from, to)
+}
+
+func (s *holidayService) UpdateHoliday(id uuid.UUID, input *HolidayInput) (*domain.Holiday, error) {
+	holiday, err := s.holidayRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	date, err := time.Parse(dateLayout, input.Date)
+	if err != nil {
+		return nil, errors.New("invalid date (use YYYY-MM-DD)")
+	}
+
+	holiday.Date = date
+	holiday.Name = input.Name
+	if input.Scope != "" {
+		holiday.Scope = input.Scope
+	}
+
+	if err := s.holidayRepo.Update(holiday); err != nil {
+		return nil, err
+	}
+
+	return holiday, nil
+}
+
+func (s *holidayService) DeleteHoliday(id uuid.UUID) error {
+	return s.holidayRepo.Delete(id)
+}
+
+// GenerateNational cadastra os feriados nacionais do ano. Feriados já cadastrados
+// são ignorados, então o gerador pode ser executado mais de uma vez.
+func (s *holidayService) GenerateNational(year int) ([]domain.Holiday, error) {
+	created := []domain.Holiday{}
+
+	for _, h := range holidays.National(year) {
+		holiday := &domain.Holiday{
+			Date:  h.Date,
+			Name:  h.Name,
+			Scope: domain.HolidayScopeNational,
+		}
+
+		ok, err := s.holidayRepo.CreateIfNotExists(holiday)
+		if err != nil {
+			return nil, err
+		}
+
+		if ok {
+			created = append(created, *holiday)
+		}
+	}
+
+	return created, nil
+}
+
+// ResolveClasses cancela ou remarca em lote as aulas agendadas no feriado. A remarcação
+// segue o fluxo normal: aulas com alunos ficam pendentes até a confirmação deles.
+func (s *holidayService) ResolveClasses(id, adminID uuid.UUID, input *ResolveHolidayClassesInput) ([]domain.HolidayClassResult, error) {
+	holiday, err := s.holidayRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	newDate := holiday.Date.AddDate(0, 0, 7)
+	if input.NewDate != nil {
+		newDate, err = time.Parse(dateLayout, *input.NewDate)
+		if err != nil {
+			return nil, errors.New("invalid new_date (use YYYY-MM-DD)")
+		}
+	}
+
+	classes, err := s.classRepo.GetScheduledByDate(holiday.Date)
+	if err != nil {
+		return nil, err
+	}
+
+	reason := fmt.Sprintf("Feriado: %s", holiday.Name)
+	results := make([]domain.HolidayClassResult, 0, len(classes))
+
+	for _, class := range classes {
+		result := domain.HolidayClassResult{ClassID: class.ID}
+
+		switch input.Action {
+		case domain.HolidayClassActionCancel:
+			if err := s.classService.CancelClass(class.ID, adminID); err != nil {
+				result.Status = "failed"
+				result.Error = err.Error()
+			} else {
+				result.Status = "cancelled"
+			}
+
+		case domain.HolidayClassActionReschedule:
+			reschedule, err := s.rescheduleService.ProposeReschedule(class.ID, adminID, &RescheduleClassInput{
+				ScheduledDate: newDate.Format(dateLayout),
+				StartTime:     clockTime(class.StartTime),
+				EndTime:       clockTime(class.EndTime),
+				Reason:        &reason,
+			})
+			switch {
+			case err != nil:
+				result.Status = "failed"
+				result.Error = err.Error()
+			case reschedule.Status == domain.RescheduleStatusApplied:
+				result.Status = "rescheduled"
+			default:
+				result.Status = "proposed"
+			}
+
+		default:
+			return nil, errors.New("action must be 'cancel' or 'reschedule'")
+		}
+
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+func (s *holidayService) buildReport(holiday *domain.Holiday) (*domain.HolidayReport, error) {
+	classes, err := s.classRepo.GetScheduledByDate(holiday.Date)
+	if err != nil {
+		return nil, err
+	}
+
+	if classes == nil {
+		classes = []domain.Class{}
+	}
+
+	return &domain.HolidayReport{
+		Holiday:         *holiday,
+		AffectedClasses: classes,
+	}, nil
+}
@@ -0,0 +1,66 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hscHeric/go-potential-api/internal/repository"
+)
+
+// PurgeService remove definitivamente os registros em soft delete
+// há mais tempo que o período de retenção
+type PurgeService interface {
+	PurgeDeleted() (*PurgeResult, error)
+}
+
+type PurgeResult struct {
+	Users     int64 `json:"users"`
+	Classes   int64 `json:"classes"`
+	TimeSlots int64 `json:"time_slots"`
+}
+
+type purgeService struct {
+	userRepo     repository.UserRepository
+	classRepo    repository.ClassRepository
+	timeSlotRepo repository.TimeSlotRepository
+	retention    time.Duration
+}
+
+func NewPurgeService(
+	userRepo repository.UserRepository,
+	classRepo repository.ClassRepository,
+	timeSlotRepo repository.TimeSlotRepository,
+	retention time.Duration,
+) PurgeService {
+	return &purgeService{
+		userRepo:     userRepo,
+		classRepo:    classRepo,
+		timeSlotRepo: timeSlotRepo,
+		retention:    retention,
+	}
+}
+
+func (s *purgeService) PurgeDeleted() (*PurgeResult, error) {
+	before := time.Now().Add(-s.retention)
+	result := &PurgeResult{}
+
+	// Aulas antes dos horários, para que nenhuma aula excluída perca o vínculo com o horário antes da própria purga
+	var err error
+	if result.Classes, err = s.classRepo.PurgeDeleted(before); err != nil {
+		return nil, fmt.Errorf("failed to purge classes: %w", err)
+	}
+
+	if result.TimeSlots, err = s.timeSlotRepo.PurgeDeleted(before); err != nil {
+		return nil, fmt.Errorf("failed to purge time slots: %w", err)
+	}
+
+	if result.Users, err = s.userRepo.PurgeDeleted(before); err != nil {
+		return nil, fmt.Errorf("failed to purge users: %w", err)
+	}
+
+	if result.Users+result.Classes+result.TimeSlots > 0 {
+		fmt.Printf("Purge: %d users, %d classes, %d time slots removed\n", result.Users, result.Classes, result.TimeSlots)
+	}
+
+	return result, nil
+}
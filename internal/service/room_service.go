@@ -0,0 +1,368 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hscHeric/go-potential-api/internal/domain"
+	"github.com/hscHeric/go-potential-api/internal/repository"
+)
+
+// maxOccupancyDays limita o período da visão de ocupação das salas
+const maxOccupancyDays = 31
+
+var (
+	// ErrRoomUnavailable indica que a sala já está reservada para outra aula no horário
+	ErrRoomUnavailable = errors.New("room not available at this time")
+	// ErrRoomTooSmall indica que a sala não comporta os alunos da aula
+	ErrRoomTooSmall = errors.New("room capacity is too small for this class")
+	// ErrNoRoomAvailable indica que nenhuma sala da unidade está livre no horário
+	ErrNoRoomAvailable = errors.New("no room available at this time")
+)
+
+type RoomService interface {
+	CreateBranch(input *BranchInput) (*domain.Branch, error)
+	GetBranches(includeInactive bool) ([]domain.Branch, error)
+	UpdateBranch(id uuid.UUID, input *BranchInput) (*domain.Branch, error)
+	DeleteBranch(id uuid.UUID) error
+	CreateRoom(branchID uuid.UUID, input *RoomInput) (*domain.Room, error)
+	GetRoom(id uuid.UUID) (*domain.Room, error)
+	GetRooms(branchID uuid.UUID, includeInactive bool) ([]domain.Room, error)
+	UpdateRoom(id uuid.UUID, input *RoomInput) (*domain.Room, error)
+	DeleteRoom(id uuid.UUID) error
+	SuggestRooms(input *SuggestRoomsInput) ([]domain.Room, error)
+	GetOccupancy(branchID uuid.UUID, from, to time.Time) ([]domain.RoomOccupancy, error)
+	AssignClassRoom(classID, requestedBy uuid.UUID, input *AssignRoomInput) (*domain.Class, error)
+}
+
+type BranchInput struct {
+	Name     string  `json:"name" binding:"required,max=150"`
+	Address  *string `json:"address"`
+	IsActive *bool   `json:"is_active"` // Padrão: true
+}
+
+type RoomInput struct {
+	Name      string   `json:"name" binding:"required,max=100"`
+	Capacity  int      `json:"capacity" binding:"required,min=1"`
+	Equipment []string `json:"equipment"`
+	IsActive  *bool    `json:"is_active"` // Padrão: true
+}
+
+// SuggestRoomsInput descreve o horário e os requisitos da sala procurada
+type SuggestRoomsInput struct {
+	Date        time.Time  // Data no fuso informado (ou da escola)
+	StartTime   string     // Format: "14:00"
+	EndTime     string     // Format: "15:00"
+	Timezone    *string    // Fuso IANA de data/horários; padrão é o da escola
+	BranchID    *uuid.UUID // Restringe a uma unidade
+	MinCapacity int
+	Equipment   []string
+}
+
+type AssignRoomInput struct {
+	RoomID   *uuid.UUID `json:"room_id"`   // Sala pedida; nula com branch_id para sugerir uma sala livre
+	BranchID *uuid.UUID `json:"branch_id"` // Ambos nulos liberam a sala (aula online)
+}
+
+type roomService struct {
+	branchRepo       repository.BranchRepository
+	roomRepo         repository.RoomRepository
+	classRepo        repository.ClassRepository
+	classStudentRepo repository.ClassStudentRepository
+	authRepo         repository.AuthRepository
+	timezones        TimezoneService
+}
+
+func NewRoomService(
+	branchRepo repository.BranchRepository,
+	roomRepo repository.RoomRepository,
+	classRepo repository.ClassRepository,
+	classStudentRepo repository.ClassStudentRepository,
+	authRepo repository.AuthRepository,
+	timezones TimezoneService,
+) RoomService {
+	return &roomService{
+		branchRepo:       branchRepo,
+		roomRepo:         roomRepo,
+		classRepo:        classRepo,
+		classStudentRepo: classStudentRepo,
+		authRepo:         authRepo,
+		timezones:        timezones,
+	}
+}
+
+func (s *roomService) CreateBranch(input *BranchInput) (*domain.Branch, error) {
+	branch := &domain.Branch{
+		Name:     strings.TrimSpace(input.Name),
+		Address:  input.Address,
+		IsActive: input.IsActive == nil || *input.IsActive,
+	}
+
+	if err := s.branchRepo.Create(branch); err != nil {
+		return nil, err
+	}
+
+	return branch, nil
+}
+
+func (s *roomService) GetBranches(includeInactive bool) ([]domain.Branch, error) {
+	return s.branchRepo.List(includeInactive)
+}
+
+func (s *roomService) UpdateBranch(id uuid.UUID, input *BranchInput) (*domain.Branch, error) {
+	branch, err := s.branchRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	branch.Name = strings.TrimSpace(input.Name)
+	branch.Address = input.Address
+	if input.IsActive != nil {
+		branch.IsActive = *input.IsActive
+	}
+
+	if err := s.branchRepo.Update(branch); err != nil {
+		return nil, err
+	}
+
+	return branch, nil
+}
+
+func (s *roomService) DeleteBranch(id uuid.UUID) error {
+	return s.branchRepo.Delete(id)
+}
+
+func (s *roomService) CreateRoom(branchID uuid.UUID, input *RoomInput) (*domain.Room, error) {
+	if _, err := s.branchRepo.GetByID(branchID); err != nil {
+		return nil, err
+	}
+
+	room := &domain.Room{
+		BranchID:  branchID,
+		Name:      strings.TrimSpace(input.Name),
+		Capacity:  input.Capacity,
+		Equipment: normalizeEquipment(input.Equipment),
+		IsActive:  input.IsActive == nil || *input.IsActive,
+	}
+
+	if err := s.roomRepo.Create(room); err != nil {
+		return nil, err
+	}
+
+	return room, nil
+}
+
+func (s *roomService) GetRoom(id uuid.UUID) (*domain.Room, error) {
+	return s.roomRepo.GetByID(id)
+}
+
+func (s *roomService) GetRooms(branchID uuid.UUID, includeInactive bool) ([]domain.Room, error) {
+	if _, err := s.branchRepo.GetByID(branchID); err != nil {
+		return nil, err
+	}
+
+	return s.roomRepo.ListByBranch(branchID, includeInactive)
+}
+
+func (s *roomService) UpdateRoom(id uuid.UUID, input *RoomInput) (*domain.Room, error) {
+	room, err := s.roomRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	room.Name = strings.TrimSpace(input.Name)
+	room.Capacity = input.Capacity
+	room.Equipment = normalizeEquipment(input.Equipment)
+	if input.IsActive != nil {
+		room.IsActive = *input.IsActive
+	}
+
+	if err := s.roomRepo.Update(room); err != nil {
+		return nil, err
+	}
+
+	return room, nil
+}
+
+func (s *roomService) DeleteRoom(id uuid.UUID) error {
+	return s.roomRepo.Delete(id)
+}
+
+// SuggestRooms lista as salas livres no horário que atendem aos requisitos,
+// das menores para as maiores
+func (s *roomService) SuggestRooms(input *SuggestRoomsInput) ([]domain.Room, error) {
+	scheduled, err := s.timezones.FromInput(input.Date, input.StartTime, input.EndTime, input.Timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := domain.RoomFilter{
+		BranchID:    input.BranchID,
+		MinCapacity: input.MinCapacity,
+		Equipment:   normalizeEquipment(input.Equipment),
+	}
+
+	rooms, err := s.roomRepo.FindAvailable(filter, scheduled.Date, scheduled.StartTime, scheduled.EndTime, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if rooms == nil {
+		rooms = []domain.Room{}
+	}
+
+	return rooms, nil
+}
+
+// GetOccupancy retorna, para cada sala da unidade, as aulas que a ocupam entre from e to
+// (inclusive) e o total de minutos reservados
+func (s *roomService) GetOccupancy(branchID uuid.UUID, from, to time.Time) ([]domain.RoomOccupancy, error) {
+	if to.Before(from) {
+		return nil, fmt.Errorf("%w: 'to' must not be before 'from'", ErrInvalidDateRange)
+	}
+
+	if to.Sub(from) > maxOccupancyDays*24*time.Hour {
+		return nil, fmt.Errorf("%w: range cannot exceed %d days", ErrInvalidDateRange, maxOccupancyDays)
+	}
+
+	rooms, err := s.GetRooms(branchID, true)
+	if err != nil {
+		return nil, err
+	}
+
+	occupancy := make([]domain.RoomOccupancy, 0, len(rooms))
+	for _, room := range rooms {
+		classes, err := s.classRepo.GetByRoom(room.ID, from, to)
+		if err != nil {
+			return nil, err
+		}
+
+		entry := domain.RoomOccupancy{
+			Room:    room,
+			Classes: []domain.Class{},
+		}
+		for _, class := range classes {
+			entry.Classes = append(entry.Classes, class)
+			entry.BookedMinutes += int(class.EndsAt.Sub(class.StartsAt).Minutes())
+		}
+
+		occupancy = append(occupancy, entry)
+	}
+
+	return occupancy, nil
+}
+
+// AssignClassRoom reserva, troca ou libera a sala de uma aula agendada.
+// Professores só podem alterar a sala das próprias aulas.
+func (s *roomService) AssignClassRoom(classID, requestedBy uuid.UUID, input *AssignRoomInput) (*domain.Class, error) {
+	class, err := s.classRepo.GetByID(classID)
+	if err != nil {
+		return nil, err
+	}
+
+	auth, err := s.authRepo.GetByID(requestedBy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get auth: %w", err)
+	}
+
+	if auth.Role != domain.RoleAdmin && class.TeacherID != requestedBy {
+		return nil, ErrNotClassTeacher
+	}
+
+	if class.Status != domain.ClassStatusScheduled {
+		return nil, errors.New("only scheduled classes can have a room assigned")
+	}
+
+	seats, err := s.classStudentRepo.CountStudentsInClass(class.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count students: %w", err)
+	}
+
+	roomID, err := reserveRoom(s.roomRepo, input.RoomID, input.BranchID, seats, class.ScheduledDate, clockTime(class.StartTime), clockTime(class.EndTime), &class.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// A sala é conferida de novo com a sala bloqueada, pois pode ter sido ocupada desde a escolha
+	if err := s.classRepo.AssignRoom(class.ID, roomID); err != nil {
+		switch {
+		case errors.Is(err, repository.ErrRoomUnavailable):
+			return nil, ErrRoomUnavailable
+		case errors.Is(err, repository.ErrSlotFull):
+			return nil, ErrRoomTooSmall
+		}
+		return nil, err
+	}
+
+	return s.classRepo.GetByID(class.ID)
+}
+
+// reserveRoom resolve a sala de uma aula no horário: valida a sala pedida ou, quando
+// só a unidade é informada, escolhe a menor sala livre que comporte os alunos.
+// Sem sala nem unidade a aula fica sem sala (nil).
+func reserveRoom(roomRepo repository.RoomRepository, roomID, branchID *uuid.UUID, seats int, date time.Time, startTime, endTime string, excludeClassID *uuid.UUID) (*uuid.UUID, error) {
+	if roomID == nil {
+		if branchID == nil {
+			return nil, nil
+		}
+
+		filter := domain.RoomFilter{BranchID: branchID, MinCapacity: seats}
+		rooms, err := roomRepo.FindAvailable(filter, date, startTime, endTime, excludeClassID)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(rooms) == 0 {
+			return nil, ErrNoRoomAvailable
+		}
+
+		return &rooms[0].ID, nil
+	}
+
+	room, err := roomRepo.GetByID(*roomID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !room.IsActive {
+		return nil, errors.New("room is not active")
+	}
+
+	if branchID != nil && room.BranchID != *branchID {
+		return nil, errors.New("room does not belong to this branch")
+	}
+
+	if seats > room.Capacity {
+		return nil, fmt.Errorf("%w: %d students, capacity is %d", ErrRoomTooSmall, seats, room.Capacity)
+	}
+
+	available, err := roomRepo.CheckAvailability(room.ID, date, startTime, endTime, excludeClassID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !available {
+		return nil, ErrRoomUnavailable
+	}
+
+	return &room.ID, nil
+}
+
+// normalizeEquipment padroniza os nomes dos equipamentos e remove repetidos
+func normalizeEquipment(equipment []string) []string {
+	normalized := []string{}
+	seen := make(map[string]bool, len(equipment))
+	for _, item := range equipment {
+		item = strings.ToLower(strings.TrimSpace(item))
+		if item == "" || seen[item] {
+			continue
+		}
+		seen[item] = true
+		normalized = append(normalized, item)
+	}
+
+	return normalized
+}
@@ -0,0 +1,89 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hscHeric/go-potential-api/internal/domain"
+	"github.com/hscHeric/go-potential-api/internal/repository"
+)
+
+type StudentLevelService interface {
+	RecordLevel(studentID, assessedBy uuid.UUID, input *RecordStudentLevelInput) (*domain.StudentLevel, error)
+	GetLevelHistory(studentID uuid.UUID, language string) ([]domain.StudentLevel, error)
+	GetCurrentLevels(studentID uuid.UUID) ([]domain.StudentLevel, error)
+}
+
+type RecordStudentLevelInput struct {
+	Language   string               `json:"language" binding:"required"`
+	Level      domain.LanguageLevel `json:"level" binding:"required,oneof=A1 A2 B1 B2 C1 C2"`
+	AssessedAt *time.Time           `json:"assessed_at"`
+	Notes      *string              `json:"notes"`
+}
+
+type studentLevelService struct {
+	studentLevelRepo repository.StudentLevelRepository
+	authRepo         repository.AuthRepository
+}
+
+func NewStudentLevelService(
+	studentLevelRepo repository.StudentLevelRepository,
+	authRepo repository.AuthRepository,
+) StudentLevelService {
+	return &studentLevelService{
+		studentLevelRepo: studentLevelRepo,
+		authRepo:         authRepo,
+	}
+}
+
+func (s *studentLevelService) RecordLevel(studentID, assessedBy uuid.UUID, input *RecordStudentLevelInput) (*domain.StudentLevel, error) {
+	// Verificar se o usuário avaliado é aluno
+	auth, err := s.authRepo.GetByID(studentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get auth: %w", err)
+	}
+
+	if auth.Role != domain.RoleStudent {
+		return nil, errors.New("levels can only be recorded for students")
+	}
+
+	language := NormalizeLanguage(input.Language)
+	if language == "" {
+		return nil, errors.New("language is required")
+	}
+
+	// Data da avaliação padrão: hoje
+	assessedAt := time.Now()
+	if input.AssessedAt != nil {
+		assessedAt = *input.AssessedAt
+	}
+
+	if assessedAt.After(time.Now()) {
+		return nil, errors.New("assessment date cannot be in the future")
+	}
+
+	level := &domain.StudentLevel{
+		StudentID:  studentID,
+		Language:   language,
+		Level:      input.Level,
+		AssessedAt: assessedAt,
+		AssessedBy: &assessedBy,
+		Notes:      input.Notes,
+	}
+
+	if err := s.studentLevelRepo.Create(level); err != nil {
+		return nil, fmt.Errorf("failed to record student level: %w", err)
+	}
+
+	return level, nil
+}
+
+func (s *studentLevelService) GetLevelHistory(studentID uuid.UUID, language string) ([]domain.StudentLevel, error) {
+	return s.studentLevelRepo.GetHistory(studentID, NormalizeLanguage(language))
+}
+
+func (s *studentLevelService) GetCurrentLevels(studentID uuid.UUID) ([]domain.StudentLevel, error) {
+	return s.studentLevelRepo.GetCurrentLevels(studentID)
+}
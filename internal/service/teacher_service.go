@@ -0,0 +1,117 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/hscHeric/go-potential-api/internal/domain"
+	"github.com/hscHeric/go-potential-api/internal/repository"
+)
+
+type TeacherService interface {
+	UpdateProfile(teacherID uuid.UUID, input *UpdateTeacherProfileInput) (*domain.TeacherProfile, error)
+	GetProfile(teacherID uuid.UUID) (*domain.TeacherProfile, error)
+	ListTeachers(filter domain.TeacherDirectoryFilter) ([]domain.TeacherDirectoryEntry, error)
+}
+
+type UpdateTeacherProfileInput struct {
+	Languages     []string               `json:"languages" binding:"required,min=1,dive,required"`
+	Levels        []domain.LanguageLevel `json:"levels" binding:"required,min=1,dive,oneof=A1 A2 B1 B2 C1 C2"`
+	Bio           *string                `json:"bio"`
+	Specialties   []string               `json:"specialties"`
+	VideoIntroURL *string                `json:"video_intro_url" binding:"omitempty,url"`
+}
+
+type teacherService struct {
+	teacherProfileRepo repository.TeacherProfileRepository
+	authRepo           repository.AuthRepository
+}
+
+func NewTeacherService(
+	teacherProfileRepo repository.TeacherProfileRepository,
+	authRepo repository.AuthRepository,
+) TeacherService {
+	return &teacherService{
+		teacherProfileRepo: teacherProfileRepo,
+		authRepo:           authRepo,
+	}
+}
+
+func (s *teacherService) UpdateProfile(teacherID uuid.UUID, input *UpdateTeacherProfileInput) (*domain.TeacherProfile, error) {
+	// Verificar se o usuário é professor
+	auth, err := s.authRepo.GetByID(teacherID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get auth: %w", err)
+	}
+
+	if auth.Role != domain.RoleTeacher {
+		return nil, errors.New("only teachers can have a teacher profile")
+	}
+
+	// Idiomas são normalizados em minúsculas para que os filtros sejam consistentes
+	languages := make([]string, 0, len(input.Languages))
+	for _, language := range input.Languages {
+		languages = append(languages, NormalizeLanguage(language))
+	}
+
+	specialties := input.Specialties
+	if specialties == nil {
+		specialties = []string{}
+	}
+
+	profile := &domain.TeacherProfile{
+		TeacherID:     teacherID,
+		Languages:     languages,
+		Levels:        input.Levels,
+		Bio:           input.Bio,
+		Specialties:   specialties,
+		VideoIntroURL: input.VideoIntroURL,
+	}
+
+	if err := s.teacherProfileRepo.Upsert(profile); err != nil {
+		return nil, fmt.Errorf("failed to save teacher profile: %w", err)
+	}
+
+	return profile, nil
+}
+
+func (s *teacherService) GetProfile(teacherID uuid.UUID) (*domain.TeacherProfile, error) {
+	return s.teacherProfileRepo.GetByTeacherID(teacherID)
+}
+
+func (s *teacherService) ListTeachers(filter domain.TeacherDirectoryFilter) ([]domain.TeacherDirectoryEntry, error) {
+	filter.Language = NormalizeLanguage(filter.Language)
+
+	entries, err := s.teacherProfileRepo.List(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	// Cada entrada aponta para o endpoint de horários disponíveis do professor
+	for i := range entries {
+		entries[i].AvailableSlotsURL = fmt.Sprintf("/api/v1/time-slots/teacher/%s/available", entries[i].TeacherID)
+	}
+
+	return entries, nil
+}
+
+// NormalizeLanguage padroniza o nome do idioma para comparação (ex: " English " -> "english")
+func NormalizeLanguage(language string) string {
+	return strings.ToLower(strings.TrimSpace(language))
+}
+
+// normalizeOptionalLanguage aplica NormalizeLanguage em campos opcionais
+func normalizeOptionalLanguage(language *string) *string {
+	if language == nil {
+		return nil
+	}
+
+	normalized := NormalizeLanguage(*language)
+	if normalized == "" {
+		return nil
+	}
+
+	return &normalized
+}
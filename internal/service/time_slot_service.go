@@ -3,49 +3,100 @@ package service
 import (
 	"errors"
 	"fmt"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/hscHeric/go-potential-api/internal/domain"
 	"github.com/hscHeric/go-potential-api/internal/repository"
+	"github.com/hscHeric/go-potential-api/pkg/timezone"
 )
 
 type TimeSlotService interface {
 	CreateTimeSlot(teacherID uuid.UUID, input *CreateTimeSlotInput) (*domain.TimeSlot, error)
 	GetTimeSlot(id uuid.UUID) (*domain.TimeSlot, error)
 	GetTeacherTimeSlots(teacherID uuid.UUID) ([]domain.TimeSlot, error)
-	GetAvailableSlots(teacherID uuid.UUID, dayOfWeek domain.DayOfWeek) ([]domain.TimeSlot, error)
-	UpdateTimeSlot(id uuid.UUID, input *UpdateTimeSlotInput) error
+	GetAvailableSlots(teacherID uuid.UUID, dayOfWeek domain.DayOfWeek, date *time.Time) ([]domain.TimeSlot, error)
+	UpdateTimeSlot(id uuid.UUID, input *UpdateTimeSlotInput) (*domain.TimeSlot, error)
 	DeleteTimeSlot(id uuid.UUID) error
+	RestoreTimeSlot(id uuid.UUID) error
 	ToggleAvailability(id uuid.UUID, isAvailable bool) error
+	GetOpenings(teacherID uuid.UUID, from, to time.Time) ([]domain.Opening, error)
+	ReplaceWeeklySlots(teacherID uuid.UUID, input *ReplaceTimeSlotsInput) (*domain.TimeSlotDiff, error)
 }
 
+// ErrInvalidDateRange indica um intervalo de datas inválido na consulta
+var ErrInvalidDateRange = errors.New("invalid date range")
+
+// ErrSlotOverlap indica um horário que se sobrepõe a outro do professor no mesmo dia
+var ErrSlotOverlap = errors.New("time slot overlaps another slot of the teacher")
+
+// maxOpeningsDays limita o intervalo consultado em GetOpenings
+const maxOpeningsDays = 90
+
+const dateLayout = "2006-01-02"
+
 type CreateTimeSlotInput struct {
 	DayOfWeek   domain.DayOfWeek `json:"day_of_week" binding:"required,min=0,max=6"`
 	StartTime   string           `json:"start_time" binding:"required"`
 	EndTime     string           `json:"end_time" binding:"required"`
 	MaxStudents int              `json:"max_students" binding:"required,min=1"`
+	ValidFrom   *string          `json:"valid_from"`  // Format: "2006-01-02"; padrão é hoje
+	ValidUntil  *string          `json:"valid_until"` // Format: "2006-01-02"; vazio = sem término
 }
 
+// UpdateTimeSlotInput altera o horário a partir de EffectiveFrom. Se a versão atual já
+// estiver valendo, ela é encerrada na véspera e uma nova versão é criada.
 type UpdateTimeSlotInput struct {
+	DayOfWeek     domain.DayOfWeek `json:"day_of_week" binding:"required,min=0,max=6"`
+	StartTime     string           `json:"start_time" binding:"required"`
+	EndTime       string           `json:"end_time" binding:"required"`
+	MaxStudents   int              `json:"max_students" binding:"required,min=1"`
+	IsAvailable   bool             `json:"is_available"`
+	EffectiveFrom *string          `json:"effective_from"` // Format: "2006-01-02"; padrão é hoje
+}
+
+// WeeklySlotInput é uma célula da grade semanal
+type WeeklySlotInput struct {
 	DayOfWeek   domain.DayOfWeek `json:"day_of_week" binding:"required,min=0,max=6"`
 	StartTime   string           `json:"start_time" binding:"required"`
 	EndTime     string           `json:"end_time" binding:"required"`
 	MaxStudents int              `json:"max_students" binding:"required,min=1"`
-	IsAvailable bool             `json:"is_available"`
+}
+
+// ReplaceTimeSlotsInput é a grade semanal completa do professor, válida a partir de
+// EffectiveFrom. Horários existentes que não estiverem na grade deixam de valer.
+type ReplaceTimeSlotsInput struct {
+	Slots         []WeeklySlotInput `json:"slots" binding:"dive"`
+	EffectiveFrom *string           `json:"effective_from"` // Format: "2006-01-02"; padrão é hoje
+	DryRun        bool              `json:"dry_run"`        // Apenas calcula o diff, sem gravar
 }
 
 type timeSlotService struct {
 	timeSlotRepo repository.TimeSlotRepository
+	classRepo    repository.ClassRepository
+	blackoutRepo repository.BlackoutRepository
 	authRepo     repository.AuthRepository
+	holidays     HolidayChecker
+	timezones    TimezoneService
 }
 
 func NewTimeSlotService(
 	timeSlotRepo repository.TimeSlotRepository,
+	classRepo repository.ClassRepository,
+	blackoutRepo repository.BlackoutRepository,
 	authRepo repository.AuthRepository,
+	holidays HolidayChecker,
+	timezones TimezoneService,
 ) TimeSlotService {
 	return &timeSlotService{
 		timeSlotRepo: timeSlotRepo,
+		classRepo:    classRepo,
+		blackoutRepo: blackoutRepo,
 		authRepo:     authRepo,
+		holidays:     holidays,
+		timezones:    timezones,
 	}
 }
 
@@ -61,17 +112,40 @@ func (s *timeSlotService) CreateTimeSlot(teacherID uuid.UUID, input *CreateTimeS
 	}
 
 	// Validar horários
-	if input.StartTime >= input.EndTime {
-		return nil, errors.New("start time must be before end time")
+	startTime, endTime, err := clockRange(input.StartTime, input.EndTime)
+	if err != nil {
+		return nil, err
+	}
+
+	validFrom, err := s.validityDate(input.ValidFrom)
+	if err != nil {
+		return nil, err
 	}
 
 	timeSlot := &domain.TimeSlot{
 		TeacherID:   teacherID,
 		DayOfWeek:   input.DayOfWeek,
-		StartTime:   input.StartTime,
-		EndTime:     input.EndTime,
+		StartTime:   startTime,
+		EndTime:     endTime,
 		MaxStudents: input.MaxStudents,
 		IsAvailable: true,
+		ValidFrom:   validFrom,
+	}
+
+	if input.ValidUntil != nil && *input.ValidUntil != "" {
+		validUntil, err := time.Parse(dateLayout, *input.ValidUntil)
+		if err != nil {
+			return nil, errors.New("invalid valid_until (use YYYY-MM-DD)")
+		}
+
+		if validUntil.Before(validFrom) {
+			return nil, errors.New("valid_until must not be before valid_from")
+		}
+		timeSlot.ValidUntil = &validUntil
+	}
+
+	if err := s.checkOverlap(timeSlot, nil); err != nil {
+		return nil, err
 	}
 
 	if err := s.timeSlotRepo.Create(timeSlot); err != nil {
@@ -85,40 +159,491 @@ func (s *timeSlotService) GetTimeSlot(id uuid.UUID) (*domain.TimeSlot, error) {
 	return s.timeSlotRepo.GetByID(id)
 }
 
+// GetTeacherTimeSlots retorna as versões vigentes e futuras dos horários do professor
 func (s *timeSlotService) GetTeacherTimeSlots(teacherID uuid.UUID) ([]domain.TimeSlot, error) {
-	return s.timeSlotRepo.GetByTeacher(teacherID)
+	return s.timeSlotRepo.GetByTeacher(teacherID, s.timezones.Today())
 }
 
-func (s *timeSlotService) GetAvailableSlots(teacherID uuid.UUID, dayOfWeek domain.DayOfWeek) ([]domain.TimeSlot, error) {
-	return s.timeSlotRepo.GetByTeacherAndDay(teacherID, dayOfWeek)
+// GetAvailableSlots retorna os horários do professor no dia da semana, na versão que vale
+// na data (hoje, se não informada). Quando date é informada, o dia da semana vem dela e
+// nada é retornado se o professor estiver de folga.
+func (s *timeSlotService) GetAvailableSlots(teacherID uuid.UUID, dayOfWeek domain.DayOfWeek, date *time.Time) ([]domain.TimeSlot, error) {
+	day := s.timezones.Today()
+	if date != nil {
+		blackouts, err := s.blackoutRepo.GetByTeacher(teacherID, *date, *date)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(blackouts) > 0 {
+			return []domain.TimeSlot{}, nil
+		}
+
+		day = *date
+		dayOfWeek = domain.DayOfWeek(date.Weekday())
+	}
+
+	return s.timeSlotRepo.GetByTeacherAndDay(teacherID, dayOfWeek, day)
 }
 
-func (s *timeSlotService) UpdateTimeSlot(id uuid.UUID, input *UpdateTimeSlotInput) error {
+// UpdateTimeSlot altera o horário a partir da data efetiva. Mudanças de dia, horário ou
+// capacidade em uma versão que já está valendo criam uma nova versão, para que as aulas
+// já marcadas continuem apontando para o horário com que foram dadas. Mudanças só de
+// disponibilidade, ou em versões que ainda não começaram, são feitas na própria versão.
+// Retorna a versão que vale a partir da data efetiva.
+func (s *timeSlotService) UpdateTimeSlot(id uuid.UUID, input *UpdateTimeSlotInput) (*domain.TimeSlot, error) {
 	// Buscar time slot existente
 	slot, err := s.timeSlotRepo.GetByID(id)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// Validar horários
-	if input.StartTime >= input.EndTime {
-		return errors.New("start time must be before end time")
+	startTime, endTime, err := clockRange(input.StartTime, input.EndTime)
+	if err != nil {
+		return nil, err
+	}
+
+	effective, err := s.validityDate(input.EffectiveFrom)
+	if err != nil {
+		return nil, err
+	}
+
+	if !slot.Covers(effective) && slot.ValidFrom.Before(effective) {
+		return nil, errors.New("this time slot version is no longer valid on the effective date")
 	}
 
-	// Atualizar campos
-	slot.DayOfWeek = input.DayOfWeek
-	slot.StartTime = input.StartTime
-	slot.EndTime = input.EndTime
-	slot.MaxStudents = input.MaxStudents
-	slot.IsAvailable = input.IsAvailable
+	next := *slot
+	next.DayOfWeek = input.DayOfWeek
+	next.StartTime = startTime
+	next.EndTime = endTime
+	next.MaxStudents = input.MaxStudents
+	next.IsAvailable = input.IsAvailable
 
-	return s.timeSlotRepo.Update(slot)
+	sameSchedule := next.DayOfWeek == slot.DayOfWeek &&
+		next.StartTime == clockTime(slot.StartTime) &&
+		next.EndTime == clockTime(slot.EndTime) &&
+		next.MaxStudents == slot.MaxStudents
+
+	if sameSchedule || !slot.ValidFrom.Before(effective) {
+		if err := s.checkOverlap(&next, &slot.ID); err != nil {
+			return nil, err
+		}
+
+		if err := s.timeSlotRepo.Update(&next); err != nil {
+			return nil, err
+		}
+		return &next, nil
+	}
+
+	next.ValidFrom = effective
+	if err := s.checkOverlap(&next, &slot.ID); err != nil {
+		return nil, err
+	}
+
+	if err := s.timeSlotRepo.Supersede(slot.ID, effective.AddDate(0, 0, -1), &next); err != nil {
+		return nil, err
+	}
+
+	return &next, nil
 }
 
 func (s *timeSlotService) DeleteTimeSlot(id uuid.UUID) error {
 	return s.timeSlotRepo.Delete(id)
 }
 
+func (s *timeSlotService) RestoreTimeSlot(id uuid.UUID) error {
+	slot, err := s.timeSlotRepo.GetDeletedByID(id)
+	if err != nil {
+		return fmt.Errorf("failed to get deleted time slot: %w", err)
+	}
+
+	// O professor pode ter criado outro horário no lugar enquanto este estava excluído
+	if err := s.checkOverlap(slot, nil); err != nil {
+		return err
+	}
+
+	return s.timeSlotRepo.Restore(id)
+}
+
 func (s *timeSlotService) ToggleAvailability(id uuid.UUID, isAvailable bool) error {
 	return s.timeSlotRepo.ToggleAvailability(id, isAvailable)
 }
+
+// GetOpenings expande os horários recorrentes do professor em ocorrências datadas
+// entre from e to (inclusive), descontando as aulas já marcadas. Ocorrências
+// passadas, ocupadas por outra aula, com turma lotada, em folgas do professor ou
+// em feriados (com a política "block") não são retornadas.
+func (s *timeSlotService) GetOpenings(teacherID uuid.UUID, from, to time.Time) ([]domain.Opening, error) {
+	if to.Before(from) {
+		return nil, fmt.Errorf("%w: 'to' must not be before 'from'", ErrInvalidDateRange)
+	}
+
+	if to.Sub(from) > maxOpeningsDays*24*time.Hour {
+		return nil, fmt.Errorf("%w: range cannot exceed %d days", ErrInvalidDateRange, maxOpeningsDays)
+	}
+
+	slots, err := s.timeSlotRepo.GetByTeacher(teacherID, from)
+	if err != nil {
+		return nil, err
+	}
+
+	classes, err := s.classRepo.GetOccupancyByTeacher(teacherID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	blackouts, err := s.blackoutRepo.GetByTeacher(teacherID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	holidays, err := s.holidays.InRange(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	// Indexar aulas por data
+	classesByDate := make(map[string][]domain.ClassOccupancy)
+	for _, class := range classes {
+		date := class.ScheduledDate.Format(dateLayout)
+		classesByDate[date] = append(classesByDate[date], class)
+	}
+
+	now := time.Now()
+	today := now.In(s.timezones.School()).Format(dateLayout)
+
+	openings := []domain.Opening{}
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		date := day.Format(dateLayout)
+		if date < today || isBlackedOut(blackouts, day) {
+			continue
+		}
+
+		holiday, isHoliday := holidays[date]
+		if isHoliday && s.holidays.Blocks() {
+			continue
+		}
+
+		for _, slot := range slots {
+			if !slot.IsAvailable || slot.DayOfWeek != domain.DayOfWeek(day.Weekday()) || !slot.Covers(day) {
+				continue
+			}
+
+			// Ocorrências já iniciadas ou inexistentes na data (horário de verão) ficam de fora
+			startsAt, endsAt, err := s.timezones.ClassTimes(day, slot.StartTime, slot.EndTime)
+			if err != nil || !startsAt.After(now) {
+				continue
+			}
+
+			if opening, ok := buildOpening(slot, date, classesByDate[date]); ok {
+				opening.StartsAt = startsAt
+				opening.EndsAt = endsAt
+				if isHoliday {
+					name := holiday.Name
+					opening.Holiday = &name
+				}
+				openings = append(openings, opening)
+			}
+		}
+	}
+
+	return openings, nil
+}
+
+// ReplaceWeeklySlots substitui a grade semanal do professor a partir da data efetiva.
+// Horários com o mesmo dia e intervalo são mantidos; os que mudam de capacidade ganham
+// uma nova versão e os que saem da grade deixam de valer na véspera (ou são excluídos,
+// se ainda não tinham começado). Aulas já marcadas continuam nas versões antigas e são
+// contadas em FutureClasses para o professor decidir o que fazer com elas.
+func (s *timeSlotService) ReplaceWeeklySlots(teacherID uuid.UUID, input *ReplaceTimeSlotsInput) (*domain.TimeSlotDiff, error) {
+	auth, err := s.authRepo.GetByID(teacherID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get auth: %w", err)
+	}
+
+	if auth.Role != domain.RoleTeacher {
+		return nil, errors.New("only teachers can manage time slots")
+	}
+
+	effective, err := s.validityDate(input.EffectiveFrom)
+	if err != nil {
+		return nil, err
+	}
+
+	desired := make([]domain.TimeSlot, 0, len(input.Slots))
+	for _, cell := range input.Slots {
+		startTime, endTime, err := clockRange(cell.StartTime, cell.EndTime)
+		if err != nil {
+			return nil, err
+		}
+
+		desired = append(desired, domain.TimeSlot{
+			TeacherID:   teacherID,
+			DayOfWeek:   cell.DayOfWeek,
+			StartTime:   startTime,
+			EndTime:     endTime,
+			MaxStudents: cell.MaxStudents,
+			IsAvailable: true,
+			ValidFrom:   effective,
+		})
+	}
+
+	// Ordenados por dia e início, basta comparar cada horário com o anterior
+	sort.Slice(desired, func(i, j int) bool {
+		if desired[i].DayOfWeek != desired[j].DayOfWeek {
+			return desired[i].DayOfWeek < desired[j].DayOfWeek
+		}
+		return desired[i].StartTime < desired[j].StartTime
+	})
+	for i := 1; i < len(desired); i++ {
+		prev, slot := desired[i-1], desired[i]
+		if prev.DayOfWeek == slot.DayOfWeek && timesOverlap(prev.StartTime, prev.EndTime, slot.StartTime, slot.EndTime) {
+			return nil, fmt.Errorf("%w (%s-%s and %s-%s)", ErrSlotOverlap, prev.StartTime, prev.EndTime, slot.StartTime, slot.EndTime)
+		}
+	}
+
+	current, err := s.timeSlotRepo.GetByTeacher(teacherID, effective)
+	if err != nil {
+		return nil, err
+	}
+
+	byKey := make(map[string]domain.TimeSlot, len(current))
+	for _, slot := range current {
+		key := slotKey(slot.DayOfWeek, clockTime(slot.StartTime), clockTime(slot.EndTime))
+		if _, exists := byKey[key]; !exists {
+			byKey[key] = slot
+		}
+	}
+
+	// Aulas marcadas a partir da data efetiva (ou de agora, se ela for hoje)
+	since := time.Date(effective.Year(), effective.Month(), effective.Day(), 0, 0, 0, 0, s.timezones.School())
+	if now := time.Now(); now.After(since) {
+		since = now
+	}
+
+	diff := &domain.TimeSlotDiff{
+		Added:   []domain.TimeSlot{},
+		Changed: []domain.TimeSlotChange{},
+		Removed: []domain.RemovedTimeSlot{},
+	}
+
+	kept := make(map[uuid.UUID]bool, len(desired))
+	for _, slot := range desired {
+		key := slotKey(slot.DayOfWeek, slot.StartTime, slot.EndTime)
+		existing, ok := byKey[key]
+		if !ok {
+			diff.Added = append(diff.Added, slot)
+			continue
+		}
+
+		delete(byKey, key)
+		kept[existing.ID] = true
+
+		if existing.MaxStudents == slot.MaxStudents && existing.IsAvailable && existing.ValidUntil == nil {
+			diff.Unchanged++
+			continue
+		}
+
+		futureClasses, err := s.classRepo.CountUpcomingBySlot(existing.ID, since)
+		if err != nil {
+			return nil, err
+		}
+
+		diff.Changed = append(diff.Changed, domain.TimeSlotChange{
+			Before:        existing,
+			After:         slot,
+			FutureClasses: futureClasses,
+		})
+	}
+
+	for _, slot := range current {
+		if kept[slot.ID] {
+			continue
+		}
+
+		futureClasses, err := s.classRepo.CountUpcomingBySlot(slot.ID, since)
+		if err != nil {
+			return nil, err
+		}
+
+		diff.Removed = append(diff.Removed, domain.RemovedTimeSlot{
+			TimeSlot:      slot,
+			FutureClasses: futureClasses,
+		})
+	}
+
+	if input.DryRun {
+		return diff, nil
+	}
+
+	// Novas versões dos alterados entram depois dos horários novos
+	added := append([]domain.TimeSlot{}, diff.Added...)
+	retired := make([]domain.TimeSlot, 0, len(diff.Changed)+len(diff.Removed))
+	for _, change := range diff.Changed {
+		added = append(added, change.After)
+		retired = append(retired, change.Before)
+	}
+	for _, removed := range diff.Removed {
+		retired = append(retired, removed.TimeSlot)
+	}
+
+	var closed []domain.TimeSlot
+	var deletedIDs []uuid.UUID
+	validUntil := effective.AddDate(0, 0, -1)
+	for _, slot := range retired {
+		if slot.ValidFrom.Before(effective) {
+			slot.ValidUntil = &validUntil
+			closed = append(closed, slot)
+		} else {
+			deletedIDs = append(deletedIDs, slot.ID)
+		}
+	}
+
+	if err := s.timeSlotRepo.ReplaceWeekly(added, closed, deletedIDs); err != nil {
+		return nil, err
+	}
+
+	copy(diff.Added, added)
+	for i := range diff.Changed {
+		diff.Changed[i].After = added[len(diff.Added)+i]
+	}
+	diff.Applied = true
+
+	return diff, nil
+}
+
+// checkOverlap recusa horários que se sobrepõem a outro do professor no mesmo dia
+// durante a janela de validade do candidato
+func (s *timeSlotService) checkOverlap(candidate *domain.TimeSlot, excludeID *uuid.UUID) error {
+	slots, err := s.timeSlotRepo.GetByTeacher(candidate.TeacherID, candidate.ValidFrom)
+	if err != nil {
+		return err
+	}
+
+	for _, slot := range slots {
+		if slot.DayOfWeek != candidate.DayOfWeek || (excludeID != nil && slot.ID == *excludeID) {
+			continue
+		}
+
+		// Versões que só começam depois do fim do candidato não coexistem com ele
+		if candidate.ValidUntil != nil && slot.ValidFrom.After(*candidate.ValidUntil) {
+			continue
+		}
+
+		slotStart, slotEnd := clockTime(slot.StartTime), clockTime(slot.EndTime)
+		if timesOverlap(candidate.StartTime, candidate.EndTime, slotStart, slotEnd) {
+			return fmt.Errorf("%w (%s-%s)", ErrSlotOverlap, slotStart, slotEnd)
+		}
+	}
+
+	return nil
+}
+
+// validityDate lê a data a partir da qual um horário vale ("YYYY-MM-DD"; vazia = hoje).
+// Datas passadas são recusadas para não reescrever o histórico das aulas já dadas.
+func (s *timeSlotService) validityDate(value *string) (time.Time, error) {
+	today := s.timezones.Today()
+	if value == nil || *value == "" {
+		return today, nil
+	}
+
+	date, err := time.Parse(dateLayout, *value)
+	if err != nil {
+		return time.Time{}, errors.New("invalid date (use YYYY-MM-DD)")
+	}
+
+	if date.Before(today) {
+		return time.Time{}, errors.New("time slot changes cannot take effect in the past")
+	}
+
+	return date, nil
+}
+
+// slotKey identifica um horário da grade pelo dia e intervalo
+func slotKey(day domain.DayOfWeek, startTime, endTime string) string {
+	return fmt.Sprintf("%d|%s|%s", day, startTime, endTime)
+}
+
+// buildOpening calcula a capacidade restante de uma ocorrência do horário.
+// Retorna false se o professor estiver ocupado com outra aula ou se a turma estiver lotada.
+func buildOpening(slot domain.TimeSlot, date string, classes []domain.ClassOccupancy) (domain.Opening, bool) {
+	opening := domain.Opening{
+		TimeSlotID:  slot.ID,
+		TeacherID:   slot.TeacherID,
+		Date:        date,
+		StartTime:   clockTime(slot.StartTime),
+		EndTime:     clockTime(slot.EndTime),
+		MaxStudents: slot.MaxStudents,
+	}
+
+	for _, class := range classes {
+		if !timesOverlap(opening.StartTime, opening.EndTime, clockTime(class.StartTime), clockTime(class.EndTime)) {
+			continue
+		}
+
+		// Aula avulsa ou de outro horário ocupa o professor
+		if class.TimeSlotID == nil || *class.TimeSlotID != slot.ID {
+			return opening, false
+		}
+
+		classID := class.ID
+		opening.ClassID = &classID
+		opening.BookedStudents += class.StudentCount
+	}
+
+	opening.RemainingCapacity = slot.MaxStudents - opening.BookedStudents
+	return opening, opening.RemainingCapacity > 0
+}
+
+// isBlackedOut verifica se a data cai em algum período de indisponibilidade
+func isBlackedOut(blackouts []domain.TeacherBlackout, date time.Time) bool {
+	for _, blackout := range blackouts {
+		if blackout.Covers(date) {
+			return true
+		}
+	}
+	return false
+}
+
+// timesOverlap verifica se os intervalos [startA, endA) e [startB, endB) se sobrepõem
+func timesOverlap(startA, endA, startB, endB string) bool {
+	return startA < endB && endA > startB
+}
+
+// clockRange valida um intervalo de horários e o normaliza para "HH:MM:SS"
+func clockRange(startTime, endTime string) (string, string, error) {
+	start, err := timezone.ParseClock(clockTime(startTime))
+	if err != nil {
+		return "", "", fmt.Errorf("invalid start time: %w", err)
+	}
+
+	end, err := timezone.ParseClock(clockTime(endTime))
+	if err != nil {
+		return "", "", fmt.Errorf("invalid end time: %w", err)
+	}
+
+	if start.String() >= end.String() {
+		return "", "", errors.New("start time must be before end time")
+	}
+
+	return start.String(), end.String(), nil
+}
+
+// clockTime normaliza horários para "HH:MM:SS". Colunas TIME podem chegar como
+// "14:00", "14:00:00" ou "0000-01-01T14:00:00Z", dependendo da origem.
+func clockTime(value string) string {
+	if i := strings.Index(value, "T"); i >= 0 && len(value) >= i+9 {
+		return value[i+1 : i+9]
+	}
+
+	if len(value) == len("15:04") {
+		return value + ":00"
+	}
+
+	if len(value) > len("15:04:05") {
+		return value[:len("15:04:05")]
+	}
+
+	return value
+}
@@ -0,0 +1,166 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hscHeric/go-potential-api/internal/domain"
+	"github.com/hscHeric/go-potential-api/internal/repository"
+	"github.com/hscHeric/go-potential-api/pkg/email"
+	"github.com/hscHeric/go-potential-api/pkg/timezone"
+)
+
+// ErrCrossesMidnight indica um horário que termina em outro dia no fuso da escola
+var ErrCrossesMidnight = errors.New("class must start and end on the same day in the school time zone")
+
+// TimezoneService centraliza os fusos da escola e dos usuários.
+// Datas e horários de aulas e disponibilidades são gravados como horário de parede
+// no fuso da escola; starts_at/ends_at guardam os instantes correspondentes.
+type TimezoneService interface {
+	// School retorna o fuso da escola
+	School() *time.Location
+	// Today retorna a data de hoje no fuso da escola, à meia-noite UTC como as colunas DATE
+	Today() time.Time
+	// ForUser retorna o fuso do usuário, ou o da escola se ele não tiver um válido
+	ForUser(authID uuid.UUID) *time.Location
+	// Resolve retorna o fuso informado, ou o do usuário quando name é vazio
+	Resolve(authID uuid.UUID, name string) (*time.Location, error)
+	// ClassTimes converte data e horários de parede da escola em instantes
+	ClassTimes(date time.Time, startTime, endTime string) (time.Time, time.Time, error)
+	// WallTimes converte data e horários de parede no fuso loc em instantes
+	WallTimes(date time.Time, startTime, endTime string, loc *time.Location) (time.Time, time.Time, error)
+	// SchoolWall converte instantes em data e horários de parede da escola
+	SchoolWall(startsAt, endsAt time.Time) (time.Time, string, string, error)
+	// FromInput converte data e horários informados no fuso zone (ou no da escola,
+	// quando nil) em instantes e no horário de parede da escola
+	FromInput(date time.Time, startTime, endTime string, zone *string) (*ScheduledTime, error)
+}
+
+// ScheduledTime é um horário de aula nas duas representações gravadas
+type ScheduledTime struct {
+	Date      time.Time // Data no fuso da escola
+	StartTime string    // "HH:MM:SS" no fuso da escola
+	EndTime   string
+	StartsAt  time.Time
+	EndsAt    time.Time
+}
+
+type timezoneService struct {
+	userRepo repository.UserRepository
+	school   *time.Location
+}
+
+func NewTimezoneService(userRepo repository.UserRepository, school *time.Location) TimezoneService {
+	return &timezoneService{
+		userRepo: userRepo,
+		school:   school,
+	}
+}
+
+func (s *timezoneService) School() *time.Location {
+	return s.school
+}
+
+func (s *timezoneService) Today() time.Time {
+	now := time.Now().In(s.school)
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func (s *timezoneService) ForUser(authID uuid.UUID) *time.Location {
+	user, err := s.userRepo.GetByAuthID(authID)
+	if err != nil {
+		return s.school
+	}
+
+	loc, err := timezone.Load(user.Timezone)
+	if err != nil {
+		return s.school
+	}
+	return loc
+}
+
+func (s *timezoneService) Resolve(authID uuid.UUID, name string) (*time.Location, error) {
+	if name == "" {
+		return s.ForUser(authID), nil
+	}
+	return timezone.Load(name)
+}
+
+func (s *timezoneService) ClassTimes(date time.Time, startTime, endTime string) (time.Time, time.Time, error) {
+	return s.WallTimes(date, startTime, endTime, s.school)
+}
+
+func (s *timezoneService) WallTimes(date time.Time, startTime, endTime string, loc *time.Location) (time.Time, time.Time, error) {
+	start, err := timezone.ParseClock(clockTime(startTime))
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid start time: %w", err)
+	}
+
+	end, err := timezone.ParseClock(clockTime(endTime))
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid end time: %w", err)
+	}
+
+	startsAt, endsAt, err := timezone.Span(date, start, end, loc)
+	if err != nil {
+		if errors.Is(err, timezone.ErrEndBeforeStart) {
+			return time.Time{}, time.Time{}, errors.New("start time must be before end time")
+		}
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid class time: %w", err)
+	}
+
+	return startsAt, endsAt, nil
+}
+
+func (s *timezoneService) SchoolWall(startsAt, endsAt time.Time) (time.Time, string, string, error) {
+	localStart := startsAt.In(s.school)
+	localEnd := endsAt.In(s.school)
+
+	if localStart.Format(dateLayout) != localEnd.Format(dateLayout) {
+		return time.Time{}, "", "", ErrCrossesMidnight
+	}
+
+	date := time.Date(localStart.Year(), localStart.Month(), localStart.Day(), 0, 0, 0, 0, time.UTC)
+	return date, timezone.ClockOf(localStart).String(), timezone.ClockOf(localEnd).String(), nil
+}
+
+func (s *timezoneService) FromInput(date time.Time, startTime, endTime string, zone *string) (*ScheduledTime, error) {
+	loc := s.school
+	if zone != nil {
+		var err error
+		if loc, err = timezone.Load(*zone); err != nil {
+			return nil, err
+		}
+	}
+
+	startsAt, endsAt, err := s.WallTimes(date, startTime, endTime, loc)
+	if err != nil {
+		return nil, err
+	}
+
+	schoolDate, schoolStart, schoolEnd, err := s.SchoolWall(startsAt, endsAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ScheduledTime{
+		Date:      schoolDate,
+		StartTime: schoolStart,
+		EndTime:   schoolEnd,
+		StartsAt:  startsAt,
+		EndsAt:    endsAt,
+	}, nil
+}
+
+// localSchedule formata data e horários da aula no fuso de quem recebe o e-mail
+func localSchedule(class *domain.Class, loc *time.Location) email.ClassEmailData {
+	startsAt := class.StartsAt.In(loc)
+	return email.ClassEmailData{
+		Date:      startsAt.Format("02/01/2006"),
+		StartTime: startsAt.Format("15:04"),
+		EndTime:   class.EndsAt.In(loc).Format("15:04"),
+		Timezone:  loc.String(),
+	}
+}
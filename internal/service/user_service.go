@@ -7,12 +7,20 @@ import (
 	"github.com/google/uuid"
 	"github.com/hscHeric/go-potential-api/internal/domain"
 	"github.com/hscHeric/go-potential-api/internal/repository"
+	"github.com/hscHeric/go-potential-api/pkg/timezone"
+	"github.com/hscHeric/go-potential-api/pkg/validator"
 )
 
+// ErrInvalidContact indica um telefone de contato inválido
+var ErrInvalidContact = errors.New("contato inválido")
+
 type UserService interface {
 	GetProfile(authID uuid.UUID) (*domain.UserWithAuth, error)
 	UpdateProfile(authID uuid.UUID, input *UpdateProfileInput) error
+	SetClassReminders(authID uuid.UUID, enabled bool) error
 	GetByID(userID uuid.UUID) (*domain.User, error)
+	DeleteUser(authID uuid.UUID) error
+	RestoreUser(authID uuid.UUID) error
 }
 
 type UpdateProfileInput struct {
@@ -20,20 +28,30 @@ type UpdateProfileInput struct {
 	BirthDate string         `json:"birth_date" binding:"required"`
 	Address   domain.Address `json:"address" binding:"required"`
 	Contact   domain.Contact `json:"contact" binding:"required"`
+	Timezone  *string        `json:"timezone"` // Nome IANA; ausente mantém o atual
 }
 
 type userService struct {
-	authRepo repository.AuthRepository
-	userRepo repository.UserRepository
+	authRepo         repository.AuthRepository
+	userRepo         repository.UserRepository
+	studentLevelRepo repository.StudentLevelRepository
+	addressService   AddressService
+	credits          CreditService
 }
 
 func NewUserService(
 	authRepo repository.AuthRepository,
 	userRepo repository.UserRepository,
+	studentLevelRepo repository.StudentLevelRepository,
+	addressService AddressService,
+	credits CreditService,
 ) UserService {
 	return &userService{
-		authRepo: authRepo,
-		userRepo: userRepo,
+		authRepo:         authRepo,
+		userRepo:         userRepo,
+		studentLevelRepo: studentLevelRepo,
+		addressService:   addressService,
+		credits:          credits,
 	}
 }
 
@@ -60,10 +78,27 @@ func (s *userService) GetProfile(authID uuid.UUID) (*domain.UserWithAuth, error)
 		return nil, fmt.Errorf("falha ao obter o usuário associado: %w", err)
 	}
 
-	return &domain.UserWithAuth{
+	profile := &domain.UserWithAuth{
 		Auth: *auth,
 		User: user,
-	}, nil
+	}
+
+	// Alunos exibem o nível CEFR atual de cada idioma
+	if auth.Role == domain.RoleStudent {
+		levels, err := s.studentLevelRepo.GetCurrentLevels(authID)
+		if err != nil {
+			return nil, fmt.Errorf("falha ao obter os níveis do aluno: %w", err)
+		}
+		profile.Levels = levels
+
+		credits, err := s.credits.GetBalance(authID)
+		if err != nil {
+			return nil, fmt.Errorf("falha ao obter os créditos do aluno: %w", err)
+		}
+		profile.Credits = credits
+	}
+
+	return profile, nil
 }
 
 func (s *userService) UpdateProfile(authID uuid.UUID, input *UpdateProfileInput) error {
@@ -76,6 +111,24 @@ func (s *userService) UpdateProfile(authID uuid.UUID, input *UpdateProfileInput)
 		return fmt.Errorf("falha ao obter o usuário: %w", err)
 	}
 
+	// Telefones são armazenados em E.164
+	if err := normalizeContact(&input.Contact); err != nil {
+		return err
+	}
+
+	// Validar consistência entre CEP, UF e cidade
+	if err := s.addressService.ValidateAddress(&input.Address); err != nil {
+		return err
+	}
+
+	// Fuso usado para exibir horários de aulas e nos e-mails
+	if input.Timezone != nil {
+		if _, err := timezone.Load(*input.Timezone); err != nil {
+			return err
+		}
+		user.Timezone = *input.Timezone
+	}
+
 	// Atualizar campos
 	user.FullName = input.FullName
 	user.Address = input.Address
@@ -92,6 +145,25 @@ func (s *userService) UpdateProfile(authID uuid.UUID, input *UpdateProfileInput)
 	return nil
 }
 
+// SetClassReminders liga ou desliga os lembretes de aula enviados por e-mail
+func (s *userService) SetClassReminders(authID uuid.UUID, enabled bool) error {
+	user, err := s.userRepo.GetByAuthID(authID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return errors.New("usuário não encontrado")
+		}
+		return fmt.Errorf("falha ao obter o usuário: %w", err)
+	}
+
+	user.ClassReminders = enabled
+
+	if err := s.userRepo.Update(user); err != nil {
+		return fmt.Errorf("falha ao atualizar o usuário: %w", err)
+	}
+
+	return nil
+}
+
 func (s *userService) GetByID(userID uuid.UUID) (*domain.User, error) {
 	user, err := s.userRepo.GetByID(userID)
 	if err != nil {
@@ -103,3 +175,54 @@ func (s *userService) GetByID(userID uuid.UUID) (*domain.User, error) {
 
 	return user, nil
 }
+
+// DeleteUser faz o soft delete do usuário e desativa o login (na mesma transação)
+func (s *userService) DeleteUser(authID uuid.UUID) error {
+	user, err := s.userRepo.GetByAuthID(authID)
+	if err != nil {
+		return fmt.Errorf("falha ao obter o usuário: %w", err)
+	}
+
+	if err := s.userRepo.Delete(user.ID); err != nil {
+		return fmt.Errorf("falha ao excluir o usuário: %w", err)
+	}
+
+	return nil
+}
+
+// RestoreUser desfaz o soft delete e devolve o login ao status anterior à exclusão
+func (s *userService) RestoreUser(authID uuid.UUID) error {
+	user, err := s.userRepo.GetDeletedByAuthID(authID)
+	if err != nil {
+		return fmt.Errorf("falha ao obter o usuário excluído: %w", err)
+	}
+
+	if err := s.userRepo.Restore(user.ID); err != nil {
+		return fmt.Errorf("falha ao restaurar o usuário: %w", err)
+	}
+
+	return nil
+}
+
+// normalizeContact converte todos os telefones preenchidos para E.164
+func normalizeContact(contact *domain.Contact) error {
+	fields := map[string]*string{
+		"phone":        &contact.Phone,
+		"mobile_phone": &contact.MobilePhone,
+		"whatsapp":     &contact.WhatsApp,
+	}
+
+	for name, value := range fields {
+		if *value == "" {
+			continue
+		}
+
+		normalized, err := validator.NormalizePhone(*value)
+		if err != nil {
+			return fmt.Errorf("%w: %s", ErrInvalidContact, name)
+		}
+		*value = normalized
+	}
+
+	return nil
+}
@@ -0,0 +1,263 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hscHeric/go-potential-api/internal/domain"
+	"github.com/hscHeric/go-potential-api/internal/repository"
+	"github.com/hscHeric/go-potential-api/pkg/email"
+	"github.com/hscHeric/go-potential-api/pkg/token"
+)
+
+type WaitlistService interface {
+	Join(classID, studentID uuid.UUID) (*domain.WaitlistEntry, error)
+	Leave(classID, studentID uuid.UUID) error
+	GetWaitlist(classID uuid.UUID) ([]domain.WaitlistEntry, error)
+	ConfirmOffer(offerToken string, studentID uuid.UUID) (*domain.WaitlistEntry, error)
+	PromoteNext(classID uuid.UUID) error
+	ExpireOffers() error
+}
+
+type waitlistService struct {
+	waitlistRepo     repository.WaitlistRepository
+	classRepo        repository.ClassRepository
+	classStudentRepo repository.ClassStudentRepository
+	timeSlotRepo     repository.TimeSlotRepository
+	roomRepo         repository.RoomRepository
+	userRepo         repository.UserRepository
+	authRepo         repository.AuthRepository
+	credits          CreditService
+	emailService     *email.Service
+	offerExpiration  time.Duration
+	timezones        TimezoneService
+}
+
+func NewWaitlistService(
+	waitlistRepo repository.WaitlistRepository,
+	classRepo repository.ClassRepository,
+	classStudentRepo repository.ClassStudentRepository,
+	timeSlotRepo repository.TimeSlotRepository,
+	roomRepo repository.RoomRepository,
+	userRepo repository.UserRepository,
+	authRepo repository.AuthRepository,
+	credits CreditService,
+	emailService *email.Service,
+	offerExpiration time.Duration,
+	timezones TimezoneService,
+) WaitlistService {
+	return &waitlistService{
+		waitlistRepo:     waitlistRepo,
+		classRepo:        classRepo,
+		classStudentRepo: classStudentRepo,
+		timeSlotRepo:     timeSlotRepo,
+		roomRepo:         roomRepo,
+		userRepo:         userRepo,
+		authRepo:         authRepo,
+		credits:          credits,
+		emailService:     emailService,
+		offerExpiration:  offerExpiration,
+		timezones:        timezones,
+	}
+}
+
+// Join coloca o aluno na lista de espera. Só é permitido quando a aula está lotada.
+func (s *waitlistService) Join(classID, studentID uuid.UUID) (*domain.WaitlistEntry, error) {
+	class, err := s.classRepo.GetByID(classID)
+	if err != nil {
+		return nil, err
+	}
+
+	if class.Status != domain.ClassStatusScheduled || !class.StartsAt.After(time.Now()) {
+		return nil, errors.New("cannot join the waitlist of a past or cancelled class")
+	}
+
+	enrolled, err := s.classStudentRepo.IsStudentInClass(classID, studentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check enrollment: %w", err)
+	}
+
+	if enrolled {
+		return nil, errors.New("student is already in this class")
+	}
+
+	capacity, err := s.capacity(class)
+	if err != nil {
+		return nil, err
+	}
+
+	taken, err := s.takenSeats(classID)
+	if err != nil {
+		return nil, err
+	}
+
+	if capacity == 0 || taken < capacity {
+		return nil, errors.New("class has available seats, join the class directly")
+	}
+
+	entry := &domain.WaitlistEntry{
+		ClassID:   classID,
+		StudentID: studentID,
+	}
+
+	if err := s.waitlistRepo.Join(entry); err != nil {
+		if errors.Is(err, repository.ErrAlreadyExists) {
+			return nil, errors.New("student is already on the waitlist")
+		}
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+// Leave tira o aluno da fila; se ele tinha uma oferta, a vaga passa ao próximo
+func (s *waitlistService) Leave(classID, studentID uuid.UUID) error {
+	entry, err := s.waitlistRepo.GetByClassAndStudent(classID, studentID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.waitlistRepo.Leave(classID, studentID); err != nil {
+		return err
+	}
+
+	if entry.Status == domain.WaitlistStatusOffered {
+		return s.PromoteNext(classID)
+	}
+
+	return nil
+}
+
+func (s *waitlistService) GetWaitlist(classID uuid.UUID) ([]domain.WaitlistEntry, error) {
+	return s.waitlistRepo.GetByClass(classID)
+}
+
+// ConfirmOffer matricula o aluno na vaga oferecida, debitando um crédito da aula
+func (s *waitlistService) ConfirmOffer(offerToken string, studentID uuid.UUID) (*domain.WaitlistEntry, error) {
+	// A matrícula e o débito do crédito são gravados na mesma transação
+	entry, err := s.waitlistRepo.ConfirmOffer(offerToken, studentID)
+	if err != nil {
+		return nil, err
+	}
+	s.credits.NotifyLowBalance(studentID)
+
+	return entry, nil
+}
+
+// PromoteNext oferece as vagas livres da aula aos próximos da fila, em ordem
+func (s *waitlistService) PromoteNext(classID uuid.UUID) error {
+	class, err := s.classRepo.GetByID(classID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	// Aulas passadas ou sem limite de vagas não usam lista de espera
+	if !class.StartsAt.After(time.Now()) {
+		return nil
+	}
+
+	capacity, err := s.capacity(class)
+	if err != nil || capacity == 0 {
+		return err
+	}
+
+	for {
+		offerToken, err := token.Generate(32)
+		if err != nil {
+			return fmt.Errorf("failed to generate offer token: %w", err)
+		}
+
+		entry, err := s.waitlistRepo.OfferNext(classID, capacity, offerToken, time.Now().Add(s.offerExpiration))
+		if err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				return nil
+			}
+			return err
+		}
+
+		go s.sendOfferEmail(entry, class, offerToken)
+	}
+}
+
+// ExpireOffers encerra as ofertas vencidas e repassa as vagas. Executado pelo scheduler.
+func (s *waitlistService) ExpireOffers() error {
+	classIDs, err := s.waitlistRepo.ExpireOffers(time.Now())
+	if err != nil {
+		return err
+	}
+
+	for _, classID := range classIDs {
+		if err := s.PromoteNext(classID); err != nil {
+			fmt.Printf("Warning: failed to promote waitlist of class %s: %v\n", classID, err)
+		}
+	}
+
+	return nil
+}
+
+// capacity retorna o limite de alunos da aula: o menor entre as vagas do horário e a
+// capacidade da sala (0 = sem limite, aula sem horário nem sala)
+func (s *waitlistService) capacity(class *domain.Class) (int, error) {
+	capacity := 0
+
+	if class.TimeSlotID != nil {
+		// O horário excluído continua limitando a turma até a purga
+		slot, err := s.timeSlotRepo.GetByIDWithDeleted(*class.TimeSlotID)
+		if err != nil {
+			return 0, fmt.Errorf("failed to get time slot: %w", err)
+		}
+		capacity = slot.MaxStudents
+	}
+
+	if class.RoomID != nil {
+		room, err := s.roomRepo.GetByID(*class.RoomID)
+		if err != nil {
+			return 0, fmt.Errorf("failed to get room: %w", err)
+		}
+		if capacity == 0 || room.Capacity < capacity {
+			capacity = room.Capacity
+		}
+	}
+
+	return capacity, nil
+}
+
+// takenSeats soma alunos matriculados e vagas reservadas por ofertas em aberto
+func (s *waitlistService) takenSeats(classID uuid.UUID) (int, error) {
+	enrolled, err := s.classStudentRepo.CountStudentsInClass(classID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count students: %w", err)
+	}
+
+	offers, err := s.waitlistRepo.CountActiveOffers(classID)
+	if err != nil {
+		return 0, err
+	}
+
+	return enrolled + offers, nil
+}
+
+func (s *waitlistService) sendOfferEmail(entry *domain.WaitlistEntry, class *domain.Class, offerToken string) {
+	studentAuth, err := s.authRepo.GetByID(entry.StudentID)
+	if err != nil {
+		fmt.Printf("Failed to get student auth: %v\n", err)
+		return
+	}
+
+	studentName := studentAuth.Email
+	if student, err := s.userRepo.GetByAuthID(entry.StudentID); err == nil {
+		studentName = student.FullName
+	}
+
+	loc := s.timezones.ForUser(entry.StudentID)
+	data := buildClassEmailData(s.userRepo, studentName, class, loc)
+
+	if err := s.emailService.SendWaitlistOfferEmail(studentAuth.Email, data, offerToken, entry.OfferExpiresAt.In(loc)); err != nil {
+		fmt.Printf("Failed to send waitlist offer email: %v\n", err)
+	}
+}
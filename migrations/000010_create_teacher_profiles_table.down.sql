@@ -0,0 +1,2 @@
+DROP TRIGGER IF EXISTS update_teacher_profiles_updated_at ON teacher_profiles;
+DROP TABLE IF EXISTS teacher_profiles;
@@ -0,0 +1,27 @@
+-- Perfil profissional do professor (extensão de users)
+CREATE TABLE teacher_profiles (
+    teacher_id UUID PRIMARY KEY REFERENCES auth(id) ON DELETE CASCADE,
+    languages JSONB NOT NULL DEFAULT '[]',
+    levels JSONB NOT NULL DEFAULT '[]',
+    bio TEXT,
+    specialties JSONB NOT NULL DEFAULT '[]',
+    video_intro_url TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Índices GIN para filtros do diretório
+CREATE INDEX idx_teacher_profiles_languages ON teacher_profiles USING GIN (languages);
+CREATE INDEX idx_teacher_profiles_levels ON teacher_profiles USING GIN (levels);
+
+-- Trigger para updated_at
+CREATE TRIGGER update_teacher_profiles_updated_at
+    BEFORE UPDATE ON teacher_profiles
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- Comentários
+COMMENT ON TABLE teacher_profiles IS 'Perfil profissional exibido no diretório de professores';
+COMMENT ON COLUMN teacher_profiles.languages IS 'Idiomas ensinados (ex: ["english", "spanish"])';
+COMMENT ON COLUMN teacher_profiles.levels IS 'Níveis CEFR atendidos (ex: ["A1", "A2", "B1"])';
+COMMENT ON COLUMN teacher_profiles.video_intro_url IS 'Link para vídeo de apresentação do professor';
@@ -0,0 +1,18 @@
+-- Histórico de níveis CEFR dos alunos por idioma
+CREATE TABLE student_levels (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    student_id UUID NOT NULL REFERENCES auth(id) ON DELETE CASCADE,
+    language VARCHAR(50) NOT NULL,
+    level VARCHAR(2) NOT NULL CHECK (level IN ('A1', 'A2', 'B1', 'B2', 'C1', 'C2')),
+    assessed_at DATE NOT NULL,
+    assessed_by UUID REFERENCES auth(id) ON DELETE SET NULL,
+    notes TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Índices
+CREATE INDEX idx_student_levels_student_language ON student_levels(student_id, language, assessed_at DESC);
+
+-- Comentários
+COMMENT ON TABLE student_levels IS 'Avaliações de nível CEFR (A1-C2) dos alunos; o nível atual é a avaliação mais recente por idioma';
+COMMENT ON COLUMN student_levels.assessed_by IS 'Quem realizou a avaliação (professor ou admin)';
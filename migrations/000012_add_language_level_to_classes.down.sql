@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_classes_language_level;
+ALTER TABLE classes
+    DROP COLUMN IF EXISTS level,
+    DROP COLUMN IF EXISTS language;
@@ -0,0 +1,9 @@
+-- Idioma e nível CEFR da aula (opcionais) para filtrar e combinar alunos por nível
+ALTER TABLE classes
+    ADD COLUMN language VARCHAR(50),
+    ADD COLUMN level VARCHAR(2) CHECK (level IN ('A1', 'A2', 'B1', 'B2', 'C1', 'C2'));
+
+CREATE INDEX idx_classes_language_level ON classes(language, level);
+
+COMMENT ON COLUMN classes.language IS 'Idioma da aula (ex: english)';
+COMMENT ON COLUMN classes.level IS 'Nível CEFR da aula (A1-C2)';
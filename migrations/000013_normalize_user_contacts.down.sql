@@ -0,0 +1,2 @@
+-- A normalização para E.164 não é revertida (o formato original não é preservado)
+DROP TABLE IF EXISTS contact_backfill_report;
@@ -0,0 +1,133 @@
+-- Normaliza os telefones de contato para E.164 e registra os registros inválidos.
+-- Telefones inválidos e CPFs com dígitos verificadores incorretos não são alterados;
+-- eles ficam listados em contact_backfill_report para correção manual.
+
+CREATE TABLE contact_backfill_report (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    field VARCHAR(50) NOT NULL,
+    original_value TEXT,
+    reason TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_contact_backfill_report_user_id ON contact_backfill_report(user_id);
+
+COMMENT ON TABLE contact_backfill_report IS 'Registros com CPF ou telefone inválidos encontrados na normalização para E.164';
+
+-- Mesmas regras de pkg/validator.NormalizePhone
+CREATE FUNCTION pg_temp.normalize_phone(raw TEXT) RETURNS TEXT AS $$
+DECLARE
+    trimmed TEXT := btrim(raw);
+    digits TEXT := regexp_replace(raw, '[^0-9]', '', 'g');
+    international BOOLEAN := trimmed LIKE '+%' OR trimmed LIKE '00%';
+BEGIN
+    IF trimmed LIKE '00%' THEN
+        digits := substr(digits, 3);
+    END IF;
+
+    IF international THEN
+        IF digits LIKE '55%' THEN
+            digits := substr(digits, 3);
+        ELSIF length(digits) BETWEEN 8 AND 15 THEN
+            RETURN '+' || digits;
+        ELSE
+            RETURN NULL;
+        END IF;
+    ELSE
+        IF digits LIKE '0%' AND length(digits) IN (11, 12) THEN
+            digits := substr(digits, 2);
+        END IF;
+        IF digits LIKE '55%' AND length(digits) IN (12, 13) THEN
+            digits := substr(digits, 3);
+        END IF;
+    END IF;
+
+    IF length(digits) NOT IN (10, 11)
+       OR substr(digits, 1, 1) = '0'
+       OR substr(digits, 2, 1) = '0'
+       OR (length(digits) = 11 AND substr(digits, 3, 1) <> '9')
+       OR (length(digits) = 10 AND substr(digits, 3, 1) NOT IN ('2', '3', '4', '5')) THEN
+        RETURN NULL;
+    END IF;
+
+    RETURN '+55' || digits;
+END;
+$$ LANGUAGE plpgsql IMMUTABLE;
+
+-- Mesmas regras de pkg/validator.IsValidCPF
+CREATE FUNCTION pg_temp.is_valid_cpf(raw TEXT) RETURNS BOOLEAN AS $$
+DECLARE
+    cpf TEXT := regexp_replace(raw, '[^0-9]', '', 'g');
+    total INTEGER;
+    digit INTEGER;
+BEGIN
+    IF length(cpf) <> 11 OR cpf ~ '^(\d)\1{10}$' THEN
+        RETURN FALSE;
+    END IF;
+
+    total := 0;
+    FOR i IN 1..9 LOOP
+        total := total + substr(cpf, i, 1)::INTEGER * (11 - i);
+    END LOOP;
+    digit := (total * 10) % 11;
+    IF digit = 10 THEN digit := 0; END IF;
+    IF digit <> substr(cpf, 10, 1)::INTEGER THEN
+        RETURN FALSE;
+    END IF;
+
+    total := 0;
+    FOR i IN 1..10 LOOP
+        total := total + substr(cpf, i, 1)::INTEGER * (12 - i);
+    END LOOP;
+    digit := (total * 10) % 11;
+    IF digit = 10 THEN digit := 0; END IF;
+
+    RETURN digit = substr(cpf, 11, 1)::INTEGER;
+END;
+$$ LANGUAGE plpgsql IMMUTABLE;
+
+-- Relatório: celular obrigatório ausente
+INSERT INTO contact_backfill_report (user_id, field, original_value, reason)
+SELECT id, 'mobile_phone', contact->>'mobile_phone', 'celular ausente'
+FROM users
+WHERE COALESCE(btrim(contact->>'mobile_phone'), '') = '';
+
+-- Relatório: telefones que não podem ser normalizados
+INSERT INTO contact_backfill_report (user_id, field, original_value, reason)
+SELECT u.id, f.field, u.contact->>f.field, 'telefone inválido'
+FROM users u
+CROSS JOIN (VALUES ('phone'), ('mobile_phone'), ('whatsapp')) AS f(field)
+WHERE COALESCE(btrim(u.contact->>f.field), '') <> ''
+  AND pg_temp.normalize_phone(u.contact->>f.field) IS NULL;
+
+-- Relatório: CPFs com dígitos verificadores inválidos
+INSERT INTO contact_backfill_report (user_id, field, original_value, reason)
+SELECT id, 'cpf', cpf, 'CPF inválido'
+FROM users
+WHERE NOT pg_temp.is_valid_cpf(cpf);
+
+-- Normalização dos telefones válidos
+UPDATE users
+SET contact = jsonb_set(contact, '{phone}', to_jsonb(pg_temp.normalize_phone(contact->>'phone')))
+WHERE COALESCE(btrim(contact->>'phone'), '') <> ''
+  AND pg_temp.normalize_phone(contact->>'phone') IS NOT NULL;
+
+UPDATE users
+SET contact = jsonb_set(contact, '{mobile_phone}', to_jsonb(pg_temp.normalize_phone(contact->>'mobile_phone')))
+WHERE COALESCE(btrim(contact->>'mobile_phone'), '') <> ''
+  AND pg_temp.normalize_phone(contact->>'mobile_phone') IS NOT NULL;
+
+UPDATE users
+SET contact = jsonb_set(contact, '{whatsapp}', to_jsonb(pg_temp.normalize_phone(contact->>'whatsapp')))
+WHERE COALESCE(btrim(contact->>'whatsapp'), '') <> ''
+  AND pg_temp.normalize_phone(contact->>'whatsapp') IS NOT NULL;
+
+DO $$
+DECLARE
+    invalid_count INTEGER;
+BEGIN
+    SELECT COUNT(*) INTO invalid_count FROM contact_backfill_report;
+    RAISE NOTICE 'contact backfill: % registro(s) inválido(s) em contact_backfill_report', invalid_count;
+END;
+$$;
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_time_slots_deleted_at;
+DROP INDEX IF EXISTS idx_classes_deleted_at;
+DROP INDEX IF EXISTS idx_users_deleted_at;
+
+ALTER TABLE time_slots DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE classes DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE users DROP COLUMN IF EXISTS deleted_at;
@@ -0,0 +1,13 @@
+-- Soft delete: registros excluídos ficam ocultos até serem purgados
+ALTER TABLE users ADD COLUMN deleted_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE classes ADD COLUMN deleted_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE time_slots ADD COLUMN deleted_at TIMESTAMP WITH TIME ZONE;
+
+-- Índices parciais para o job de purga
+CREATE INDEX idx_users_deleted_at ON users(deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX idx_classes_deleted_at ON classes(deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX idx_time_slots_deleted_at ON time_slots(deleted_at) WHERE deleted_at IS NOT NULL;
+
+COMMENT ON COLUMN users.deleted_at IS 'Data do soft delete (NULL=ativo)';
+COMMENT ON COLUMN classes.deleted_at IS 'Data do soft delete (NULL=ativo)';
+COMMENT ON COLUMN time_slots.deleted_at IS 'Data do soft delete (NULL=ativo)';
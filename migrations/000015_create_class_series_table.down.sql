@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_classes_series_id;
+ALTER TABLE classes DROP COLUMN IF EXISTS series_id;
+
+DROP TRIGGER IF EXISTS update_class_series_updated_at ON class_series;
+DROP TABLE IF EXISTS class_series;
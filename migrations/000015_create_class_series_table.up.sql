@@ -0,0 +1,42 @@
+-- Séries de aulas recorrentes (subconjunto do RRULE: semanal em dias fixos, até uma data ou N ocorrências)
+CREATE TABLE class_series (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    teacher_id UUID NOT NULL REFERENCES auth(id) ON DELETE CASCADE,
+    days_of_week JSONB NOT NULL,
+    start_date DATE NOT NULL,
+    until_date DATE,
+    occurrences INTEGER CHECK (occurrences > 0),
+    start_time TIME NOT NULL,
+    end_time TIME NOT NULL,
+    title VARCHAR(255),
+    description TEXT,
+    class_link TEXT,
+    language VARCHAR(50),
+    level VARCHAR(2) CHECK (level IN ('A1', 'A2', 'B1', 'B2', 'C1', 'C2')),
+    exclude_dates JSONB NOT NULL DEFAULT '[]'::jsonb,
+    created_by UUID NOT NULL REFERENCES auth(id),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT valid_series_time CHECK (end_time > start_time),
+    CONSTRAINT valid_series_end CHECK (until_date IS NOT NULL OR occurrences IS NOT NULL)
+);
+
+CREATE INDEX idx_class_series_teacher_id ON class_series(teacher_id);
+
+-- Aulas geradas apontam para a série
+ALTER TABLE classes ADD COLUMN series_id UUID REFERENCES class_series(id) ON DELETE SET NULL;
+CREATE INDEX idx_classes_series_id ON classes(series_id);
+
+-- Trigger para updated_at
+CREATE TRIGGER update_class_series_updated_at
+    BEFORE UPDATE ON class_series
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- Comentários
+COMMENT ON TABLE class_series IS 'Padrão de recorrência usado para gerar aulas semanais';
+COMMENT ON COLUMN class_series.days_of_week IS 'Dias da semana (0=domingo ... 6=sábado)';
+COMMENT ON COLUMN class_series.until_date IS 'Última data da série (opcional se occurrences for informado)';
+COMMENT ON COLUMN class_series.occurrences IS 'Número de ocorrências do padrão (datas puladas também contam)';
+COMMENT ON COLUMN class_series.exclude_dates IS 'Datas excluídas da série (feriados, recessos)';
+COMMENT ON COLUMN classes.series_id IS 'Série que gerou a aula (NULL=aula avulsa)';
@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS update_class_waitlist_updated_at ON class_waitlist;
+DROP TABLE IF EXISTS class_waitlist;
+DROP TYPE IF EXISTS waitlist_status;
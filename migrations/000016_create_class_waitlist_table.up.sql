@@ -0,0 +1,33 @@
+-- Status da entrada na lista de espera
+CREATE TYPE waitlist_status AS ENUM ('waiting', 'offered', 'confirmed', 'expired', 'left');
+
+-- Lista de espera por aula lotada
+CREATE TABLE class_waitlist (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    class_id UUID NOT NULL REFERENCES classes(id) ON DELETE CASCADE,
+    student_id UUID NOT NULL REFERENCES auth(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    status waitlist_status NOT NULL DEFAULT 'waiting',
+    offer_token VARCHAR(255) UNIQUE,
+    offered_at TIMESTAMP WITH TIME ZONE,
+    offer_expires_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(class_id, student_id)
+);
+
+-- Índices
+CREATE INDEX idx_class_waitlist_class_position ON class_waitlist(class_id, position);
+CREATE INDEX idx_class_waitlist_offer_expires_at ON class_waitlist(offer_expires_at) WHERE status = 'offered';
+
+-- Trigger para updated_at
+CREATE TRIGGER update_class_waitlist_updated_at
+    BEFORE UPDATE ON class_waitlist
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- Comentários
+COMMENT ON TABLE class_waitlist IS 'Fila de espera de alunos por vaga em aulas lotadas';
+COMMENT ON COLUMN class_waitlist.position IS 'Ordem de chegada na fila (menor = primeiro)';
+COMMENT ON COLUMN class_waitlist.offer_token IS 'Token enviado por email para confirmar a vaga oferecida';
+COMMENT ON COLUMN class_waitlist.offer_expires_at IS 'Prazo para confirmar; depois a vaga passa ao próximo da fila';
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS class_reschedule_responses;
+DROP TABLE IF EXISTS class_reschedules;
+DROP TYPE IF EXISTS reschedule_status;
@@ -0,0 +1,38 @@
+-- Status da proposta de remarcação
+CREATE TYPE reschedule_status AS ENUM ('pending', 'applied', 'rejected', 'cancelled');
+
+-- Propostas de remarcação (também servem de histórico dos horários anteriores da aula)
+CREATE TABLE class_reschedules (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    class_id UUID NOT NULL REFERENCES classes(id) ON DELETE CASCADE,
+    proposed_by UUID NOT NULL REFERENCES auth(id),
+    previous_date DATE NOT NULL,
+    previous_start_time TIME NOT NULL,
+    previous_end_time TIME NOT NULL,
+    new_date DATE NOT NULL,
+    new_start_time TIME NOT NULL,
+    new_end_time TIME NOT NULL,
+    reason TEXT,
+    status reschedule_status NOT NULL DEFAULT 'pending',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    resolved_at TIMESTAMP WITH TIME ZONE,
+    CONSTRAINT valid_reschedule_time CHECK (new_end_time > new_start_time)
+);
+
+-- Apenas uma proposta pendente por aula
+CREATE UNIQUE INDEX idx_class_reschedules_pending ON class_reschedules(class_id) WHERE status = 'pending';
+CREATE INDEX idx_class_reschedules_class_id ON class_reschedules(class_id, created_at);
+
+-- Respostas dos alunos à proposta
+CREATE TABLE class_reschedule_responses (
+    reschedule_id UUID NOT NULL REFERENCES class_reschedules(id) ON DELETE CASCADE,
+    student_id UUID NOT NULL REFERENCES auth(id) ON DELETE CASCADE,
+    accepted BOOLEAN NOT NULL,
+    responded_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (reschedule_id, student_id)
+);
+
+-- Comentários
+COMMENT ON TABLE class_reschedules IS 'Propostas de remarcação e histórico de horários das aulas';
+COMMENT ON COLUMN class_reschedules.status IS 'pending=aguardando alunos, applied=aula remarcada, rejected=algum aluno recusou, cancelled=substituída ou inviável';
+COMMENT ON TABLE class_reschedule_responses IS 'Confirmação de cada aluno matriculado para a nova data';
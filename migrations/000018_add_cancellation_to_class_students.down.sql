@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_class_students_active;
+
+ALTER TABLE class_students DROP COLUMN IF EXISTS cancellation_outcome;
+ALTER TABLE class_students DROP COLUMN IF EXISTS cancelled_by;
+ALTER TABLE class_students DROP COLUMN IF EXISTS cancelled_at;
+
+DROP TYPE IF EXISTS cancellation_outcome;
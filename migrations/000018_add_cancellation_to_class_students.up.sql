@@ -0,0 +1,13 @@
+-- Cancelamento da participação do aluno: a linha fica como registro da decisão da política
+CREATE TYPE cancellation_outcome AS ENUM ('on_time', 'late_used', 'no_show');
+
+ALTER TABLE class_students ADD COLUMN cancelled_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE class_students ADD COLUMN cancelled_by UUID REFERENCES auth(id);
+ALTER TABLE class_students ADD COLUMN cancellation_outcome cancellation_outcome;
+
+-- Índice parcial para as contagens de vagas (apenas participações ativas)
+CREATE INDEX idx_class_students_active ON class_students(class_id) WHERE cancelled_at IS NULL;
+
+COMMENT ON COLUMN class_students.cancelled_at IS 'Data do cancelamento da participação (NULL=ativo)';
+COMMENT ON COLUMN class_students.cancelled_by IS 'Quem cancelou a participação';
+COMMENT ON COLUMN class_students.cancellation_outcome IS 'Decisão da política: on_time=sem penalidade, late_used=conta como aula usada, no_show=falta';
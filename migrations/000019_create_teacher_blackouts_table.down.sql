@@ -0,0 +1,2 @@
+DROP TRIGGER IF EXISTS update_teacher_blackouts_updated_at ON teacher_blackouts;
+DROP TABLE IF EXISTS teacher_blackouts;
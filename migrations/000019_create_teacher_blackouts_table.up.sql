@@ -0,0 +1,26 @@
+-- Períodos de indisponibilidade do professor (férias, folgas, afastamentos)
+CREATE TABLE teacher_blackouts (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    teacher_id UUID NOT NULL REFERENCES auth(id) ON DELETE CASCADE,
+    start_date DATE NOT NULL,
+    end_date DATE NOT NULL,
+    reason TEXT,
+    created_by UUID NOT NULL REFERENCES auth(id),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT check_blackout_dates CHECK (start_date <= end_date)
+);
+
+-- Índices
+CREATE INDEX idx_teacher_blackouts_teacher_dates ON teacher_blackouts(teacher_id, start_date, end_date);
+
+-- Trigger para updated_at
+CREATE TRIGGER update_teacher_blackouts_updated_at
+    BEFORE UPDATE ON teacher_blackouts
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- Comentários
+COMMENT ON TABLE teacher_blackouts IS 'Períodos em que o professor não dá aulas';
+COMMENT ON COLUMN teacher_blackouts.start_date IS 'Primeiro dia do período (inclusive)';
+COMMENT ON COLUMN teacher_blackouts.end_date IS 'Último dia do período (inclusive)';
@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS update_holidays_updated_at ON holidays;
+DROP TABLE IF EXISTS holidays;
+DROP TYPE IF EXISTS holiday_scope;
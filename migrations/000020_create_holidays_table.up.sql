@@ -0,0 +1,28 @@
+-- Abrangência do feriado
+CREATE TYPE holiday_scope AS ENUM ('national', 'state', 'municipal', 'school');
+
+-- Calendário de feriados e recessos da escola
+CREATE TABLE holidays (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    date DATE NOT NULL,
+    name VARCHAR(150) NOT NULL,
+    scope holiday_scope NOT NULL DEFAULT 'school',
+    created_by UUID REFERENCES auth(id),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT unique_holiday_date_name UNIQUE (date, name)
+);
+
+-- Índices
+CREATE INDEX idx_holidays_date ON holidays(date);
+
+-- Trigger para updated_at
+CREATE TRIGGER update_holidays_updated_at
+    BEFORE UPDATE ON holidays
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- Comentários
+COMMENT ON TABLE holidays IS 'Feriados e recessos em que a escola não funciona';
+COMMENT ON COLUMN holidays.scope IS 'national=nacional, state=estadual, municipal=municipal, school=recesso da escola';
+COMMENT ON COLUMN holidays.created_by IS 'Admin que cadastrou (NULL=gerado automaticamente)';
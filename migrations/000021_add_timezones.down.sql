@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_classes_starts_at;
+ALTER TABLE classes DROP COLUMN IF EXISTS ends_at;
+ALTER TABLE classes DROP COLUMN IF EXISTS starts_at;
+ALTER TABLE users DROP COLUMN IF EXISTS timezone;
@@ -0,0 +1,27 @@
+-- Fuso IANA do usuário, usado para exibir horários e nos e-mails
+ALTER TABLE users ADD COLUMN timezone VARCHAR(64) NOT NULL DEFAULT 'America/Sao_Paulo';
+
+-- Instantes normalizados da aula. scheduled_date/start_time/end_time continuam
+-- sendo o horário de parede no fuso da escola.
+ALTER TABLE classes
+    ADD COLUMN starts_at TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN ends_at TIMESTAMP WITH TIME ZONE;
+
+-- Aulas existentes foram agendadas no fuso da escola. A migração não lê o .env:
+-- escolas com SCHOOL_TIMEZONE diferente do padrão devem informar o mesmo fuso em
+-- app.school_timezone ao migrar, ex.: PGOPTIONS='-c app.school_timezone=Europe/Lisbon'
+UPDATE classes
+SET starts_at = (scheduled_date + start_time) AT TIME ZONE COALESCE(NULLIF(current_setting('app.school_timezone', true), ''), 'America/Sao_Paulo'),
+    ends_at = (scheduled_date + end_time) AT TIME ZONE COALESCE(NULLIF(current_setting('app.school_timezone', true), ''), 'America/Sao_Paulo');
+
+ALTER TABLE classes
+    ALTER COLUMN starts_at SET NOT NULL,
+    ALTER COLUMN ends_at SET NOT NULL;
+
+-- Índices
+CREATE INDEX idx_classes_starts_at ON classes(starts_at) WHERE deleted_at IS NULL;
+
+-- Comentários
+COMMENT ON COLUMN users.timezone IS 'Fuso IANA do usuário (ex.: America/Sao_Paulo, Europe/Lisbon)';
+COMMENT ON COLUMN classes.starts_at IS 'Início da aula como instante (fuso da escola aplicado a scheduled_date + start_time)';
+COMMENT ON COLUMN classes.ends_at IS 'Término da aula como instante (fuso da escola aplicado a scheduled_date + end_time)';
@@ -0,0 +1,15 @@
+-- Assinatura de calendário (.ics) de cada usuário
+CREATE TABLE calendar_feeds (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    auth_id UUID NOT NULL REFERENCES auth(id) ON DELETE CASCADE,
+    token VARCHAR(64) NOT NULL,
+    last_accessed_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT unique_calendar_feed_auth UNIQUE (auth_id),
+    CONSTRAINT unique_calendar_feed_token UNIQUE (token)
+);
+
+-- Comentários
+COMMENT ON TABLE calendar_feeds IS 'URL secreta de assinatura do calendário de aulas; um token ativo por usuário';
+COMMENT ON COLUMN calendar_feeds.token IS 'Token da URL pública; gerar outro revoga o anterior';
+COMMENT ON COLUMN calendar_feeds.last_accessed_at IS 'Última leitura do feed pelo cliente de calendário';
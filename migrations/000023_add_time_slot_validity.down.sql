@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_time_slots_validity;
+ALTER TABLE time_slots DROP CONSTRAINT IF EXISTS chk_time_slots_validity;
+ALTER TABLE time_slots DROP COLUMN IF EXISTS valid_until;
+ALTER TABLE time_slots DROP COLUMN IF EXISTS valid_from;
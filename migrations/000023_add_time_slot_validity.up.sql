@@ -0,0 +1,22 @@
+-- Janela de validade do horário. Mudanças na grade criam uma nova versão e encerram
+-- a anterior, preservando o horário referenciado pelas aulas já realizadas.
+ALTER TABLE time_slots
+    ADD COLUMN valid_from DATE,
+    ADD COLUMN valid_until DATE;
+
+-- Horários existentes valem desde a criação, no fuso da escola (app.school_timezone,
+-- como na migração 000021)
+UPDATE time_slots
+SET valid_from = (created_at AT TIME ZONE COALESCE(NULLIF(current_setting('app.school_timezone', true), ''), 'America/Sao_Paulo'))::date;
+
+ALTER TABLE time_slots
+    ALTER COLUMN valid_from SET NOT NULL,
+    ALTER COLUMN valid_from SET DEFAULT CURRENT_DATE,
+    ADD CONSTRAINT chk_time_slots_validity CHECK (valid_until IS NULL OR valid_until >= valid_from);
+
+-- Índices
+CREATE INDEX idx_time_slots_validity ON time_slots(teacher_id, day_of_week, valid_from, valid_until) WHERE deleted_at IS NULL;
+
+-- Comentários
+COMMENT ON COLUMN time_slots.valid_from IS 'Primeira data em que o horário vale (inclusive)';
+COMMENT ON COLUMN time_slots.valid_until IS 'Última data em que o horário vale (inclusive); NULL = sem data de término';
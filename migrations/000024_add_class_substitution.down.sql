@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_classes_original_teacher_id;
+ALTER TABLE classes DROP COLUMN IF EXISTS substituted_at;
+ALTER TABLE classes DROP COLUMN IF EXISTS substitution_reason;
+ALTER TABLE classes DROP COLUMN IF EXISTS original_teacher_id;
@@ -0,0 +1,14 @@
+-- Substituição de professor. teacher_id passa a ser quem dá a aula; o titular fica
+-- em original_teacher_id para relatórios.
+ALTER TABLE classes
+    ADD COLUMN original_teacher_id UUID REFERENCES auth(id) ON DELETE SET NULL,
+    ADD COLUMN substitution_reason TEXT,
+    ADD COLUMN substituted_at TIMESTAMP WITH TIME ZONE;
+
+-- Índices
+CREATE INDEX idx_classes_original_teacher_id ON classes(original_teacher_id) WHERE original_teacher_id IS NOT NULL;
+
+-- Comentários
+COMMENT ON COLUMN classes.original_teacher_id IS 'Professor titular quando a aula é dada por um substituto (NULL = sem substituição)';
+COMMENT ON COLUMN classes.substitution_reason IS 'Motivo da substituição (ex.: doença)';
+COMMENT ON COLUMN classes.substituted_at IS 'Momento em que o substituto foi designado';
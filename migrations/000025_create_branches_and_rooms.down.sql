@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_classes_room_date;
+ALTER TABLE classes DROP COLUMN IF EXISTS room_id;
+DROP TRIGGER IF EXISTS update_rooms_updated_at ON rooms;
+DROP TRIGGER IF EXISTS update_branches_updated_at ON branches;
+DROP TABLE IF EXISTS rooms;
+DROP TABLE IF EXISTS branches;
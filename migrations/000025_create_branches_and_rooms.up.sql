@@ -0,0 +1,49 @@
+-- Unidades físicas da escola
+CREATE TABLE branches (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(150) NOT NULL,
+    address TEXT,
+    is_active BOOLEAN NOT NULL DEFAULT true,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT unique_branch_name UNIQUE (name)
+);
+
+-- Salas de aula de cada unidade
+CREATE TABLE rooms (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    branch_id UUID NOT NULL REFERENCES branches(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    capacity INTEGER NOT NULL CHECK (capacity > 0),
+    equipment JSONB NOT NULL DEFAULT '[]',
+    is_active BOOLEAN NOT NULL DEFAULT true,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT unique_room_name_per_branch UNIQUE (branch_id, name)
+);
+
+-- Sala reservada pela aula (NULL=aula online)
+ALTER TABLE classes ADD COLUMN room_id UUID REFERENCES rooms(id) ON DELETE SET NULL;
+
+-- Índices
+CREATE INDEX idx_rooms_branch_id ON rooms(branch_id);
+CREATE INDEX idx_rooms_equipment ON rooms USING GIN (equipment);
+CREATE INDEX idx_classes_room_date ON classes(room_id, scheduled_date) WHERE room_id IS NOT NULL AND deleted_at IS NULL;
+
+-- Triggers para updated_at
+CREATE TRIGGER update_branches_updated_at
+    BEFORE UPDATE ON branches
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TRIGGER update_rooms_updated_at
+    BEFORE UPDATE ON rooms
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- Comentários
+COMMENT ON TABLE branches IS 'Unidades físicas onde acontecem as aulas presenciais';
+COMMENT ON TABLE rooms IS 'Salas de aula de uma unidade, com capacidade e equipamentos';
+COMMENT ON COLUMN rooms.capacity IS 'Número máximo de alunos na sala';
+COMMENT ON COLUMN rooms.equipment IS 'Equipamentos disponíveis (ex: ["projector", "whiteboard"])';
+COMMENT ON COLUMN classes.room_id IS 'Sala reservada para a aula presencial (NULL=aula online)';
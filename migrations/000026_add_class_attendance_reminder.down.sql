@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_classes_open_ends_at;
+ALTER TABLE classes DROP COLUMN IF EXISTS attendance_reminded_at;
@@ -0,0 +1,8 @@
+-- Controle do lembrete de chamada pendente enviado ao professor (no máximo um por aula)
+ALTER TABLE classes ADD COLUMN attendance_reminded_at TIMESTAMP WITH TIME ZONE;
+
+-- Índice para o job que encerra as aulas já terminadas
+CREATE INDEX idx_classes_open_ends_at ON classes(ends_at) WHERE status = 'scheduled' AND deleted_at IS NULL;
+
+-- Comentários
+COMMENT ON COLUMN classes.attendance_reminded_at IS 'Quando o professor foi lembrado de fazer a chamada (NULL=não lembrado)';
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS class_reminders;
+ALTER TABLE users DROP COLUMN IF EXISTS class_reminders;
@@ -0,0 +1,17 @@
+-- Preferência do usuário por receber lembretes de aula
+ALTER TABLE users ADD COLUMN class_reminders BOOLEAN NOT NULL DEFAULT true;
+
+-- Lembretes de aula já enviados; a chave primária garante no máximo um envio
+-- por aula, destinatário e antecedência, mesmo após reinícios
+CREATE TABLE class_reminders (
+    class_id UUID NOT NULL REFERENCES classes(id) ON DELETE CASCADE,
+    auth_id UUID NOT NULL REFERENCES auth(id) ON DELETE CASCADE,
+    offset_minutes INTEGER NOT NULL CHECK (offset_minutes > 0),
+    sent_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (class_id, auth_id, offset_minutes)
+);
+
+-- Comentários
+COMMENT ON COLUMN users.class_reminders IS 'Se o usuário recebe lembretes por e-mail antes das aulas';
+COMMENT ON TABLE class_reminders IS 'Registro dos lembretes de aula enviados (idempotência do job de lembretes)';
+COMMENT ON COLUMN class_reminders.offset_minutes IS 'Antecedência do lembrete em minutos (ex: 1440 = 24 horas)';
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS credit_ledger;
+DROP TABLE IF EXISTS credit_packages;
+DROP TYPE IF EXISTS credit_entry_type;
+DROP TYPE IF EXISTS credit_package_kind;
@@ -0,0 +1,48 @@
+-- Tipos de lote de créditos e de lançamento no extrato
+CREATE TYPE credit_package_kind AS ENUM ('package', 'makeup');
+CREATE TYPE credit_entry_type AS ENUM ('purchase', 'makeup', 'debit', 'refund');
+
+-- Lotes de créditos do aluno: pacotes comprados e créditos de reposição
+CREATE TABLE credit_packages (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    student_id UUID NOT NULL REFERENCES auth(id) ON DELETE CASCADE,
+    kind credit_package_kind NOT NULL DEFAULT 'package',
+    name VARCHAR(150) NOT NULL,
+    credits INTEGER NOT NULL CHECK (credits > 0),
+    expires_at TIMESTAMP WITH TIME ZONE,
+    class_id UUID REFERENCES classes(id) ON DELETE SET NULL,
+    created_by UUID REFERENCES auth(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_credit_packages_student ON credit_packages(student_id, expires_at);
+
+-- No máximo uma reposição por aluno e aula cancelada
+CREATE UNIQUE INDEX idx_credit_packages_makeup ON credit_packages(student_id, class_id) WHERE kind = 'makeup';
+
+CREATE TRIGGER update_credit_packages_updated_at BEFORE UPDATE ON credit_packages
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- Extrato de créditos: o saldo de um lote é a soma dos seus lançamentos
+CREATE TABLE credit_ledger (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    package_id UUID NOT NULL REFERENCES credit_packages(id) ON DELETE CASCADE,
+    student_id UUID NOT NULL REFERENCES auth(id) ON DELETE CASCADE,
+    class_id UUID REFERENCES classes(id) ON DELETE SET NULL,
+    entry_type credit_entry_type NOT NULL,
+    amount INTEGER NOT NULL CHECK (amount <> 0),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_credit_ledger_package ON credit_ledger(package_id);
+CREATE INDEX idx_credit_ledger_student ON credit_ledger(student_id, created_at);
+CREATE INDEX idx_credit_ledger_class ON credit_ledger(class_id, student_id);
+
+-- Comentários
+COMMENT ON TABLE credit_packages IS 'Lotes de créditos de aula do aluno (pacotes comprados e reposições)';
+COMMENT ON COLUMN credit_packages.kind IS 'package = pacote comprado, makeup = reposição por aula cancelada pelo professor';
+COMMENT ON COLUMN credit_packages.expires_at IS 'Validade dos créditos do lote; nula não expira';
+COMMENT ON COLUMN credit_packages.class_id IS 'Aula cancelada que originou a reposição';
+COMMENT ON TABLE credit_ledger IS 'Lançamentos de créditos por aluno e lote';
+COMMENT ON COLUMN credit_ledger.amount IS 'Positivo para créditos concedidos ou estornados, negativo para aulas debitadas';
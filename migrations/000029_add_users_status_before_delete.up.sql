@@ -0,0 +1,8 @@
+-- Status do login no momento do soft delete, devolvido ao restaurar o usuário
+ALTER TABLE users ADD COLUMN status_before_delete VARCHAR(20)
+    CHECK (status_before_delete IN ('pending', 'active', 'inactive'));
+
+-- Usuários já excluídos eram reativados ao restaurar; mantém o mesmo comportamento
+UPDATE users SET status_before_delete = 'active' WHERE deleted_at IS NOT NULL;
+
+COMMENT ON COLUMN users.status_before_delete IS 'Status do login (auth) antes do soft delete; NULL se o usuário não está excluído';
@@ -0,0 +1,89 @@
+// Package cancellation decide se um cancelamento de aula é permitido e quais as consequências
+package cancellation
+
+import (
+	"errors"
+	"time"
+)
+
+// Actor é quem pede o cancelamento (mesmos valores de domain.Role)
+type Actor string
+
+const (
+	ActorAdmin   Actor = "admin"
+	ActorTeacher Actor = "teacher"
+	ActorStudent Actor = "student"
+)
+
+// Penalty define como um cancelamento fora do prazo é contabilizado para o aluno
+type Penalty string
+
+const (
+	PenaltyUsed   Penalty = "used"    // Conta como aula usada
+	PenaltyNoShow Penalty = "no_show" // Registrado como falta
+)
+
+var (
+	ErrNotAllowed     = errors.New("cancellation not allowed for this role")
+	ErrAlreadyStarted = errors.New("class has already started")
+	ErrInvalidPenalty = errors.New("late penalty must be 'used' or 'no_show'")
+)
+
+// Policy reúne as regras de cancelamento da escola
+type Policy struct {
+	MinNotice         time.Duration // Antecedência mínima para cancelar sem penalidade
+	StudentsMayCancel bool          // Alunos podem cancelar a própria participação
+	TeachersMayCancel bool          // Professores podem cancelar a aula
+	LatePenalty       Penalty       // Consequência para o aluno que cancela fora do prazo
+}
+
+// Decision é o resultado da avaliação de um cancelamento
+type Decision struct {
+	Late    bool          // Feito com menos antecedência que MinNotice
+	Penalty Penalty       // Vazio quando não há penalidade
+	Notice  time.Duration // Antecedência em relação ao início da aula
+}
+
+// Validate verifica se a política está bem configurada
+func (p Policy) Validate() error {
+	if p.LatePenalty != PenaltyUsed && p.LatePenalty != PenaltyNoShow {
+		return ErrInvalidPenalty
+	}
+	return nil
+}
+
+// Evaluate decide se o ator pode cancelar uma aula que começa em start.
+// Admins sempre podem cancelar, inclusive aulas já iniciadas. Apenas alunos
+// recebem penalidade; o cancelamento tardio do professor é só sinalizado.
+func (p Policy) Evaluate(actor Actor, start, now time.Time) (Decision, error) {
+	notice := start.Sub(now)
+	decision := Decision{
+		Late:   notice < p.MinNotice,
+		Notice: notice,
+	}
+
+	switch actor {
+	case ActorAdmin:
+		return decision, nil
+	case ActorTeacher:
+		if !p.TeachersMayCancel {
+			return Decision{}, ErrNotAllowed
+		}
+	case ActorStudent:
+		if !p.StudentsMayCancel {
+			return Decision{}, ErrNotAllowed
+		}
+	default:
+		return Decision{}, ErrNotAllowed
+	}
+
+	if notice <= 0 {
+		return Decision{}, ErrAlreadyStarted
+	}
+
+	if decision.Late && actor == ActorStudent {
+		decision.Penalty = p.LatePenalty
+	}
+
+	return decision, nil
+}
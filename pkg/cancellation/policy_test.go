@@ -0,0 +1,95 @@
+package cancellation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newPolicy() Policy {
+	return Policy{
+		MinNotice:         24 * time.Hour,
+		StudentsMayCancel: true,
+		TeachersMayCancel: true,
+		LatePenalty:       PenaltyUsed,
+	}
+}
+
+func TestPolicyEvaluate(t *testing.T) {
+	now := time.Date(2025, 3, 10, 10, 0, 0, 0, time.UTC)
+
+	t.Run("should allow student cancellation with enough notice", func(t *testing.T) {
+		decision, err := newPolicy().Evaluate(ActorStudent, now.Add(48*time.Hour), now)
+
+		require.NoError(t, err)
+		assert.False(t, decision.Late)
+		assert.Empty(t, decision.Penalty)
+		assert.Equal(t, 48*time.Hour, decision.Notice)
+	})
+
+	t.Run("should apply the late penalty to students", func(t *testing.T) {
+		policy := newPolicy()
+		policy.LatePenalty = PenaltyNoShow
+
+		decision, err := policy.Evaluate(ActorStudent, now.Add(2*time.Hour), now)
+
+		require.NoError(t, err)
+		assert.True(t, decision.Late)
+		assert.Equal(t, PenaltyNoShow, decision.Penalty)
+	})
+
+	t.Run("should flag late teacher cancellations without penalty", func(t *testing.T) {
+		decision, err := newPolicy().Evaluate(ActorTeacher, now.Add(time.Hour), now)
+
+		require.NoError(t, err)
+		assert.True(t, decision.Late)
+		assert.Empty(t, decision.Penalty)
+	})
+
+	t.Run("should reject roles not allowed to cancel", func(t *testing.T) {
+		policy := newPolicy()
+		policy.StudentsMayCancel = false
+		policy.TeachersMayCancel = false
+
+		_, err := policy.Evaluate(ActorStudent, now.Add(48*time.Hour), now)
+		assert.ErrorIs(t, err, ErrNotAllowed)
+
+		_, err = policy.Evaluate(ActorTeacher, now.Add(48*time.Hour), now)
+		assert.ErrorIs(t, err, ErrNotAllowed)
+
+		_, err = policy.Evaluate(Actor("guest"), now.Add(48*time.Hour), now)
+		assert.ErrorIs(t, err, ErrNotAllowed)
+	})
+
+	t.Run("should reject cancellations after the class started", func(t *testing.T) {
+		_, err := newPolicy().Evaluate(ActorStudent, now, now)
+		assert.ErrorIs(t, err, ErrAlreadyStarted)
+
+		_, err = newPolicy().Evaluate(ActorTeacher, now.Add(-time.Minute), now)
+		assert.ErrorIs(t, err, ErrAlreadyStarted)
+	})
+
+	t.Run("should always allow admins", func(t *testing.T) {
+		policy := newPolicy()
+		policy.TeachersMayCancel = false
+
+		decision, err := policy.Evaluate(ActorAdmin, now.Add(-time.Hour), now)
+
+		require.NoError(t, err)
+		assert.True(t, decision.Late)
+		assert.Empty(t, decision.Penalty)
+	})
+}
+
+func TestPolicyValidate(t *testing.T) {
+	t.Run("should accept known penalties", func(t *testing.T) {
+		assert.NoError(t, Policy{LatePenalty: PenaltyUsed}.Validate())
+		assert.NoError(t, Policy{LatePenalty: PenaltyNoShow}.Validate())
+	})
+
+	t.Run("should reject unknown penalties", func(t *testing.T) {
+		assert.ErrorIs(t, Policy{LatePenalty: "fine"}.Validate(), ErrInvalidPenalty)
+	})
+}
@@ -0,0 +1,104 @@
+// Package cep consulta endereços brasileiros a partir do CEP
+package cep
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+)
+
+var (
+	ErrInvalidCEP = errors.New("CEP inválido")
+	ErrNotFound   = errors.New("CEP não encontrado")
+	// ErrNotInDataset indica um CEP ausente da base local, que pode estar incompleta
+	ErrNotInDataset = fmt.Errorf("%w na base local", ErrNotFound)
+)
+
+// Address representa o endereço retornado pela consulta de CEP
+type Address struct {
+	CEP        string `json:"cep"`
+	Street     string `json:"street"`
+	Complement string `json:"complement"`
+	District   string `json:"district"`
+	City       string `json:"city"`
+	State      string `json:"state"`
+}
+
+// Provider é a interface implementada pelas fontes de consulta de CEP
+type Provider interface {
+	Lookup(cep string) (*Address, error)
+}
+
+var nonDigits = regexp.MustCompile(`[^0-9]`)
+
+// Normalize remove a formatação do CEP ("01310-100" -> "01310100")
+// e retorna ErrInvalidCEP se o resultado não tiver 8 dígitos
+func Normalize(cep string) (string, error) {
+	digits := nonDigits.ReplaceAllString(cep, "")
+	if len(digits) != 8 {
+		return "", ErrInvalidCEP
+	}
+
+	return digits, nil
+}
+
+// stateRange representa uma faixa de CEPs (5 primeiros dígitos) de uma UF
+type stateRange struct {
+	start, end int
+	state      string
+}
+
+// stateRanges são as faixas de CEP por UF definidas pelos Correios
+var stateRanges = []stateRange{
+	{1000, 19999, "SP"},
+	{20000, 28999, "RJ"},
+	{29000, 29999, "ES"},
+	{30000, 39999, "MG"},
+	{40000, 48999, "BA"},
+	{49000, 49999, "SE"},
+	{50000, 56999, "PE"},
+	{57000, 57999, "AL"},
+	{58000, 58999, "PB"},
+	{59000, 59999, "RN"},
+	{60000, 63999, "CE"},
+	{64000, 64999, "PI"},
+	{65000, 65999, "MA"},
+	{66000, 68899, "PA"},
+	{68900, 68999, "AP"},
+	{69000, 69299, "AM"},
+	{69300, 69399, "RR"},
+	{69400, 69899, "AM"},
+	{69900, 69999, "AC"},
+	{70000, 72799, "DF"},
+	{72800, 72999, "GO"},
+	{73000, 73699, "DF"},
+	{73700, 76799, "GO"},
+	{76800, 76999, "RO"},
+	{77000, 77999, "TO"},
+	{78000, 78899, "MT"},
+	{79000, 79999, "MS"},
+	{80000, 87999, "PR"},
+	{88000, 89999, "SC"},
+	{90000, 99999, "RS"},
+}
+
+// StateForCEP retorna a UF correspondente à faixa do CEP, sem consulta externa
+func StateForCEP(cep string) (string, bool) {
+	digits, err := Normalize(cep)
+	if err != nil {
+		return "", false
+	}
+
+	prefix := 0
+	for _, d := range digits[:5] {
+		prefix = prefix*10 + int(d-'0')
+	}
+
+	for _, r := range stateRanges {
+		if prefix >= r.start && prefix <= r.end {
+			return r.state, true
+		}
+	}
+
+	return "", false
+}
@@ -0,0 +1,130 @@
+package cep
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalize(t *testing.T) {
+	t.Run("should strip formatting", func(t *testing.T) {
+		digits, err := Normalize("01310-100")
+
+		require.NoError(t, err)
+		assert.Equal(t, "01310100", digits)
+	})
+
+	t.Run("should reject wrong length", func(t *testing.T) {
+		_, err := Normalize("1234")
+
+		assert.ErrorIs(t, err, ErrInvalidCEP)
+	})
+}
+
+func TestStateForCEP(t *testing.T) {
+	cases := map[string]string{
+		"01310-100": "SP",
+		"20040-020": "RJ",
+		"70040-010": "DF",
+		"69301-000": "RR",
+		"90010-000": "RS",
+	}
+
+	for cep, expected := range cases {
+		state, ok := StateForCEP(cep)
+
+		assert.True(t, ok, cep)
+		assert.Equal(t, expected, state, cep)
+	}
+
+	_, ok := StateForCEP("00000-000")
+	assert.False(t, ok)
+}
+
+func TestOfflineProvider(t *testing.T) {
+	data := "cep;logradouro;bairro;cidade;uf\n01310-100;Avenida Paulista;Bela Vista;São Paulo;sp\n"
+
+	provider, err := NewOfflineProvider(strings.NewReader(data))
+	require.NoError(t, err)
+
+	t.Run("should find known cep", func(t *testing.T) {
+		address, err := provider.Lookup("01310100")
+
+		require.NoError(t, err)
+		assert.Equal(t, "Avenida Paulista", address.Street)
+		assert.Equal(t, "SP", address.State)
+	})
+
+	t.Run("should return not found for unknown cep", func(t *testing.T) {
+		_, err := provider.Lookup("20040-020")
+
+		assert.ErrorIs(t, err, ErrNotFound)
+		assert.ErrorIs(t, err, ErrNotInDataset)
+	})
+}
+
+func TestViaCEPProvider(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/01310100/json/" {
+			_, _ = w.Write([]byte(`{"cep":"01310-100","logradouro":"Avenida Paulista","bairro":"Bela Vista","localidade":"São Paulo","uf":"SP"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"erro": "true"}`))
+	}))
+	defer server.Close()
+
+	provider := NewViaCEPProvider(server.URL, time.Second)
+
+	t.Run("should map response fields", func(t *testing.T) {
+		address, err := provider.Lookup("01310-100")
+
+		require.NoError(t, err)
+		assert.Equal(t, "São Paulo", address.City)
+		assert.Equal(t, "Bela Vista", address.District)
+	})
+
+	t.Run("should return not found when api reports error", func(t *testing.T) {
+		_, err := provider.Lookup("99999-999")
+
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+}
+
+type countingProvider struct {
+	calls int
+}
+
+func (p *countingProvider) Lookup(cep string) (*Address, error) {
+	p.calls++
+	return &Address{CEP: cep, State: "SP"}, nil
+}
+
+func TestCachedProvider(t *testing.T) {
+	next := &countingProvider{}
+	provider := NewCachedProvider(next, time.Hour, 2)
+
+	_, err := provider.Lookup("01310-100")
+	require.NoError(t, err)
+	_, err = provider.Lookup("01310100")
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, next.calls)
+
+	t.Run("should evict the oldest entry when full", func(t *testing.T) {
+		_, err := provider.Lookup("20040-020")
+		require.NoError(t, err)
+		_, err = provider.Lookup("90010-000")
+		require.NoError(t, err)
+
+		assert.Len(t, provider.entries, 2)
+
+		_, err = provider.Lookup("01310-100")
+		require.NoError(t, err)
+		assert.Equal(t, 4, next.calls)
+	})
+}
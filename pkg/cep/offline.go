@@ -0,0 +1,164 @@
+package cep
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// OfflineProvider consulta CEPs em uma base local carregada em memória.
+// O arquivo é um CSV separado por ";" com as colunas:
+// cep;logradouro;bairro;cidade;uf
+type OfflineProvider struct {
+	addresses map[string]Address
+}
+
+// NewOfflineProvider carrega a base de CEPs a partir de um reader CSV
+func NewOfflineProvider(r io.Reader) (*OfflineProvider, error) {
+	reader := csv.NewReader(r)
+	reader.Comma = ';'
+	reader.FieldsPerRecord = 5
+	reader.TrimLeadingSpace = true
+
+	addresses := make(map[string]Address)
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("falha ao ler a base de CEPs (linha %d): %w", line, err)
+		}
+
+		digits, err := Normalize(record[0])
+		if err != nil {
+			// Ignora cabeçalho e linhas com CEP malformado
+			continue
+		}
+
+		addresses[digits] = Address{
+			CEP:      digits,
+			Street:   record[1],
+			District: record[2],
+			City:     record[3],
+			State:    strings.ToUpper(record[4]),
+		}
+	}
+
+	return &OfflineProvider{addresses: addresses}, nil
+}
+
+// NewOfflineProviderFromFile carrega a base de CEPs a partir de um arquivo
+func NewOfflineProviderFromFile(path string) (*OfflineProvider, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("falha ao abrir a base de CEPs: %w", err)
+	}
+
+	defer func() {
+		_ = file.Close()
+	}()
+
+	return NewOfflineProvider(file)
+}
+
+// Lookup consulta um CEP na base local
+func (p *OfflineProvider) Lookup(cep string) (*Address, error) {
+	digits, err := Normalize(cep)
+	if err != nil {
+		return nil, err
+	}
+
+	address, ok := p.addresses[digits]
+	if !ok {
+		return nil, ErrNotInDataset
+	}
+
+	return &address, nil
+}
+
+type cacheEntry struct {
+	address   *Address
+	err       error
+	expiresAt time.Time
+}
+
+// CachedProvider guarda em memória os resultados de outro provider.
+// CEPs inexistentes também são cacheados; falhas de rede não.
+// O cache guarda no máximo maxEntries CEPs.
+type CachedProvider struct {
+	next       Provider
+	ttl        time.Duration
+	maxEntries int
+	mu         sync.RWMutex
+	entries    map[string]cacheEntry
+}
+
+// NewCachedProvider cria um cache em memória na frente de outro provider
+func NewCachedProvider(next Provider, ttl time.Duration, maxEntries int) *CachedProvider {
+	return &CachedProvider{
+		next:       next,
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		entries:    make(map[string]cacheEntry),
+	}
+}
+
+// Lookup consulta o cache e, em caso de ausência, o provider interno
+func (p *CachedProvider) Lookup(cep string) (*Address, error) {
+	digits, err := Normalize(cep)
+	if err != nil {
+		return nil, err
+	}
+
+	p.mu.RLock()
+	entry, ok := p.entries[digits]
+	p.mu.RUnlock()
+
+	if ok && time.Now().Before(entry.expiresAt) {
+		return entry.address, entry.err
+	}
+
+	address, err := p.next.Lookup(digits)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	if _, cached := p.entries[digits]; !cached && len(p.entries) >= p.maxEntries {
+		p.evict()
+	}
+	p.entries[digits] = cacheEntry{
+		address:   address,
+		err:       err,
+		expiresAt: time.Now().Add(p.ttl),
+	}
+	p.mu.Unlock()
+
+	return address, err
+}
+
+// evict remove as entradas expiradas e, se o cache continuar cheio, a que expira
+// primeiro. Deve ser chamado com o lock de escrita.
+func (p *CachedProvider) evict() {
+	now := time.Now()
+	oldest := ""
+	for digits, entry := range p.entries {
+		if !now.Before(entry.expiresAt) {
+			delete(p.entries, digits)
+			continue
+		}
+		if oldest == "" || entry.expiresAt.Before(p.entries[oldest].expiresAt) {
+			oldest = digits
+		}
+	}
+
+	if len(p.entries) >= p.maxEntries && oldest != "" {
+		delete(p.entries, oldest)
+	}
+}
@@ -0,0 +1,84 @@
+package cep
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const defaultViaCEPURL = "https://viacep.com.br/ws"
+
+// ViaCEPProvider consulta CEPs na API pública do ViaCEP
+type ViaCEPProvider struct {
+	baseURL string
+	client  *http.Client
+}
+
+// NewViaCEPProvider cria um provider do ViaCEP; baseURL vazio usa o endereço público
+func NewViaCEPProvider(baseURL string, timeout time.Duration) *ViaCEPProvider {
+	if baseURL == "" {
+		baseURL = defaultViaCEPURL
+	}
+
+	return &ViaCEPProvider{
+		baseURL: baseURL,
+		client:  &http.Client{Timeout: timeout},
+	}
+}
+
+// viaCEPResponse é o formato retornado pelo ViaCEP. O campo "erro" pode vir
+// como booleano ou como string dependendo da versão da API.
+type viaCEPResponse struct {
+	CEP         string `json:"cep"`
+	Logradouro  string `json:"logradouro"`
+	Complemento string `json:"complemento"`
+	Bairro      string `json:"bairro"`
+	Localidade  string `json:"localidade"`
+	UF          string `json:"uf"`
+	Erro        any    `json:"erro"`
+}
+
+// Lookup consulta um CEP no ViaCEP
+func (p *ViaCEPProvider) Lookup(cep string) (*Address, error) {
+	digits, err := Normalize(cep)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := p.client.Get(fmt.Sprintf("%s/%s/json/", p.baseURL, digits))
+	if err != nil {
+		return nil, fmt.Errorf("falha ao consultar o ViaCEP: %w", err)
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	// O ViaCEP responde 400 para CEPs com formato inválido
+	if resp.StatusCode == http.StatusBadRequest {
+		return nil, ErrInvalidCEP
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("ViaCEP retornou status %d", resp.StatusCode)
+	}
+
+	var body viaCEPResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("falha ao decodificar resposta do ViaCEP: %w", err)
+	}
+
+	if body.Erro == true || body.Erro == "true" {
+		return nil, ErrNotFound
+	}
+
+	return &Address{
+		CEP:        digits,
+		Street:     body.Logradouro,
+		Complement: body.Complemento,
+		District:   body.Bairro,
+		City:       body.Localidade,
+		State:      body.UF,
+	}, nil
+}
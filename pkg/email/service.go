@@ -5,6 +5,8 @@ import (
 	"bytes"
 	"fmt"
 	"html/template"
+	"io"
+	"time"
 
 	"gopkg.in/gomail.v2"
 )
@@ -81,14 +83,99 @@ func (s *Service) SendWelcomeEmail(to, name string) error {
 	return s.sendEmail(to, subject, body)
 }
 
+// ClassEmailData contém os dados da aula exibidos nos emails
+type ClassEmailData struct {
+	StudentName string
+	TeacherName string
+	Date        string
+	StartTime   string
+	EndTime     string
+	Timezone    string // Fuso em que Date/StartTime/EndTime estão expressos
+	Title       string
+	ClassLink   string
+	Calendar    []byte // Arquivo .ics da aula, enviado como anexo quando presente
+}
+
+// Attachment é um arquivo anexado ao email
+type Attachment struct {
+	Filename    string
+	ContentType string
+	Data        []byte
+}
+
+// CalendarAttachment monta o anexo .ics de um evento; retorna nil se data for vazio
+func CalendarAttachment(data []byte) []Attachment {
+	if len(data) == 0 {
+		return nil
+	}
+	return []Attachment{{Filename: "aula.ics", ContentType: "text/calendar; charset=utf-8; method=PUBLISH", Data: data}}
+}
+
+// SendClassScheduledEmail envia ao aluno o aviso de aula agendada
+func (s *Service) SendClassScheduledEmail(to string, class ClassEmailData) error {
+	data := map[string]any{
+		"StudentName": class.StudentName,
+		"TeacherName": class.TeacherName,
+		"Date":        class.Date,
+		"StartTime":   class.StartTime,
+		"EndTime":     class.EndTime,
+		"Timezone":    class.Timezone,
+		"Title":       class.Title,
+		"ClassLink":   class.ClassLink,
+	}
+
+	subject := "Nova Aula Agendada - Potential Idiomas"
+	body, err := s.renderTemplate("class_scheduled", data)
+	if err != nil {
+		return err
+	}
+
+	return s.sendEmail(to, subject, body, CalendarAttachment(class.Calendar)...)
+}
+
+// SendWaitlistOfferEmail avisa o aluno da lista de espera que uma vaga foi liberada
+func (s *Service) SendWaitlistOfferEmail(to string, class ClassEmailData, offerToken string, expiresAt time.Time) error {
+	confirmURL := fmt.Sprintf("%s/waitlist/confirm?token=%s", s.frontendURL, offerToken)
+
+	data := map[string]any{
+		"StudentName": class.StudentName,
+		"TeacherName": class.TeacherName,
+		"Date":        class.Date,
+		"StartTime":   class.StartTime,
+		"EndTime":     class.EndTime,
+		"Timezone":    class.Timezone,
+		"Title":       class.Title,
+		"ConfirmURL":  confirmURL,
+		"ExpiresAt":   expiresAt.Format("02/01/2006 15:04"),
+	}
+
+	subject := "Vaga Disponível - Potential Idiomas"
+	body, err := s.renderTemplate("waitlist_offer", data)
+	if err != nil {
+		return err
+	}
+
+	return s.sendEmail(to, subject, body, CalendarAttachment(class.Calendar)...)
+}
+
 // sendEmail envia um email usando SMTP
-func (s *Service) sendEmail(to, subject, body string) error {
+func (s *Service) sendEmail(to, subject, body string, attachments ...Attachment) error {
 	m := gomail.NewMessage()
 	m.SetHeader("From", s.fromEmail)
 	m.SetHeader("To", to)
 	m.SetHeader("Subject", subject)
 	m.SetBody("text/html", body)
 
+	for _, attachment := range attachments {
+		m.Attach(attachment.Filename,
+			gomail.SetHeader(map[string][]string{"Content-Type": {attachment.ContentType}}),
+			gomail.SetCopyFunc(func(w io.Writer) error {
+				_, err := w.Write(attachment.Data)
+				return err
+			}),
+		)
+	}
+
 	d := gomail.NewDialer(s.smtpHost, s.smtpPort, s.smtpUsername, s.smtpPassword)
 
 	if err := d.DialAndSend(m); err != nil {
@@ -199,7 +286,7 @@ func (s *Service) renderTemplate(templateName string, data map[string]any) (stri
         <p>Uma nova aula foi agendada para você.</p>
         <div style="background-color: #f8f9fa; padding: 20px; border-radius: 5px; margin: 20px 0;">
             <p style="margin: 5px 0;"><strong>Data:</strong> {{.Date}}</p>
-            <p style="margin: 5px 0;"><strong>Horário:</strong> {{.StartTime}} - {{.EndTime}}</p>
+            <p style="margin: 5px 0;"><strong>Horário:</strong> {{.StartTime}} - {{.EndTime}}{{if .Timezone}} ({{.Timezone}}){{end}}</p>
             <p style="margin: 5px 0;"><strong>Professor:</strong> {{.TeacherName}}</p>
             {{if .Title}}<p style="margin: 5px 0;"><strong>Tema:</strong> {{.Title}}</p>{{end}}
             {{if .ClassLink}}
@@ -218,6 +305,41 @@ func (s *Service) renderTemplate(templateName string, data map[string]any) (stri
     </div>
 </body>
 </html>
+`,
+		"waitlist_offer": `
+<!DOCTYPE html>
+<html>
+<head>
+    <meta charset="UTF-8">
+    <title>Vaga Disponível</title>
+</head>
+<body style="font-family: Arial, sans-serif; line-height: 1.6; color: #333;">
+    <div style="max-width: 600px; margin: 0 auto; padding: 20px;">
+        <h2 style="color: #27ae60;">Uma vaga foi liberada!</h2>
+        <p>Olá, {{.StudentName}}!</p>
+        <p>Abriu uma vaga na aula em que você estava na lista de espera:</p>
+        <div style="background-color: #f8f9fa; padding: 20px; border-radius: 5px; margin: 20px 0;">
+            <p style="margin: 5px 0;"><strong>Data:</strong> {{.Date}}</p>
+            <p style="margin: 5px 0;"><strong>Horário:</strong> {{.StartTime}} - {{.EndTime}}{{if .Timezone}} ({{.Timezone}}){{end}}</p>
+            <p style="margin: 5px 0;"><strong>Professor:</strong> {{.TeacherName}}</p>
+            {{if .Title}}<p style="margin: 5px 0;"><strong>Tema:</strong> {{.Title}}</p>{{end}}
+        </div>
+        <div style="text-align: center; margin: 30px 0;">
+            <a href="{{.ConfirmURL}}" 
+               style="background-color: #27ae60; color: white; padding: 12px 30px; 
+                      text-decoration: none; border-radius: 5px; display: inline-block;">
+                Confirmar Vaga
+            </a>
+        </div>
+        <p style="color: #e74c3c; font-size: 14px;">
+            Confirme até {{.ExpiresAt}}. Depois desse prazo a vaga passa para o próximo da fila.
+        </p>
+        <p style="word-break: break-all; color: #27ae60; font-size: 12px;">
+            {{.ConfirmURL}}
+        </p>
+    </div>
+</body>
+</html>
 `,
 		"class_cancelled": `
 <!DOCTYPE html>
@@ -233,7 +355,7 @@ func (s *Service) renderTemplate(templateName string, data map[string]any) (stri
         <p>Infelizmente, a aula abaixo foi cancelada:</p>
         <div style="background-color: #fee; padding: 20px; border-radius: 5px; margin: 20px 0; border-left: 4px solid #e74c3c;">
             <p style="margin: 5px 0;"><strong>Data:</strong> {{.Date}}</p>
-            <p style="margin: 5px 0;"><strong>Horário:</strong> {{.StartTime}} - {{.EndTime}}</p>
+            <p style="margin: 5px 0;"><strong>Horário:</strong> {{.StartTime}} - {{.EndTime}}{{if .Timezone}} ({{.Timezone}}){{end}}</p>
             <p style="margin: 5px 0;"><strong>Professor:</strong> {{.TeacherName}}</p>
             {{if .Title}}<p style="margin: 5px 0;"><strong>Tema:</strong> {{.Title}}</p>{{end}}
         </div>
@@ -266,6 +388,6 @@ func (s *Service) renderTemplate(templateName string, data map[string]any) (stri
 }
 
 // SendCustomEmail envia um email customizado com assunto e corpo HTML
-func (s *Service) SendCustomEmail(to, subject, htmlBody string) error {
-	return s.sendEmail(to, subject, htmlBody)
+func (s *Service) SendCustomEmail(to, subject, htmlBody string, attachments ...Attachment) error {
+	return s.sendEmail(to, subject, htmlBody, attachments...)
 }
@@ -0,0 +1,72 @@
+// Package holidays calcula os feriados nacionais brasileiros, inclusive os móveis
+package holidays
+
+import (
+	"sort"
+	"time"
+)
+
+// Holiday é um feriado em uma data (meia-noite UTC)
+type Holiday struct {
+	Date time.Time
+	Name string
+}
+
+// Easter retorna o domingo de Páscoa do ano (algoritmo de Meeus/Jones/Butcher)
+func Easter(year int) time.Time {
+	a := year % 19
+	b := year / 100
+	c := year % 100
+	d := b / 4
+	e := b % 4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i := c / 4
+	k := c % 4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	day := (h+l-7*m+114)%31 + 1
+
+	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+}
+
+// National retorna os feriados nacionais do ano em ordem de data. Carnaval
+// (ponto facultativo federal) entra porque a escola fecha nesses dias.
+func National(year int) []Holiday {
+	fixed := func(month time.Month, day int, name string) Holiday {
+		return Holiday{Date: time.Date(year, month, day, 0, 0, 0, 0, time.UTC), Name: name}
+	}
+
+	easter := Easter(year)
+	movable := func(offset int, name string) Holiday {
+		return Holiday{Date: easter.AddDate(0, 0, offset), Name: name}
+	}
+
+	list := []Holiday{
+		fixed(time.January, 1, "Confraternização Universal"),
+		movable(-48, "Carnaval (segunda-feira)"),
+		movable(-47, "Carnaval (terça-feira)"),
+		movable(-2, "Sexta-feira Santa"),
+		fixed(time.April, 21, "Tiradentes"),
+		fixed(time.May, 1, "Dia do Trabalho"),
+		movable(60, "Corpus Christi"),
+		fixed(time.September, 7, "Independência do Brasil"),
+		fixed(time.October, 12, "Nossa Senhora Aparecida"),
+		fixed(time.November, 2, "Finados"),
+		fixed(time.November, 15, "Proclamação da República"),
+		fixed(time.December, 25, "Natal"),
+	}
+
+	// Lei 14.759/2023 tornou o 20 de novembro feriado nacional a partir de 2024
+	if year >= 2024 {
+		list = append(list, fixed(time.November, 20, "Dia Nacional de Zumbi e da Consciência Negra"))
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Date.Before(list[j].Date)
+	})
+
+	return list
+}
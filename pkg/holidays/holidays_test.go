@@ -0,0 +1,60 @@
+package holidays
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEaster(t *testing.T) {
+	t.Run("should compute known Easter dates", func(t *testing.T) {
+		cases := map[int]string{
+			2000: "2000-04-23",
+			2019: "2019-04-21",
+			2024: "2024-03-31",
+			2025: "2025-04-20",
+			2026: "2026-04-05",
+			2038: "2038-04-25",
+		}
+
+		for year, expected := range cases {
+			assert.Equal(t, expected, Easter(year).Format("2006-01-02"), "year %d", year)
+		}
+	})
+}
+
+func TestNational(t *testing.T) {
+	find := func(list []Holiday, name string) string {
+		for _, h := range list {
+			if h.Name == name {
+				return h.Date.Format("2006-01-02")
+			}
+		}
+		return ""
+	}
+
+	t.Run("should compute movable holidays from Easter", func(t *testing.T) {
+		list := National(2025)
+
+		assert.Equal(t, "2025-03-03", find(list, "Carnaval (segunda-feira)"))
+		assert.Equal(t, "2025-03-04", find(list, "Carnaval (terça-feira)"))
+		assert.Equal(t, "2025-04-18", find(list, "Sexta-feira Santa"))
+		assert.Equal(t, "2025-06-19", find(list, "Corpus Christi"))
+	})
+
+	t.Run("should include Consciência Negra only from 2024", func(t *testing.T) {
+		assert.Empty(t, find(National(2023), "Dia Nacional de Zumbi e da Consciência Negra"))
+		assert.Equal(t, "2024-11-20", find(National(2024), "Dia Nacional de Zumbi e da Consciência Negra"))
+	})
+
+	t.Run("should return holidays sorted by date", func(t *testing.T) {
+		list := National(2026)
+
+		assert.Len(t, list, 13)
+		for i := 1; i < len(list); i++ {
+			assert.False(t, list[i].Date.Before(list[i-1].Date))
+		}
+		assert.Equal(t, "Confraternização Universal", list[0].Name)
+		assert.Equal(t, "Natal", list[len(list)-1].Name)
+	})
+}
@@ -0,0 +1,142 @@
+// Package ical gera calendários no formato iCalendar (RFC 5545)
+package ical
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ContentType é o tipo MIME de arquivos .ics
+const ContentType = "text/calendar; charset=utf-8"
+
+// maxLineOctets é o tamanho máximo de uma linha antes da dobra (RFC 5545, 3.1)
+const maxLineOctets = 75
+
+const utcLayout = "20060102T150405Z"
+
+// Status representa o STATUS de um VEVENT
+type Status string
+
+const (
+	StatusConfirmed Status = "CONFIRMED"
+	StatusTentative Status = "TENTATIVE"
+	StatusCancelled Status = "CANCELLED"
+)
+
+// Event é um VEVENT. Os horários são gravados em UTC.
+type Event struct {
+	UID          string
+	Start        time.Time
+	End          time.Time
+	Summary      string
+	Description  string
+	Location     string
+	URL          string
+	Status       Status
+	Sequence     int // Incrementa a cada alteração para que os clientes substituam a versão anterior
+	Created      time.Time
+	LastModified time.Time
+}
+
+// Calendar é um VCALENDAR com seus eventos
+type Calendar struct {
+	ProdID string // Ex.: "-//Potential Idiomas//Aulas//PT"
+	Name   string // X-WR-CALNAME, exibido pelos clientes de calendário
+	Method string // Ex.: "PUBLISH"; vazio omite a propriedade
+	Events []Event
+}
+
+// Encode serializa o calendário com linhas terminadas em CRLF e dobradas em 75 octetos.
+// stamp é o DTSTAMP dos eventos (momento em que o arquivo foi gerado).
+func (c Calendar) Encode(stamp time.Time) []byte {
+	var buf bytes.Buffer
+
+	writeLine(&buf, "BEGIN:VCALENDAR")
+	writeLine(&buf, "VERSION:2.0")
+	writeLine(&buf, "PRODID:"+c.ProdID)
+	writeLine(&buf, "CALSCALE:GREGORIAN")
+	if c.Method != "" {
+		writeLine(&buf, "METHOD:"+c.Method)
+	}
+	if c.Name != "" {
+		writeLine(&buf, "X-WR-CALNAME:"+EscapeText(c.Name))
+	}
+
+	for _, event := range c.Events {
+		writeLine(&buf, "BEGIN:VEVENT")
+		writeLine(&buf, "UID:"+event.UID)
+		writeLine(&buf, "DTSTAMP:"+FormatTime(stamp))
+		writeLine(&buf, "DTSTART:"+FormatTime(event.Start))
+		writeLine(&buf, "DTEND:"+FormatTime(event.End))
+		writeLine(&buf, "SUMMARY:"+EscapeText(event.Summary))
+		if event.Description != "" {
+			writeLine(&buf, "DESCRIPTION:"+EscapeText(event.Description))
+		}
+		if event.Location != "" {
+			writeLine(&buf, "LOCATION:"+EscapeText(event.Location))
+		}
+		if event.URL != "" {
+			writeLine(&buf, "URL:"+event.URL)
+		}
+		if event.Status != "" {
+			writeLine(&buf, "STATUS:"+string(event.Status))
+		}
+		writeLine(&buf, "SEQUENCE:"+strconv.Itoa(event.Sequence))
+		if !event.Created.IsZero() {
+			writeLine(&buf, "CREATED:"+FormatTime(event.Created))
+		}
+		if !event.LastModified.IsZero() {
+			writeLine(&buf, "LAST-MODIFIED:"+FormatTime(event.LastModified))
+		}
+		writeLine(&buf, "END:VEVENT")
+	}
+
+	writeLine(&buf, "END:VCALENDAR")
+
+	return buf.Bytes()
+}
+
+// FormatTime formata o instante em UTC ("20250115T170000Z")
+func FormatTime(t time.Time) string {
+	return t.UTC().Format(utcLayout)
+}
+
+// EscapeText escapa barras, vírgulas, ponto e vírgula e quebras de linha de valores TEXT
+func EscapeText(value string) string {
+	replacer := strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+		"\r", `\n`,
+	)
+	return replacer.Replace(value)
+}
+
+// writeLine grava a linha dobrando-a a cada 75 octetos sem quebrar caracteres UTF-8.
+// As linhas de continuação começam com um espaço, que conta no limite.
+func writeLine(buf *bytes.Buffer, line string) {
+	limit := maxLineOctets
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !isRuneStart(line[cut]) {
+			cut--
+		}
+
+		buf.WriteString(line[:cut])
+		buf.WriteString("\r\n ")
+		line = line[cut:]
+		limit = maxLineOctets - 1
+	}
+
+	buf.WriteString(line)
+	buf.WriteString("\r\n")
+}
+
+// isRuneStart indica se o byte inicia um caractere UTF-8
+func isRuneStart(b byte) bool {
+	return b&0xC0 != 0x80
+}
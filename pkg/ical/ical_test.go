@@ -0,0 +1,71 @@
+package ical
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEscapeText(t *testing.T) {
+	t.Run("should escape special characters", func(t *testing.T) {
+		assert.Equal(t, `Aula\, nível B1\; tema: viagens\nLeve o livro \\ caderno`,
+			EscapeText("Aula, nível B1; tema: viagens\nLeve o livro \\ caderno"))
+	})
+}
+
+func TestEncode(t *testing.T) {
+	saoPaulo := time.FixedZone("BRT", -3*60*60)
+	stamp := time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC)
+
+	calendar := Calendar{
+		ProdID: "-//Potential Idiomas//Aulas//PT",
+		Name:   "Minhas aulas",
+		Method: "PUBLISH",
+		Events: []Event{
+			{
+				UID:     "class-1@potential",
+				Start:   time.Date(2025, 1, 15, 14, 0, 0, 0, saoPaulo),
+				End:     time.Date(2025, 1, 15, 15, 0, 0, 0, saoPaulo),
+				Summary: "Inglês B1",
+				URL:     "https://meet.example.com/abc",
+				Status:  StatusCancelled,
+			},
+		},
+	}
+
+	t.Run("should use CRLF line endings and UTC times", func(t *testing.T) {
+		out := string(calendar.Encode(stamp))
+
+		assert.True(t, strings.HasPrefix(out, "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n"))
+		assert.True(t, strings.HasSuffix(out, "END:VCALENDAR\r\n"))
+		assert.Contains(t, out, "DTSTART:20250115T170000Z\r\n")
+		assert.Contains(t, out, "DTEND:20250115T180000Z\r\n")
+		assert.Contains(t, out, "DTSTAMP:20250110T120000Z\r\n")
+		assert.Contains(t, out, "STATUS:CANCELLED\r\n")
+		assert.Contains(t, out, "URL:https://meet.example.com/abc\r\n")
+		assert.Contains(t, out, "METHOD:PUBLISH\r\n")
+		assert.NotContains(t, strings.ReplaceAll(out, "\r\n", ""), "\n")
+	})
+
+	t.Run("should fold long lines at 75 octets without splitting characters", func(t *testing.T) {
+		long := calendar
+		long.Events = []Event{{
+			UID:         "class-2@potential",
+			Start:       stamp,
+			End:         stamp.Add(time.Hour),
+			Summary:     "Aula",
+			Description: strings.Repeat("ção ", 40),
+		}}
+
+		out := string(long.Encode(stamp))
+		for _, line := range strings.Split(strings.TrimSuffix(out, "\r\n"), "\r\n") {
+			assert.LessOrEqual(t, len(line), 75)
+			assert.True(t, strings.ToValidUTF8(line, "?") == line, "line splits a character: %q", line)
+		}
+
+		unfolded := strings.ReplaceAll(out, "\r\n ", "")
+		assert.Contains(t, unfolded, "DESCRIPTION:"+strings.Repeat("ção ", 40)+"\r\n")
+	})
+}
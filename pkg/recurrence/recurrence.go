@@ -0,0 +1,91 @@
+// Package recurrence expande regras de repetição semanais (subconjunto do RRULE)
+package recurrence
+
+import (
+	"errors"
+	"time"
+)
+
+// MaxOccurrences limita o número de datas geradas por uma regra
+const MaxOccurrences = 366
+
+var (
+	ErrNoWeekdays  = errors.New("informe ao menos um dia da semana")
+	ErrNoEnd       = errors.New("informe a data final ou o número de ocorrências")
+	ErrUntilBefore = errors.New("a data final é anterior à data inicial")
+	ErrTooMany     = errors.New("a regra gera ocorrências demais")
+)
+
+// Rule equivale a FREQ=WEEKLY;BYDAY=...;UNTIL=... ou COUNT=...
+// Quando Until e Count são informados, vale o que terminar primeiro.
+type Rule struct {
+	Weekdays []time.Weekday
+	Start    time.Time
+	Until    *time.Time
+	Count    int
+}
+
+// Validate verifica se a regra é finita e bem formada
+func (r Rule) Validate() error {
+	if len(r.Weekdays) == 0 {
+		return ErrNoWeekdays
+	}
+
+	if r.Until == nil && r.Count <= 0 {
+		return ErrNoEnd
+	}
+
+	if r.Until != nil && dateOnly(*r.Until).Before(dateOnly(r.Start)) {
+		return ErrUntilBefore
+	}
+
+	if r.Count > MaxOccurrences {
+		return ErrTooMany
+	}
+
+	return nil
+}
+
+// Dates retorna as datas (meia-noite UTC) que seguem a regra, em ordem crescente
+func (r Rule) Dates() ([]time.Time, error) {
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+
+	weekdays := make(map[time.Weekday]bool, len(r.Weekdays))
+	for _, weekday := range r.Weekdays {
+		weekdays[weekday] = true
+	}
+
+	var until time.Time
+	if r.Until != nil {
+		until = dateOnly(*r.Until)
+	}
+
+	var dates []time.Time
+	for day := dateOnly(r.Start); ; day = day.AddDate(0, 0, 1) {
+		if r.Until != nil && day.After(until) {
+			break
+		}
+
+		if r.Count > 0 && len(dates) == r.Count {
+			break
+		}
+
+		if !weekdays[day.Weekday()] {
+			continue
+		}
+
+		if len(dates) == MaxOccurrences {
+			return nil, ErrTooMany
+		}
+
+		dates = append(dates, day)
+	}
+
+	return dates, nil
+}
+
+func dateOnly(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
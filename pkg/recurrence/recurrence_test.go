@@ -0,0 +1,107 @@
+package recurrence
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func date(s string) time.Time {
+	t, _ := time.Parse("2006-01-02", s)
+	return t
+}
+
+func formatDates(dates []time.Time) []string {
+	formatted := make([]string, 0, len(dates))
+	for _, d := range dates {
+		formatted = append(formatted, d.Format("2006-01-02"))
+	}
+	return formatted
+}
+
+func TestRuleDates(t *testing.T) {
+	t.Run("should generate weekly dates until the end date", func(t *testing.T) {
+		until := date("2025-03-19")
+		rule := Rule{
+			Weekdays: []time.Weekday{time.Monday, time.Wednesday},
+			Start:    date("2025-03-03"),
+			Until:    &until,
+		}
+
+		dates, err := rule.Dates()
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{
+			"2025-03-03", "2025-03-05",
+			"2025-03-10", "2025-03-12",
+			"2025-03-17", "2025-03-19",
+		}, formatDates(dates))
+	})
+
+	t.Run("should stop after count occurrences", func(t *testing.T) {
+		rule := Rule{
+			Weekdays: []time.Weekday{time.Tuesday},
+			Start:    date("2025-03-01"),
+			Count:    3,
+		}
+
+		dates, err := rule.Dates()
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"2025-03-04", "2025-03-11", "2025-03-18"}, formatDates(dates))
+	})
+
+	t.Run("should stop at whichever end comes first", func(t *testing.T) {
+		until := date("2025-03-10")
+		rule := Rule{
+			Weekdays: []time.Weekday{time.Tuesday},
+			Start:    date("2025-03-01"),
+			Until:    &until,
+			Count:    10,
+		}
+
+		dates, err := rule.Dates()
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"2025-03-04"}, formatDates(dates))
+	})
+
+	t.Run("should ignore the time of day", func(t *testing.T) {
+		rule := Rule{
+			Weekdays: []time.Weekday{time.Monday},
+			Start:    time.Date(2025, 3, 3, 23, 30, 0, 0, time.UTC),
+			Count:    1,
+		}
+
+		dates, err := rule.Dates()
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"2025-03-03"}, formatDates(dates))
+	})
+}
+
+func TestRuleValidate(t *testing.T) {
+	t.Run("should require weekdays", func(t *testing.T) {
+		_, err := Rule{Start: date("2025-03-01"), Count: 1}.Dates()
+		assert.ErrorIs(t, err, ErrNoWeekdays)
+	})
+
+	t.Run("should require an end", func(t *testing.T) {
+		_, err := Rule{Weekdays: []time.Weekday{time.Monday}, Start: date("2025-03-01")}.Dates()
+		assert.ErrorIs(t, err, ErrNoEnd)
+	})
+
+	t.Run("should reject until before start", func(t *testing.T) {
+		until := date("2025-02-01")
+		_, err := Rule{Weekdays: []time.Weekday{time.Monday}, Start: date("2025-03-01"), Until: &until}.Dates()
+		assert.ErrorIs(t, err, ErrUntilBefore)
+	})
+
+	t.Run("should reject rules that generate too many dates", func(t *testing.T) {
+		until := date("2035-01-01")
+		_, err := Rule{Weekdays: []time.Weekday{time.Monday, time.Tuesday}, Start: date("2025-01-01"), Until: &until}.Dates()
+		assert.ErrorIs(t, err, ErrTooMany)
+	})
+}
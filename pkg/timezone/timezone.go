@@ -0,0 +1,116 @@
+// Package timezone converte datas e horários de parede em instantes usando fusos IANA
+package timezone
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	// Embute a base de fusos para não depender do tzdata do sistema
+	_ "time/tzdata"
+)
+
+// Default é o fuso usado quando a escola ou o usuário não informam outro
+const Default = "America/Sao_Paulo"
+
+var (
+	ErrInvalidZone    = errors.New("fuso horário inválido (use um nome IANA, ex.: America/Sao_Paulo)")
+	ErrInvalidClock   = errors.New("horário inválido (use HH:MM ou HH:MM:SS)")
+	ErrNonexistent    = errors.New("o horário não existe nesta data por causa do horário de verão")
+	ErrEndBeforeStart = errors.New("o horário de término deve ser posterior ao de início")
+)
+
+// Load retorna o fuso IANA informado. Nomes vazios e "Local" são rejeitados para
+// que o resultado não dependa da configuração do servidor.
+func Load(name string) (*time.Location, error) {
+	if name == "" || name == "Local" {
+		return nil, ErrInvalidZone
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, ErrInvalidZone
+	}
+	return loc, nil
+}
+
+// Clock é um horário de parede sem data nem fuso
+type Clock struct {
+	Hour   int
+	Minute int
+	Second int
+}
+
+// ParseClock aceita "HH:MM" ou "HH:MM:SS"
+func ParseClock(value string) (Clock, error) {
+	for _, layout := range []string{"15:04:05", "15:04"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return Clock{Hour: t.Hour(), Minute: t.Minute(), Second: t.Second()}, nil
+		}
+	}
+	return Clock{}, ErrInvalidClock
+}
+
+// ClockOf retorna o horário de parede de t no fuso de t
+func ClockOf(t time.Time) Clock {
+	return Clock{Hour: t.Hour(), Minute: t.Minute(), Second: t.Second()}
+}
+
+// String retorna o horário no formato "HH:MM:SS" usado pelas colunas TIME
+func (c Clock) String() string {
+	return fmt.Sprintf("%02d:%02d:%02d", c.Hour, c.Minute, c.Second)
+}
+
+// Combine retorna o instante em que o relógio de parede marca clock na data informada
+// (apenas ano, mês e dia são usados) no fuso loc.
+// Horários pulados na entrada do horário de verão retornam ErrNonexistent; horários
+// repetidos na saída resolvem para a primeira ocorrência.
+func Combine(date time.Time, clock Clock, loc *time.Location) (time.Time, error) {
+	wall := time.Date(date.Year(), date.Month(), date.Day(), clock.Hour, clock.Minute, clock.Second, 0, time.UTC)
+
+	// Os candidatos são os deslocamentos vigentes antes e depois do horário pedido;
+	// fusos não mudam mais de uma vez no mesmo dia.
+	var result time.Time
+	for _, probe := range []time.Duration{-12 * time.Hour, 12 * time.Hour} {
+		_, offset := wall.Add(probe).In(loc).Zone()
+		candidate := wall.Add(-time.Duration(offset) * time.Second).In(loc)
+
+		if !sameWall(candidate, wall) {
+			continue
+		}
+		if result.IsZero() || candidate.Before(result) {
+			result = candidate
+		}
+	}
+
+	if result.IsZero() {
+		return time.Time{}, ErrNonexistent
+	}
+	return result, nil
+}
+
+// Span retorna início e fim de um intervalo de parede na data e fuso informados
+func Span(date time.Time, start, end Clock, loc *time.Location) (time.Time, time.Time, error) {
+	startsAt, err := Combine(date, start, loc)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	endsAt, err := Combine(date, end, loc)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	if !endsAt.After(startsAt) {
+		return time.Time{}, time.Time{}, ErrEndBeforeStart
+	}
+
+	return startsAt, endsAt, nil
+}
+
+// sameWall compara o relógio de parede de t (no fuso de t) com wall (em UTC)
+func sameWall(t, wall time.Time) bool {
+	y1, m1, d1 := t.Date()
+	y2, m2, d2 := wall.Date()
+	return y1 == y2 && m1 == m2 && d1 == d2 && ClockOf(t) == ClockOf(wall)
+}
@@ -0,0 +1,99 @@
+package timezone
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func date(value string) time.Time {
+	d, _ := time.Parse("2006-01-02", value)
+	return d
+}
+
+func TestLoad(t *testing.T) {
+	t.Run("should load IANA zones", func(t *testing.T) {
+		loc, err := Load("America/New_York")
+		require.NoError(t, err)
+		assert.Equal(t, "America/New_York", loc.String())
+	})
+
+	t.Run("should reject empty, Local and unknown zones", func(t *testing.T) {
+		for _, name := range []string{"", "Local", "Mars/Olympus"} {
+			_, err := Load(name)
+			assert.ErrorIs(t, err, ErrInvalidZone, name)
+		}
+	})
+}
+
+func TestParseClock(t *testing.T) {
+	t.Run("should accept HH:MM and HH:MM:SS", func(t *testing.T) {
+		clock, err := ParseClock("09:30")
+		require.NoError(t, err)
+		assert.Equal(t, "09:30:00", clock.String())
+
+		clock, err = ParseClock("14:05:10")
+		require.NoError(t, err)
+		assert.Equal(t, "14:05:10", clock.String())
+	})
+
+	t.Run("should reject invalid values", func(t *testing.T) {
+		for _, value := range []string{"", "25:00", "9h", "14:60:00"} {
+			_, err := ParseClock(value)
+			assert.ErrorIs(t, err, ErrInvalidClock, value)
+		}
+	})
+}
+
+func TestCombine(t *testing.T) {
+	newYork, err := Load("America/New_York")
+	require.NoError(t, err)
+
+	t.Run("should use the offset in effect on the date", func(t *testing.T) {
+		winter, err := Combine(date("2025-01-15"), Clock{Hour: 10}, newYork)
+		require.NoError(t, err)
+		assert.Equal(t, "2025-01-15T15:00:00Z", winter.UTC().Format(time.RFC3339))
+
+		summer, err := Combine(date("2025-07-15"), Clock{Hour: 10}, newYork)
+		require.NoError(t, err)
+		assert.Equal(t, "2025-07-15T14:00:00Z", summer.UTC().Format(time.RFC3339))
+	})
+
+	t.Run("should reject wall times skipped by DST", func(t *testing.T) {
+		_, err := Combine(date("2025-03-09"), Clock{Hour: 2, Minute: 30}, newYork)
+		assert.ErrorIs(t, err, ErrNonexistent)
+	})
+
+	t.Run("should resolve repeated wall times to the first occurrence", func(t *testing.T) {
+		instant, err := Combine(date("2025-11-02"), Clock{Hour: 1, Minute: 30}, newYork)
+		require.NoError(t, err)
+		assert.Equal(t, "2025-11-02T05:30:00Z", instant.UTC().Format(time.RFC3339))
+	})
+
+	t.Run("should handle zones without DST", func(t *testing.T) {
+		saoPaulo, err := Load(Default)
+		require.NoError(t, err)
+
+		instant, err := Combine(date("2025-11-02"), Clock{Hour: 14}, saoPaulo)
+		require.NoError(t, err)
+		assert.Equal(t, "2025-11-02T17:00:00Z", instant.UTC().Format(time.RFC3339))
+	})
+}
+
+func TestSpan(t *testing.T) {
+	newYork, err := Load("America/New_York")
+	require.NoError(t, err)
+
+	t.Run("should measure real duration across a DST change", func(t *testing.T) {
+		start, end, err := Span(date("2025-11-02"), Clock{Hour: 0, Minute: 30}, Clock{Hour: 2}, newYork)
+		require.NoError(t, err)
+		assert.Equal(t, 150*time.Minute, end.Sub(start))
+	})
+
+	t.Run("should reject end before start", func(t *testing.T) {
+		_, _, err := Span(date("2025-01-15"), Clock{Hour: 11}, Clock{Hour: 10}, newYork)
+		assert.ErrorIs(t, err, ErrEndBeforeStart)
+	})
+}
@@ -2,20 +2,32 @@
 package validator
 
 import (
+	"errors"
 	"regexp"
 	"strings"
 
 	"github.com/go-playground/validator/v10"
 )
 
+// ErrInvalidPhone indica um telefone que não pode ser normalizado para E.164
+var ErrInvalidPhone = errors.New("número de telefone inválido")
+
 var validate *validator.Validate
 
+var nonDigits = regexp.MustCompile(`[^0-9]`)
+
 func init() {
 	validate = validator.New()
 
 	// Registrar validações customizadas
-	_ = validate.RegisterValidation("cpf", validateCPF)
-	_ = validate.RegisterValidation("phone", validatePhone)
+	RegisterCustomValidations(validate)
+}
+
+// RegisterCustomValidations registra as validações customizadas (cpf, phone) em
+// outra instância do validator, como a usada pelo ShouldBindJSON do gin
+func RegisterCustomValidations(v *validator.Validate) {
+	_ = v.RegisterValidation("cpf", validateCPF)
+	_ = v.RegisterValidation("phone", validatePhone)
 }
 
 // Validate valida uma struct usando as tags de validação
@@ -30,10 +42,18 @@ func GetValidator() *validator.Validate {
 
 // validateCPF valida um CPF brasileiro
 func validateCPF(fl validator.FieldLevel) bool {
-	cpf := fl.Field().String()
+	return IsValidCPF(fl.Field().String())
+}
+
+// NormalizeCPF remove a formatação do CPF ("123.456.789-09" -> "12345678909")
+func NormalizeCPF(cpf string) string {
+	return nonDigits.ReplaceAllString(cpf, "")
+}
 
+// IsValidCPF valida os dígitos verificadores de um CPF, com ou sem formatação
+func IsValidCPF(cpf string) bool {
 	// Remove caracteres não numéricos
-	cpf = regexp.MustCompile(`[^0-9]`).ReplaceAllString(cpf, "")
+	cpf = NormalizeCPF(cpf)
 
 	if len(cpf) != 11 {
 		return false
@@ -80,16 +100,70 @@ func validateCPF(fl validator.FieldLevel) bool {
 	return true
 }
 
-// validatePhone valida um telefone brasileiro
+// validatePhone valida um telefone brasileiro ou internacional (E.164)
 func validatePhone(fl validator.FieldLevel) bool {
-	phone := fl.Field().String()
+	_, err := NormalizePhone(fl.Field().String())
+	return err == nil
+}
 
-	// Remove caracteres não numéricos
-	phone = regexp.MustCompile(`[^0-9]`).ReplaceAllString(phone, "")
+// NormalizePhone converte um telefone para o formato E.164 ("+5511987654321").
+// Números sem código de país são tratados como brasileiros:
+// fixo com 10 dígitos (DDD + 8) ou celular com 11 dígitos (DDD + 9 + 8).
+// Números iniciados por "+" com outro código de país são aceitos com 8 a 15 dígitos.
+func NormalizePhone(phone string) (string, error) {
+	phone = strings.TrimSpace(phone)
+	international := strings.HasPrefix(phone, "+") || strings.HasPrefix(phone, "00")
+
+	digits := nonDigits.ReplaceAllString(phone, "")
+	if strings.HasPrefix(phone, "00") {
+		digits = strings.TrimPrefix(digits, "00")
+	}
+
+	if international {
+		if strings.HasPrefix(digits, "55") {
+			return normalizeBrazilianPhone(digits[2:])
+		}
+		if len(digits) < 8 || len(digits) > 15 {
+			return "", ErrInvalidPhone
+		}
+		return "+" + digits, nil
+	}
+
+	// Prefixo de discagem interurbana: "0" + DDD + número
+	if strings.HasPrefix(digits, "0") && (len(digits) == 11 || len(digits) == 12) {
+		digits = digits[1:]
+	}
+
+	// Código do país sem "+": 55 + DDD + número
+	if strings.HasPrefix(digits, "55") && (len(digits) == 12 || len(digits) == 13) {
+		digits = digits[2:]
+	}
+
+	return normalizeBrazilianPhone(digits)
+}
+
+// normalizeBrazilianPhone valida DDD + número e retorna em E.164
+func normalizeBrazilianPhone(national string) (string, error) {
+	if len(national) != 10 && len(national) != 11 {
+		return "", ErrInvalidPhone
+	}
+
+	// DDDs vão de 11 a 99 e não possuem o dígito 0
+	if national[0] == '0' || national[1] == '0' {
+		return "", ErrInvalidPhone
+	}
+
+	// Celular: o primeiro dígito após o DDD é 9
+	if len(national) == 11 && national[2] != '9' {
+		return "", ErrInvalidPhone
+	}
+
+	// Fixo: o primeiro dígito após o DDD vai de 2 a 5
+	if len(national) == 10 && (national[2] < '2' || national[2] > '5') {
+		return "", ErrInvalidPhone
+	}
 
-	// Telefone fixo: 10 dígitos (DDD + 8 dígitos)
-	// Celular: 11 dígitos (DDD + 9 dígitos)
-	return len(phone) == 10 || len(phone) == 11
+	return "+55" + national, nil
 }
 
 // FormatValidationErrors formata erros de validação para mensagens amigáveis em português
@@ -0,0 +1,80 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsValidCPF(t *testing.T) {
+	t.Run("should accept valid cpf with and without formatting", func(t *testing.T) {
+		assert.True(t, IsValidCPF("52998224725"))
+		assert.True(t, IsValidCPF("529.982.247-25"))
+	})
+
+	t.Run("should reject wrong check digits", func(t *testing.T) {
+		assert.False(t, IsValidCPF("52998224724"))
+	})
+
+	t.Run("should reject repeated digits", func(t *testing.T) {
+		assert.False(t, IsValidCPF("11111111111"))
+	})
+}
+
+func TestNormalizePhone(t *testing.T) {
+	valid := map[string]string{
+		"(11) 98765-4321":   "+5511987654321",
+		"11987654321":       "+5511987654321",
+		"+55 11 98765-4321": "+5511987654321",
+		"5511987654321":     "+5511987654321",
+		"011 98765-4321":    "+5511987654321",
+		"(21) 3456-7890":    "+552134567890",
+		"+1 415 555 2671":   "+14155552671",
+		"0044 20 7946 0958": "+442079460958",
+	}
+
+	for input, expected := range valid {
+		normalized, err := NormalizePhone(input)
+
+		require.NoError(t, err, input)
+		assert.Equal(t, expected, normalized, input)
+	}
+
+	invalid := []string{
+		"",
+		"12345",
+		"(11) 8765-43210", // celular sem o 9
+		"(01) 98765-4321", // DDD inválido
+		"(11) 9876-5432",  // fixo começando com 9
+		"+1234567",
+	}
+
+	for _, input := range invalid {
+		_, err := NormalizePhone(input)
+
+		assert.ErrorIs(t, err, ErrInvalidPhone, input)
+	}
+}
+
+func TestRegisterCustomValidations(t *testing.T) {
+	type payload struct {
+		CPF   string `binding:"required,cpf"`
+		Phone string `binding:"omitempty,phone"`
+	}
+
+	// Mesma configuração do validator usado pelo gin
+	engine := validator.New()
+	engine.SetTagName("binding")
+	RegisterCustomValidations(engine)
+
+	require.NoError(t, engine.Struct(payload{CPF: "52998224725", Phone: "(11) 98765-4321"}))
+
+	err := engine.Struct(payload{CPF: "12345678900", Phone: "123"})
+	require.Error(t, err)
+
+	details := FormatValidationErrors(err)
+	assert.Equal(t, "CPF inválido", details["cpf"])
+	assert.Equal(t, "Número de telefone inválido", details["phone"])
+}